@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/fakemarket"
+)
+
+// fakemarket serves deterministic Lazada and Shopee affiliate API responses for local development.
+// Point the API at it with:
+//
+//	LAZADA_API_GATEWAY=http://localhost:9090/rest
+//	SHOPEE_API_ENDPOINT=http://localhost:9090/graphql
+func main() {
+	addr := flag.String("addr", "localhost:9090", "address to listen on")
+	fixturesPath := flag.String("fixtures", "", "JSON fixtures file (defaults to the built-in catalogue)")
+	flag.Parse()
+
+	fixtures := fakemarket.DefaultFixtures()
+	if *fixturesPath != "" {
+		var err error
+		fixtures, err = fakemarket.LoadFixtures(*fixturesPath)
+		if err != nil {
+			log.Fatalf("Failed to load fixtures: %v", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Addr:    *addr,
+		Handler: fakemarket.NewServer(fixtures),
+	}
+	log.Printf("Fake marketplace listening at %s\n", *addr)
+	log.Printf("LAZADA_API_GATEWAY=%s SHOPEE_API_ENDPOINT=%s\n", fakemarket.LazadaGateway("http://"+*addr), fakemarket.ShopeeEndpoint("http://"+*addr))
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("listen: %s\n", err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Fatal("Server forced to shutdown: ", err)
+	}
+}
@@ -13,6 +13,11 @@ func NewHttpServer(
 	campaignHandler *handlers.CampaignHandler,
 	linkHandler *handlers.LinkHandler,
 	dashboardHandler *handlers.DashboardHandler,
+	tagHandler *handlers.TagHandler,
+	collectionHandler *handlers.CollectionHandler,
+	storefrontHandler *handlers.StorefrontHandler,
+	postbackHandler *handlers.PostbackHandler,
+	clickStreamHandler *handlers.ClickStreamHandler,
 ) *gin.Engine {
 	// gin.SetMode(gin.ReleaseMode)
 	g := gin.Default()
@@ -24,8 +29,9 @@ func NewHttpServer(
 		}
 		// c.Writer.Header().Set("Vary", "Origin")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, If-None-Match")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -42,6 +48,8 @@ func NewHttpServer(
 	g.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	g.GET("/go/:short_code", linkHandler.RedirectLink)
+	g.GET("/postback", postbackHandler.RecordPostback)
+	g.POST("/postback", postbackHandler.RecordPostback)
 
 	api := g.Group("/api")
 	apiV1 := api.Group("/v1")
@@ -53,32 +61,75 @@ func NewHttpServer(
 	v1UserGroup.POST("/market-credential", userHandler.VerifyAndGetUserId, userHandler.SaveMarketplaceCredential)
 	v1UserGroup.GET("/market-credential/:platform", userHandler.VerifyAndGetUserId, userHandler.CheckMarketplaceCredential)
 	v1UserGroup.DELETE("/market-credential/:platform", userHandler.VerifyAndGetUserId, userHandler.DeleteMarketplaceCredential)
+	v1UserGroup.PUT("/link-policy", userHandler.VerifyAndGetUserId, userHandler.UpdateLinkPolicy)
+	v1UserGroup.POST("/postback-secret", userHandler.VerifyAndGetUserId, userHandler.RotatePostbackSecret)
 
 	v1ProductGroup := apiV1.Group("product")
 	v1ProductGroup.GET("/:productId", productHandler.GetProductById)
 	v1ProductGroup.Use(userHandler.VerifyAndGetUserId)
 	v1ProductGroup.POST("", productHandler.AddProduct)
 	v1ProductGroup.GET("", productHandler.GetProducts)
+	v1ProductGroup.GET("/unavailable", productHandler.GetUnavailableProducts)
 	v1ProductGroup.GET("/:productId/offer", productHandler.GetOffers)
 	v1ProductGroup.DELETE("/:productId", productHandler.DeleteProduct)
+	v1ProductGroup.POST("/:productId/restore", productHandler.RestoreProduct)
+	v1ProductGroup.POST("/:productId/refresh", productHandler.RefreshProduct)
+	v1ProductGroup.POST("/:productId/tag/:tag_id", tagHandler.AddProductTag)
+	v1ProductGroup.DELETE("/:productId/tag/:tag_id", tagHandler.RemoveProductTag)
+
+	v1TagGroup := apiV1.Group("tag")
+	v1TagGroup.Use(userHandler.VerifyAndGetUserId)
+	v1TagGroup.POST("", tagHandler.CreateTag)
+	v1TagGroup.GET("", tagHandler.GetTags)
+	v1TagGroup.PUT("/:tag_id", tagHandler.UpdateTag)
+	v1TagGroup.DELETE("/:tag_id", tagHandler.DeleteTag)
+
+	v1CollectionGroup := apiV1.Group("collection")
+	v1CollectionGroup.Use(userHandler.VerifyAndGetUserId)
+	v1CollectionGroup.POST("", collectionHandler.CreateCollection)
+	v1CollectionGroup.GET("", collectionHandler.GetCollections)
+	v1CollectionGroup.GET("/:collection_id", collectionHandler.GetCollectionById)
+	v1CollectionGroup.PUT("/:collection_id", collectionHandler.UpdateCollection)
+	v1CollectionGroup.DELETE("/:collection_id", collectionHandler.DeleteCollection)
+	v1CollectionGroup.POST("/:collection_id/product/:productId", collectionHandler.AddCollectionProduct)
+	v1CollectionGroup.DELETE("/:collection_id/product/:productId", collectionHandler.RemoveCollectionProduct)
+	v1CollectionGroup.POST("/:collection_id/link", collectionHandler.CreateCollectionLinks)
 
 	v1CampaignGroup := apiV1.Group("campaign")
 	v1CampaignGroup.GET("/available", campaignHandler.GetPublicCampaigns)
 	v1CampaignGroup.Use(userHandler.VerifyAndGetUserId)
 	v1CampaignGroup.POST("", campaignHandler.CreateCampaign)
 	v1CampaignGroup.GET("", campaignHandler.GetCampaigns)
+	v1CampaignGroup.PATCH("/:campaign_id", campaignHandler.UpdateCampaign)
+	v1CampaignGroup.POST("/:campaign_id/clone", campaignHandler.CloneCampaign)
+	v1CampaignGroup.PUT("/:campaign_id/state", campaignHandler.ChangeCampaignState)
+	v1CampaignGroup.PUT("/:campaign_id/goal", campaignHandler.SetCampaignGoal)
+	v1CampaignGroup.GET("/:campaign_id/progress", campaignHandler.GetCampaignProgress)
+	v1CampaignGroup.GET("/:campaign_id/report", campaignHandler.GetCampaignReport)
+	v1CampaignGroup.GET("/:campaign_id/report/export", campaignHandler.ExportCampaignReport)
 	v1CampaignGroup.DELETE("/:campaign_id", campaignHandler.DeleteCampaign)
+	v1CampaignGroup.POST("/:campaign_id/restore", campaignHandler.RestoreCampaign)
 
 	v1LinkGroup := apiV1.Group("link")
 	v1LinkGroup.POST("", userHandler.VerifyAndGetUserId, linkHandler.CreateLink)
 	v1LinkGroup.GET("/campaign/:campaignId", linkHandler.GetLinksByCampaign)
+	v1LinkGroup.GET("/campaign/:campaignId/export", userHandler.VerifyAndGetUserId, linkHandler.ExportCampaignLinks)
 	v1LinkGroup.DELETE("/:link_id", userHandler.VerifyAndGetUserId, linkHandler.DeleteLink)
 	v1LinkGroup.GET("/:link_id", linkHandler.GetLinkById)
 	v1LinkGroup.GET("/short-code/:short_code", linkHandler.GetLinkByShortCode)
 	v1LinkGroup.GET("/redirect/:short_code", linkHandler.RedirectLink)
 
+	v1StorefrontGroup := apiV1.Group("storefront")
+	v1StorefrontGroup.GET("/campaign/:campaign_id", storefrontHandler.GetCampaignStorefront)
+	v1StorefrontGroup.GET("/user/:user_id", storefrontHandler.GetUserStorefront)
+
 	v1DashboardGroup := apiV1.Group("dashboard")
 	v1DashboardGroup.GET("/metrics", userHandler.VerifyAndGetUserId, dashboardHandler.GetDashboardData)
+	v1DashboardGroup.GET("/leaderboards", userHandler.VerifyAndGetUserId, dashboardHandler.GetDashboardLeaderboards)
+	v1DashboardGroup.GET("/breakdown", userHandler.VerifyAndGetUserId, dashboardHandler.GetDashboardBreakdown)
+	v1DashboardGroup.GET("/metrics/export", userHandler.VerifyAndGetUserId, dashboardHandler.ExportDashboardMetrics)
+	v1DashboardGroup.GET("/clicks/export", userHandler.VerifyAndGetUserId, dashboardHandler.ExportClickLog)
+	v1DashboardGroup.GET("/clicks/stream", userHandler.VerifyAndGetUserId, clickStreamHandler.StreamClicks)
 
 	return g
 }
@@ -8,15 +8,17 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // CAMPAIGN_TIMEZONE must load on hosts without a zoneinfo database
 
 	"github.com/market-place-affiliate/api/cmd/httpserver"
 	"github.com/market-place-affiliate/api/config"
 	infrastructure "github.com/market-place-affiliate/api/infrastructures"
+	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/market-place-affiliate/api/internal/core/services"
 	"github.com/market-place-affiliate/api/internal/handlers"
 	"github.com/market-place-affiliate/api/internal/repositories/db"
-	"github.com/market-place-affiliate/commonlib/lazada"
-	"github.com/market-place-affiliate/commonlib/shopee"
+	"github.com/market-place-affiliate/api/internal/repositories/marketplace"
+	"github.com/market-place-affiliate/api/pkg/eventbus"
 
 	_ "github.com/market-place-affiliate/api/docs" // Swagger docs
 )
@@ -54,21 +56,60 @@ func main() {
 	offerRepository := db.NewOfferRepository(postgresClient)
 	marketplaceCredentialRepository := db.NewMarketplaceCredentialRepository(postgresClient)
 	clickRepository := db.NewClickRepository(postgresClient)
+	tagRepository := db.NewTagRepository(postgresClient)
+	collectionRepository := db.NewCollectionRepository(postgresClient)
+	clickRollupRepository := db.NewClickRollupRepository(postgresClient)
+	conversionRepository := db.NewConversionRepository(postgresClient)
+
+	lazadaClients := marketplace.NewLazadaClients(cfg.Marketplace.LazadaApiGateway, cfg.Marketplace.Debug)
+	shopeeClients := marketplace.NewShopeeClients(cfg.Marketplace.ShopeeApiEndpoint, cfg.Marketplace.Debug)
+	marketplaceRegistry := marketplace.NewRegistry(
+		marketplace.NewLazadaProvider(lazadaClients),
+		marketplace.NewShopeeProvider(shopeeClients),
+	)
 
-	lazadaRepository := lazada.NewLazadaRepository(lazada.ApiGatewayTH, true)
-	shopeeRepository := shopee.NewShopeeRepository(true)
-
-	userService := services.NewUserService(string(cfg.Secret.PasswordSecret), string(cfg.Secret.JWTSecret), userRepository, marketplaceCredentialRepository)
-	productService := services.NewProductService(productRepository, offerRepository, lazadaRepository, shopeeRepository, marketplaceCredentialRepository, linkRepository, clickRepository)
-	campaignService := services.NewCampaignService(campaignRepository, linkRepository, clickRepository)
-	linkService := services.NewLinkService(linkRepository, clickRepository, productRepository, campaignRepository, offerRepository, lazadaRepository, shopeeRepository, marketplaceCredentialRepository)
-	dashboardService := services.NewDashboardService(clickRepository, productRepository)
+	campaignLocation, err := time.LoadLocation(cfg.Campaign.Timezone)
+	if err != nil {
+		log.Fatalf("Failed to load campaign timezone: %v", err)
+	}
+	eventBus := eventbus.New()
+	eventBus.Subscribe(domains.TopicCampaignStateChanged, func(ctx context.Context, payload any) {
+		event := payload.(domains.CampaignStateChanged)
+		log.Printf("Campaign %s: %s -> %s\n", event.CampaignId, event.From, event.To)
+	})
+	eventBus.Subscribe(domains.TopicCampaignGoalThreshold, func(ctx context.Context, payload any) {
+		event := payload.(domains.CampaignGoalThresholdReached)
+		log.Printf("Campaign %s reached %d%% of its %s goal\n", event.CampaignId, event.Threshold, event.Metric)
+	})
+
+	urlResolver := marketplace.NewUrlResolver(marketplace.UrlResolverConfig{
+		AllowedHosts:   cfg.Resolver.AllowedHosts,
+		ShortLinkHosts: cfg.Resolver.ShortLinkHosts,
+		MaxHops:        cfg.Resolver.MaxHops,
+		Timeout:        cfg.Resolver.Timeout,
+	})
+
+	userService := services.NewUserService(string(cfg.Secret.PasswordSecret), string(cfg.Secret.JWTSecret), userRepository, marketplaceCredentialRepository, marketplaceRegistry)
+	productService := services.NewProductService(productRepository, offerRepository, marketplaceRegistry, urlResolver, marketplaceCredentialRepository, linkRepository, clickRepository)
+	linkService := services.NewLinkService(linkRepository, clickRepository, productRepository, campaignRepository, offerRepository, userRepository, marketplaceRegistry, marketplaceCredentialRepository, eventBus)
+	campaignService := services.NewCampaignService(campaignRepository, linkRepository, clickRepository, linkService, eventBus, campaignLocation)
+	dashboardService := services.NewDashboardService(clickRepository, conversionRepository, productRepository, campaignLocation)
+	tagService := services.NewTagService(tagRepository, productRepository)
+	collectionService := services.NewCollectionService(collectionRepository, productRepository, linkService)
+	storefrontService := services.NewStorefrontService(campaignRepository, linkRepository, productRepository, offerRepository, cfg.Storefront.PublicBaseUrl)
+	postbackService := services.NewPostbackService(clickRepository, linkRepository, campaignRepository, offerRepository, userRepository, conversionRepository)
+	clickStreamService := services.NewClickStreamService(campaignRepository, linkRepository, eventBus)
 
 	userHandler := handlers.NewUserHandler(userService)
 	productHandler := handlers.NewProductHandler(productService)
 	campaignHandler := handlers.NewCampaignHandler(campaignService)
 	linkHandler := handlers.NewLinkHandler(linkService)
 	dashboardHandler := handlers.NewDashboardHandler(dashboardService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	collectionHandler := handlers.NewCollectionHandler(collectionService)
+	storefrontHandler := handlers.NewStorefrontHandler(storefrontService, cfg.Storefront.CacheMaxAge)
+	postbackHandler := handlers.NewPostbackHandler(postbackService)
+	clickStreamHandler := handlers.NewClickStreamHandler(clickStreamService, cfg.Stream.Heartbeat)
 
 	httpServer := httpserver.NewHttpServer(
 		userHandler,
@@ -76,11 +117,38 @@ func main() {
 		campaignHandler,
 		linkHandler,
 		dashboardHandler,
+		tagHandler,
+		collectionHandler,
+		storefrontHandler,
+		postbackHandler,
+		clickStreamHandler,
 	)
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	campaignScheduler := services.NewCampaignScheduler(campaignRepository, eventBus, campaignLocation, cfg.Campaign.SchedulerInterval)
+	go campaignScheduler.Run(ctx)
+	campaignGoalMonitor := services.NewCampaignGoalMonitor(campaignRepository, clickRepository, eventBus, cfg.Campaign.SchedulerInterval)
+	go campaignGoalMonitor.Run(ctx)
+	retentionPurger := services.NewRetentionPurger(campaignRepository, productRepository, linkRepository, cfg.Retention.DeletedRetention, cfg.Retention.PurgeInterval)
+	go retentionPurger.Run(ctx)
+	clickRollup := services.NewClickRollup(clickRollupRepository, campaignLocation, cfg.Rollup.Interval)
+	go clickRollup.Run(ctx)
+	conversionImporter := services.NewConversionImporter(marketplaceCredentialRepository, conversionRepository, linkRepository, campaignRepository, marketplaceRegistry, cfg.Conversion.Lookback, cfg.Conversion.ImportInterval)
+	go conversionImporter.Run(ctx)
+	if cfg.Stream.Redis {
+		redisClient := infrastructure.NewRedis(cfg.Redis.Host, cfg.Redis.Port, cfg.Redis.DB, cfg.Redis.Username, cfg.Redis.Password)
+		go func() {
+			err := eventbus.Relay(ctx, eventBus, redisClient, cfg.Stream.RedisChannel, map[string]eventbus.Decoder{
+				domains.TopicClickRecorded: eventbus.DecodeAs[domains.ClickRecorded](),
+			})
+			if err != nil {
+				log.Printf("Event relay stopped: %v\n", err)
+			}
+		}()
+	}
+
 	srv := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", cfg.HTTPServer.Host, cfg.HTTPServer.Port),
 		Handler: httpServer,
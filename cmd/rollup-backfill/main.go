@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // CAMPAIGN_TIMEZONE must load on hosts without a zoneinfo database
+
+	"github.com/market-place-affiliate/api/config"
+	infrastructure "github.com/market-place-affiliate/api/infrastructures"
+	"github.com/market-place-affiliate/api/internal/core/services"
+	"github.com/market-place-affiliate/api/internal/repositories/db"
+)
+
+// rollup-backfill rebuilds the click rollups read by the dashboard and reports from raw clicks,
+// for instance after deploying them on a database that already has clicks. The API keeps them
+// up to date from then on.
+func main() {
+	from := flag.String("from", "", "first day to rebuild, YYYY-MM-DD in CAMPAIGN_TIMEZONE (defaults to the first click)")
+	to := flag.String("to", "", "day to stop before, YYYY-MM-DD in CAMPAIGN_TIMEZONE (defaults to now)")
+	reset := flag.Bool("reset", false, "delete every rollup first, e.g. after changing CAMPAIGN_TIMEZONE")
+	flag.Parse()
+
+	cfg := config.Init()
+	location, err := time.LoadLocation(cfg.Campaign.Timezone)
+	if err != nil {
+		log.Fatalf("Failed to load campaign timezone: %v", err)
+	}
+	var fromTime, toTime time.Time
+	if *from != "" {
+		if fromTime, err = time.ParseInLocation(time.DateOnly, *from, location); err != nil {
+			log.Fatalf("Invalid -from: %v", err)
+		}
+	}
+	if *to != "" {
+		if toTime, err = time.ParseInLocation(time.DateOnly, *to, location); err != nil {
+			log.Fatalf("Invalid -to: %v", err)
+		}
+	}
+
+	postgresClient := infrastructure.NewPostgresDB(cfg.DB.Host, cfg.DB.Port, cfg.DB.Username, cfg.DB.Password, cfg.DB.DbName)
+	if err := db.Migrate(postgresClient); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	rollupRepository := db.NewClickRollupRepository(postgresClient)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if *reset {
+		if err := rollupRepository.ResetRollups(ctx); err != nil {
+			log.Fatalf("Failed to reset rollups: %v", err)
+		}
+	}
+	started := time.Now()
+	if err := services.NewClickRollup(rollupRepository, location, 0).Backfill(ctx, fromTime, toTime); err != nil {
+		log.Fatalf("Failed to backfill rollups: %v", err)
+	}
+	log.Printf("Rolled up clicks in %s\n", time.Since(started).Round(time.Millisecond))
+}
@@ -2,16 +2,25 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 )
 
 type config struct {
-	HTTPServer httpServer
-	DB         DB
-	Redis      redis
-	Secret     secret
+	HTTPServer  httpServer
+	DB          DB
+	Redis       redis
+	Secret      secret
+	Marketplace marketplace
+	Resolver    resolver
+	Campaign    campaign
+	Storefront  storefront
+	Retention   retention
+	Rollup      rollup
+	Conversion  conversion
+	Stream      stream
 }
 
 type httpServer struct {
@@ -38,6 +47,61 @@ type secret struct {
 	JWTSecret      []byte `envconfig:"JWT_SECRET"`
 }
 
+// marketplace points the affiliate API clients somewhere other than production, e.g. cmd/fakemarket.
+// When set, the gateway and endpoint are used for every region instead of the regional production APIs.
+type marketplace struct {
+	LazadaApiGateway  string `envconfig:"LAZADA_API_GATEWAY" firestore:"lazada_api_gateway"`
+	ShopeeApiEndpoint string `envconfig:"SHOPEE_API_ENDPOINT" firestore:"shopee_api_endpoint"`
+	Debug             bool   `envconfig:"MARKETPLACE_DEBUG" default:"true" firestore:"marketplace_debug"`
+}
+
+// resolver controls how pasted share links are expanded before product import.
+type resolver struct {
+	AllowedHosts   []string      `envconfig:"RESOLVER_ALLOWED_HOSTS" default:"shopee.co.th,shopee.com.my,shopee.vn,shopee.ph,shopee.sg,shopee.co.id,shope.ee,shp.ee,lazada.co.th,lazada.com.my,lazada.vn,lazada.com.ph,lazada.sg,lazada.co.id" firestore:"resolver_allowed_hosts"`
+	ShortLinkHosts []string      `envconfig:"RESOLVER_SHORT_LINK_HOSTS" default:"s.shopee.co.th,s.shopee.com.my,s.shopee.vn,s.shopee.ph,s.shopee.sg,s.shopee.co.id,shope.ee,shp.ee,s.lazada.co.th,s.lazada.com.my,s.lazada.vn,s.lazada.com.ph,s.lazada.sg,s.lazada.co.id" firestore:"resolver_short_link_hosts"`
+	MaxHops        int           `envconfig:"RESOLVER_MAX_HOPS" default:"5" firestore:"resolver_max_hops"`
+	Timeout        time.Duration `envconfig:"RESOLVER_TIMEOUT" default:"5s" firestore:"resolver_timeout"`
+}
+
+// campaign controls when the scheduler moves campaigns between states.
+type campaign struct {
+	Timezone          string        `envconfig:"CAMPAIGN_TIMEZONE" default:"Asia/Bangkok" firestore:"campaign_timezone"`
+	SchedulerInterval time.Duration `envconfig:"CAMPAIGN_SCHEDULER_INTERVAL" default:"1m" firestore:"campaign_scheduler_interval"`
+}
+
+// storefront controls the public campaign pages.
+type storefront struct {
+	// PublicBaseUrl is where this API is reachable by shoppers; short links are built on it.
+	PublicBaseUrl string        `envconfig:"PUBLIC_BASE_URL" default:"http://localhost:8080" firestore:"public_base_url"`
+	CacheMaxAge   time.Duration `envconfig:"STOREFRONT_CACHE_MAX_AGE" default:"5m" firestore:"storefront_cache_max_age"`
+}
+
+// retention controls how long soft deleted campaigns, products and links can be restored.
+type retention struct {
+	DeletedRetention time.Duration `envconfig:"DELETED_RETENTION" default:"720h" firestore:"deleted_retention"`
+	PurgeInterval    time.Duration `envconfig:"PURGE_INTERVAL" default:"1h" firestore:"purge_interval"`
+}
+
+// rollup controls how often clicks are rolled up for the dashboard and reports.
+type rollup struct {
+	Interval time.Duration `envconfig:"ROLLUP_INTERVAL" default:"1m" firestore:"rollup_interval"`
+}
+
+// conversion controls how often marketplace conversion reports are imported and how far back.
+type conversion struct {
+	ImportInterval time.Duration `envconfig:"CONVERSION_IMPORT_INTERVAL" default:"1h" firestore:"conversion_import_interval"`
+	Lookback       time.Duration `envconfig:"CONVERSION_LOOKBACK" default:"720h" firestore:"conversion_lookback"`
+}
+
+// stream controls the live click stream.
+type stream struct {
+	// Redis relays clicks between instances through Redis pub/sub, for deployments running more
+	// than one; a single instance streams its own clicks without it.
+	Redis        bool          `envconfig:"STREAM_REDIS" default:"false" firestore:"stream_redis"`
+	RedisChannel string        `envconfig:"STREAM_REDIS_CHANNEL" default:"marketplace-affiliate:events" firestore:"stream_redis_channel"`
+	Heartbeat    time.Duration `envconfig:"STREAM_HEARTBEAT" default:"15s" firestore:"stream_heartbeat"`
+}
+
 func Init() config {
 	var cfg config
 
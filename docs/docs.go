@@ -44,6 +44,66 @@ const docTemplate = `{
                         "description": "UTM campaign filter",
                         "name": "utm_campaign",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "scheduled",
+                            "active",
+                            "paused",
+                            "ended",
+                            "archived"
+                        ],
+                        "type": "string",
+                        "description": "State filter",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "List soft deleted campaigns instead",
+                        "name": "deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page; replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "start_at",
+                            "end_at",
+                            "name",
+                            "clicks"
+                        ],
+                        "type": "string",
+                        "description": "Sort field (default start_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default desc)",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -119,7 +179,7 @@ const docTemplate = `{
         },
         "/campaign/available": {
             "get": {
-                "description": "Get all public campaigns available for everyone",
+                "description": "Get active campaigns of every user",
                 "produces": [
                     "application/json"
                 ],
@@ -133,6 +193,45 @@ const docTemplate = `{
                         "description": "UTM campaign filter",
                         "name": "utm_campaign",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page; replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "start_at",
+                            "end_at",
+                            "name"
+                        ],
+                        "type": "string",
+                        "description": "Sort field (default start_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default desc)",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -145,7 +244,7 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.EmptyResponse"
                         }
                     }
                 }
@@ -158,7 +257,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a campaign and all associated links and clicks",
+                "description": "Soft delete a campaign and its links. It can be restored until the retention period has passed; clicks stay in analytics.",
                 "produces": [
                     "application/json"
                 ],
@@ -201,53 +300,53 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/dashboard/metrics": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get dashboard analytics including clicks, products, and performance metrics",
+                "description": "Update the name, UTM campaign or dates of a campaign. Only the fields sent are changed. Set regenerate_links to refresh the affiliate urls of existing links when utm_campaign changes.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "dashboard"
+                    "campaign"
                 ],
-                "summary": "Get dashboard metrics",
+                "summary": "Update campaign",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "\"7 days ago\"",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "start_at",
-                        "in": "query"
+                        "description": "Campaign ID",
+                        "name": "campaign_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "default": "\"tomorrow\"",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "end_at",
-                        "in": "query"
+                        "description": "Campaign fields to update",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCampaignRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.DashboardResponse"
+                            "$ref": "#/definitions/dto.CampaignUpdateResult"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.EmptyResponse"
                         }
                     },
                     "401": {
@@ -255,18 +354,24 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
                     }
                 }
             }
         },
-        "/link": {
+        "/campaign/{campaign_id}/clone": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new affiliate link for a product and campaign",
+                "description": "Copy a campaign under new dates and UTM campaign, creating a new affiliate link for each of its products. Products whose link could not be created are listed in failures.",
                 "consumes": [
                     "application/json"
                 ],
@@ -274,17 +379,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "link"
+                    "campaign"
                 ],
-                "summary": "Create affiliate link",
+                "summary": "Clone a campaign",
                 "parameters": [
                     {
-                        "description": "Link request",
+                        "type": "string",
+                        "description": "Campaign ID",
+                        "name": "campaign_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clone request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateLinkRequest"
+                            "$ref": "#/definitions/dto.CloneCampaignRequest"
                         }
                     }
                 ],
@@ -292,13 +404,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.LinkResponse"
+                            "$ref": "#/definitions/dto.CampaignCloneResult"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.EmptyResponse"
                         }
                     },
                     "401": {
@@ -312,99 +424,79 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.EmptyResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Campaign not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
                     }
                 }
             }
         },
-        "/link/campaign/{campaignId}": {
-            "get": {
-                "description": "Get all links associated with a campaign",
+        "/campaign/{campaign_id}/goal": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the click, unique visitor and estimated commission targets of a campaign. Notify thresholds are attainment percentages that publish an event the first time they are reached.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "link"
+                    "campaign"
                 ],
-                "summary": "Get links by campaign",
+                "summary": "Set campaign goal",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Campaign ID",
-                        "name": "campaignId",
+                        "name": "campaign_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Campaign goal",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CampaignGoalRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.LinksResponse"
+                            "$ref": "#/definitions/dto.CampaignGoalResult"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/dto.EmptyResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/link/redirect/{short_code}": {
-            "get": {
-                "description": "Track click and redirect to the marketplace affiliate link",
-                "tags": [
-                    "link"
-                ],
-                "summary": "Redirect to affiliate link",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Short code",
-                        "name": "short_code",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "302": {
-                        "description": "Redirect to affiliate URL",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            }
-        },
-        "/link/short-code/{short_code}": {
-            "get": {
-                "description": "Get a link by its short code",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "link"
-                ],
-                "summary": "Get link by short code",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Short code",
-                        "name": "short_code",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/dto.LinkResponse"
+                            "$ref": "#/definitions/dto.EmptyResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/dto.EmptyResponse"
                         }
@@ -412,21 +504,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/link/{link_id}": {
+        "/campaign/{campaign_id}/progress": {
             "get": {
-                "description": "Get a specific link by its ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get attainment and pacing of each goal target from the campaign's clicks so far",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "link"
+                    "campaign"
                 ],
-                "summary": "Get link by ID",
+                "summary": "Get campaign goal progress",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Link ID",
-                        "name": "link_id",
+                        "description": "Campaign ID",
+                        "name": "campaign_id",
                         "in": "path",
                         "required": true
                     }
@@ -435,51 +532,88 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.LinkResponse"
+                            "$ref": "#/definitions/dto.CampaignProgressResult"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Campaign not found or has no goal",
                         "schema": {
                             "$ref": "#/definitions/dto.EmptyResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/campaign/{campaign_id}/report": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a link and all associated clicks",
+                "description": "Get a campaign's click totals, daily series and per link and per product breakdown for a range of days in the campaign timezone. Clicks on deleted links are included.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "link"
+                    "campaign"
                 ],
-                "summary": "Delete link",
+                "summary": "Get campaign report",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Link ID",
-                        "name": "link_id",
+                        "description": "Campaign ID",
+                        "name": "campaign_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD), default the campaign start date",
+                        "name": "start_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD), default today or the campaign end date",
+                        "name": "end_at",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "previous_period",
+                            "previous_month",
+                            "previous_year"
+                        ],
+                        "type": "string",
+                        "description": "Also report a comparison period and the change per link and product",
+                        "name": "compare",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.EmptyResponse"
+                            "$ref": "#/definitions/dto.CampaignReportResult"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.EmptyResponse"
                         }
                     },
                     "401": {
@@ -493,30 +627,87 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.EmptyResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Campaign not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
                     }
                 }
             }
         },
-        "/product": {
+        "/campaign/{campaign_id}/report/export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all products for the authenticated user",
+                "description": "Download a campaign report as CSV or XLSX, with the same filters as /campaign/{campaign_id}/report: the daily series, links and products and, with compare, the comparison period. XLSX puts each in its own sheet; CSV separates them with an empty line.",
                 "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "campaign"
+                ],
+                "summary": "Export campaign report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign ID",
+                        "name": "campaign_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD), default the campaign start date",
+                        "name": "start_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD), default today or the campaign end date",
+                        "name": "end_at",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "previous_period",
+                            "previous_month",
+                            "previous_year"
+                        ],
+                        "type": "string",
+                        "description": "Also export a comparison period and the change per link and product",
+                        "name": "compare",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get user products",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "campaign-report.csv or .xlsx",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProductsResponse"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
                         }
                     },
                     "401": {
@@ -524,48 +715,57 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Campaign not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/campaign/{campaign_id}/restore": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new affiliate product from marketplace URL",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Restore a soft deleted campaign and the links deleted with it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "campaign"
                 ],
-                "summary": "Add a new product",
+                "summary": "Restore campaign",
                 "parameters": [
                     {
-                        "description": "Product request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateProductRequest"
-                        }
+                        "type": "string",
+                        "description": "Campaign ID",
+                        "name": "campaign_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProductsResponse"
+                            "$ref": "#/definitions/dto.CampaignResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Campaign is not deleted",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.EmptyResponse"
                         }
                     },
                     "401": {
@@ -573,70 +773,67 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            }
-        },
-        "/product/{productId}": {
-            "get": {
-                "description": "Get a specific product by its ID",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "product"
-                ],
-                "summary": "Get product by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Product ID",
-                        "name": "productId",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProductResponse"
+                            "$ref": "#/definitions/dto.EmptyResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Campaign not found",
                         "schema": {
                             "$ref": "#/definitions/dto.EmptyResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/campaign/{campaign_id}/state": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a product and all associated links and clicks",
+                "description": "Move a campaign to draft, paused, ended or archived, or publish/resume it with scheduled. Published campaigns become active and ended as their dates pass.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "campaign"
                 ],
-                "summary": "Delete product",
+                "summary": "Change campaign state",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
-                        "name": "productId",
+                        "description": "Campaign ID",
+                        "name": "campaign_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Target state",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CampaignStateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CampaignResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/dto.EmptyResponse"
                         }
@@ -656,35 +853,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/{productId}/offer": {
+        "/collection": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get marketplace offers for a specific product",
+                "description": "Get all collections of the authenticated user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product"
-                ],
-                "summary": "Get product offers",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Product ID",
-                        "name": "productId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "collection"
                 ],
+                "summary": "Get user collections",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.OfferResponse"
+                            "$ref": "#/definitions/dto.CollectionsResponse"
                         }
                     },
                     "401": {
@@ -692,19 +880,16 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/dto.EmptyResponse"
-                        }
                     }
                 }
-            }
-        },
-        "/user/login": {
+            },
             "post": {
-                "description": "Authenticate user and return session token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a named, ordered list of products",
                 "consumes": [
                     "application/json"
                 ],
@@ -712,17 +897,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "collection"
                 ],
-                "summary": "Login user",
+                "summary": "Create a collection",
                 "parameters": [
                     {
-                        "description": "Login request",
+                        "description": "Collection request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginRequest"
+                            "$ref": "#/definitions/dto.CollectionRequest"
                         }
                     }
                 ],
@@ -730,17 +915,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.StringResponse"
+                            "$ref": "#/definitions/dto.CollectionResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/dto.EmptyResponse"
+                            "type": "string"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.EmptyResponse"
                         }
@@ -748,37 +939,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/logout": {
-            "post": {
-                "description": "Clear session cookie",
+        "/collection/{collection_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a collection with its products in order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "collection"
+                ],
+                "summary": "Get collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Logout user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.CollectionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
                         }
                     }
                 }
-            }
-        },
-        "/user/market-credential": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Save or update marketplace API credentials (Lazada/Shopee)",
+                "description": "Rename a collection and, when product_ids is given, replace its products in the given order",
                 "consumes": [
                     "application/json"
                 ],
@@ -786,17 +998,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "collection"
                 ],
-                "summary": "Save marketplace credentials",
+                "summary": "Update collection",
                 "parameters": [
                     {
-                        "description": "Marketplace credential",
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Collection request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.MarketplaceCredentialRequest"
+                            "$ref": "#/definitions/dto.CollectionRequest"
                         }
                     }
                 ],
@@ -804,7 +1023,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.EmptyResponse"
+                            "$ref": "#/definitions/dto.CollectionResponse"
                         }
                     },
                     "400": {
@@ -818,30 +1037,34 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/user/market-credential/{platform}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Check if marketplace credentials exist for a platform",
+                "description": "Delete a collection. The products themselves are kept.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "collection"
                 ],
-                "summary": "Check marketplace credentials",
+                "summary": "Delete collection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Platform name (lazada/shopee)",
-                        "name": "platform",
+                        "description": "Collection ID",
+                        "name": "collection_id",
                         "in": "path",
                         "required": true
                     }
@@ -850,7 +1073,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.BoolResponse"
+                            "$ref": "#/definitions/dto.EmptyResponse"
                         }
                     },
                     "401": {
@@ -858,37 +1081,63 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "security": [
+            }
+        },
+        "/collection/{collection_id}/link": {
+            "post": {
+                "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete stored marketplace credentials for a platform",
+                "description": "Create an affiliate link for every product in the collection against a campaign. Products that fail are reported in failures.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "collection"
                 ],
-                "summary": "Delete marketplace credentials",
+                "summary": "Create links for a collection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Platform name (lazada/shopee)",
-                        "name": "platform",
+                        "description": "Collection ID",
+                        "name": "collection_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Campaign to link against",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCollectionLinksRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.EmptyResponse"
+                            "$ref": "#/definitions/dto.BulkLinkResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "401": {
@@ -896,30 +1145,103 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/me": {
-            "get": {
+        "/collection/{collection_id}/product/{productId}": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get authenticated user information",
+                "description": "Append a product to the end of a collection",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "collection"
+                ],
+                "summary": "Add product to collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get current user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.UserResponse"
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a product from a collection",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collection"
+                ],
+                "summary": "Remove product from collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "collection_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
                         }
                     },
                     "401": {
@@ -927,39 +1249,203 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/register": {
-            "post": {
-                "description": "Create a new user account",
-                "consumes": [
-                    "application/json"
+        "/dashboard/breakdown": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Group the clicks of a range by one dimension of their visitor and rank its values, with the change of the sort metric since a comparison period. Sources are the social network the click came from (by referrer, or in-app browser when there is none), direct or other; referrers are domains. Values the visitor did not send have an empty key.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "dashboard"
                 ],
-                "summary": "Register a new user",
+                "summary": "Get a dashboard breakdown",
                 "parameters": [
                     {
-                        "description": "Register request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
+                        "enum": [
+                            "source",
+                            "referrer",
+                            "device",
+                            "os",
+                            "browser",
+                            "language"
+                        ],
+                        "type": "string",
+                        "description": "Dimension to group by",
+                        "name": "dimension",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"7 days ago\"",
+                        "description": "Start date (YYYY-MM-DD, midnight in tz) or RFC 3339 time",
+                        "name": "start_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"tomorrow\"",
+                        "description": "Exclusive end date (YYYY-MM-DD, midnight in tz) or RFC 3339 time",
+                        "name": "end_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "CAMPAIGN_TIMEZONE",
+                        "description": "IANA time zone, e.g. Asia/Bangkok",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the clicks of this campaign",
+                        "name": "campaign_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the clicks of this link",
+                        "name": "link_id",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Values to list",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "clicks",
+                            "unique_visitors"
+                        ],
+                        "type": "string",
+                        "default": "clicks",
+                        "description": "Metric to rank by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "previous_period",
+                            "previous_month",
+                            "previous_year"
+                        ],
+                        "type": "string",
+                        "default": "previous_period",
+                        "description": "Period the deltas compare with",
+                        "name": "compare",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.RegisterRequest"
+                            "$ref": "#/definitions/dto.DashboardBreakdownResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DashboardBreakdownResult"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
                         }
                     }
+                }
+            }
+        },
+        "/dashboard/clicks/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download every click in a range as CSV or XLSX, streamed as it is read. The range works like in /dashboard/metrics.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/json"
+                ],
+                "tags": [
+                    "dashboard"
+                ],
+                "summary": "Export click log",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"7 days ago\"",
+                        "description": "Start date (YYYY-MM-DD, midnight in tz) or RFC 3339 time",
+                        "name": "start_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"tomorrow\"",
+                        "description": "Exclusive end date (YYYY-MM-DD, midnight in tz) or RFC 3339 time",
+                        "name": "end_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "CAMPAIGN_TIMEZONE",
+                        "description": "IANA time zone of the range and click times",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only clicks of this campaign",
+                        "name": "campaign_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only clicks of this link",
+                        "name": "link_id",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "clicks.csv or .xlsx",
                         "schema": {
-                            "$ref": "#/definitions/dto.StringResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -967,168 +1453,3457 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.EmptyResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domains.Campaign": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "end_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "start_at": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
+        },
+        "/dashboard/clicks/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of the clicks on the user's links as they are recorded, optionally only those of one campaign or link. Every click is a ` + "`" + `click` + "`" + ` event whose data is a domains.ClickRecorded. Clicks are not replayed: the stream starts with the clicks after it is opened, and a client that falls far behind misses clicks rather than slowing redirects down.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "dashboard"
+                ],
+                "summary": "Stream clicks live",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign ID",
+                        "name": "campaign_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link ID",
+                        "name": "link_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domains.ClickRecorded"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboard/leaderboards": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rank the most clicked products, links, campaigns and marketplaces in a range, with the change of the sort metric since a comparison period. Boards are empty when nothing was clicked.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dashboard"
+                ],
+                "summary": "Get dashboard leaderboards",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "\"7 days ago\"",
+                        "description": "Start date (YYYY-MM-DD, midnight in tz) or RFC 3339 time",
+                        "name": "start_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"tomorrow\"",
+                        "description": "Exclusive end date (YYYY-MM-DD, midnight in tz) or RFC 3339 time",
+                        "name": "end_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "CAMPAIGN_TIMEZONE",
+                        "description": "IANA time zone, e.g. Asia/Bangkok",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 50,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Entries per leaderboard",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "clicks",
+                            "unique_visitors"
+                        ],
+                        "type": "string",
+                        "default": "clicks",
+                        "description": "Metric to rank by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "previous_period",
+                            "previous_month",
+                            "previous_year"
+                        ],
+                        "type": "string",
+                        "default": "previous_period",
+                        "description": "Period the deltas compare with",
+                        "name": "compare",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DashboardLeaderboardsResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DashboardLeaderboardsResult"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboard/metrics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get dashboard analytics including clicks, products, and performance metrics. Clicks are bucketed by granularity in the tz timezone, and every bucket of the range is returned, with zero clicks when empty. Buckets also count imported conversions with their sales and revenue, and performance gives each series' conversion rate and earnings per click.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dashboard"
+                ],
+                "summary": "Get dashboard metrics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "\"7 days ago\"",
+                        "description": "Start date (YYYY-MM-DD, midnight in tz) or RFC 3339 time",
+                        "name": "start_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"tomorrow\"",
+                        "description": "Exclusive end date (YYYY-MM-DD, midnight in tz) or RFC 3339 time",
+                        "name": "end_at",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hour",
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Bucket size",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "CAMPAIGN_TIMEZONE",
+                        "description": "IANA time zone, e.g. Asia/Bangkok",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "previous_period",
+                            "previous_month",
+                            "previous_year"
+                        ],
+                        "type": "string",
+                        "description": "Also count a comparison period and the change per series",
+                        "name": "compare",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DashboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DashboardResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboard/metrics/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the dashboard metrics as CSV or XLSX, with the same filters as /dashboard/metrics: the buckets, each series' performance and, with compare, the buckets of the comparison period. XLSX puts each in its own sheet; CSV separates them with an empty line.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/json"
+                ],
+                "tags": [
+                    "dashboard"
+                ],
+                "summary": "Export dashboard metrics",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"7 days ago\"",
+                        "description": "Start date (YYYY-MM-DD, midnight in tz) or RFC 3339 time",
+                        "name": "start_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"tomorrow\"",
+                        "description": "Exclusive end date (YYYY-MM-DD, midnight in tz) or RFC 3339 time",
+                        "name": "end_at",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hour",
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Bucket size",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "CAMPAIGN_TIMEZONE",
+                        "description": "IANA time zone, e.g. Asia/Bangkok",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "previous_period",
+                            "previous_month",
+                            "previous_year"
+                        ],
+                        "type": "string",
+                        "description": "Also export the comparison period",
+                        "name": "compare",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "dashboard-metrics.csv or .xlsx",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/link": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new affiliate link for a product and campaign",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "link"
+                ],
+                "summary": "Create affiliate link",
+                "parameters": [
+                    {
+                        "description": "Link request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/link/campaign/{campaignId}": {
+            "get": {
+                "description": "Get all links associated with a campaign",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "link"
+                ],
+                "summary": "Get links by campaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign ID",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LinksResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/link/campaign/{campaignId}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the links of one of your campaigns as CSV or XLSX",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/json"
+                ],
+                "tags": [
+                    "link"
+                ],
+                "summary": "Export campaign links",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign ID",
+                        "name": "campaignId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "campaign-links.csv or .xlsx",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/link/redirect/{short_code}": {
+            "get": {
+                "description": "Track click and redirect to the marketplace affiliate link",
+                "tags": [
+                    "link"
+                ],
+                "summary": "Redirect to affiliate link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "short_code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirect to affiliate URL",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/link/short-code/{short_code}": {
+            "get": {
+                "description": "Get a link by its short code",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "link"
+                ],
+                "summary": "Get link by short code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "short_code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LinkResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/link/{link_id}": {
+            "get": {
+                "description": "Get a specific link by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "link"
+                ],
+                "summary": "Get link by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Link ID",
+                        "name": "link_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LinkResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a link and all associated clicks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "link"
+                ],
+                "summary": "Delete link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Link ID",
+                        "name": "link_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/postback": {
+            "get": {
+                "description": "Server to server postback from a network or advertiser, served at /postback outside the API base path. Records an order against the click id the redirect passed to the destination, as a query string, form or JSON body. Authenticate with the link owner's postback secret, sent in the X-Postback-Secret header or as secret in a POST body but never in the query string, or with signature: the hex HMAC-SHA256, keyed by the secret, of the other non-empty parameters query encoded in key order (amount, click_id, currency, order_id, payout, status). An order posted back again updates the status, payout and amount recorded for it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "postback"
+                ],
+                "summary": "Record a conversion postback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Click ID",
+                        "name": "click_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order ID, unique per user",
+                        "name": "order_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Commission earned",
+                        "name": "payout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Order value",
+                        "name": "amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency, defaults to the product's",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Conversion status (pending/approved/cancelled), defaults to approved",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Postback secret",
+                        "name": "X-Postback-Secret",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 signature",
+                        "name": "signature",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConversionResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Server to server postback from a network or advertiser, served at /postback outside the API base path. Records an order against the click id the redirect passed to the destination, as a query string, form or JSON body. Authenticate with the link owner's postback secret, sent in the X-Postback-Secret header or as secret in a POST body but never in the query string, or with signature: the hex HMAC-SHA256, keyed by the secret, of the other non-empty parameters query encoded in key order (amount, click_id, currency, order_id, payout, status). An order posted back again updates the status, payout and amount recorded for it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "postback"
+                ],
+                "summary": "Record a conversion postback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Click ID",
+                        "name": "click_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order ID, unique per user",
+                        "name": "order_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Commission earned",
+                        "name": "payout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Order value",
+                        "name": "amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency, defaults to the product's",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Conversion status (pending/approved/cancelled), defaults to approved",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Postback secret",
+                        "name": "X-Postback-Secret",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 signature",
+                        "name": "signature",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ConversionResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all products for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get user products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only products with this tag",
+                        "name": "tag_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only products in this collection, in collection order",
+                        "name": "collection_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "List soft deleted products instead",
+                        "name": "deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new affiliate product from marketplace URL. Share links such as s.shopee.co.th/xxxx are followed to the product page first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Add a new product",
+                "parameters": [
+                    {
+                        "description": "Product request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "502": {
+                        "description": "Share link could not be resolved or marketplace unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/unavailable": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get products whose offers are all out of stock or delisted, with the number of links still pointing at each",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get unavailable products",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UnavailableProductsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{productId}": {
+            "get": {
+                "description": "Get a specific product by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete a product and its links. It can be restored until the retention period has passed; clicks stay in analytics.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Delete product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{productId}/offer": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get marketplace offers for a specific product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Get product offers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OfferResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{productId}/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Re-fetch title, images and offers from the marketplace with your credentials and update the product in place, keeping its links and clicks. Returns what changed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Refresh product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductRefreshResult"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Marketplace unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{productId}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a soft deleted product and the links deleted with it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Restore product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Product is not deleted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{productId}/tag/{tag_id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attach a tag to a product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Tag a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Detach a tag from a product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Untag a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/storefront/campaign/{campaign_id}": {
+            "get": {
+                "description": "Get an active campaign with its products, their best available offer and short link, for rendering landing pages. Responses carry Cache-Control and ETag headers and honour If-None-Match.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "storefront"
+                ],
+                "summary": "Get a campaign storefront",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign ID",
+                        "name": "campaign_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StorefrontCampaignResult"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StorefrontCampaignResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/storefront/user/{user_id}": {
+            "get": {
+                "description": "Get every active campaign of a user with its products, their best available offer and short link. Responses carry Cache-Control and ETag headers and honour If-None-Match.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "storefront"
+                ],
+                "summary": "Get a user storefront",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserStorefrontResult"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tag": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all product tags of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Get user tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new product tag for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Create a tag",
+                "parameters": [
+                    {
+                        "description": "Tag request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tag/{tag_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename a product tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Rename a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a tag and remove it from all products",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Delete a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/link-policy": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Choose what links do when their product is out of stock or delisted: keep redirecting, reroute to another available offer of the product, or send shoppers to a fallback url",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Set unavailable product policy",
+                "parameters": [
+                    {
+                        "description": "Link policy",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LinkPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/login": {
+            "post": {
+                "description": "Authenticate user and return session token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StringResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/logout": {
+            "post": {
+                "description": "Clear session cookie",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Logout user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/market-credential": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save or update marketplace API credentials (Lazada/Shopee)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Save marketplace credentials",
+                "parameters": [
+                    {
+                        "description": "Marketplace credential",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MarketplaceCredentialRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/market-credential/{platform}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check if marketplace credentials exist for a platform",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Check marketplace credentials",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Platform name (lazada/shopee)",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BoolResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete stored marketplace credentials for a platform",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete marketplace credentials",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Platform name (lazada/shopee)",
+                        "name": "platform",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get authenticated user information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/postback-secret": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a new secret for authenticating conversion postbacks, replacing the current one. Postbacks are refused until a secret has been generated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Generate postback secret",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostbackSecretResult"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/register": {
+            "post": {
+                "description": "Create a new user account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "Register request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StringResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmptyResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domains.Campaign": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "description": "Clicks is only filled in campaign listings.",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt is set while the campaign is soft deleted. It is purged for good once the\nretention period has passed.",
+                    "type": "string"
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "utm_campaign": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.CampaignGoal": {
+            "type": "object",
+            "properties": {
+                "campaign_id": {
+                    "type": "string"
+                },
+                "conversion_rate": {
+                    "description": "ConversionRate estimates commission from clicks: each click earns its offer's commission\ntimes this rate.",
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deadline": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notify_thresholds": {
+                    "description": "NotifyThresholds are attainment percentages, e.g. 50, 100, that publish a\nCampaignGoalThresholdReached event the first time a metric crosses them.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "target_clicks": {
+                    "type": "integer"
+                },
+                "target_commission": {
+                    "type": "number"
+                },
+                "target_unique_visitors": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.ClickRecorded": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "campaign_id": {
+                    "type": "string"
+                },
+                "click_id": {
+                    "type": "string"
+                },
+                "link_id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "short_code": {
+                    "type": "string"
+                },
+                "visitor_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.Collection": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.CollectionItem"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.CollectionItem": {
+            "type": "object",
+            "properties": {
+                "collection_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "product": {
+                    "$ref": "#/definitions/domains.Product"
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.Conversion": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount is what the shopper paid for the item and Commission what the affiliate earns on it,\nboth in Currency.",
+                    "type": "number"
+                },
+                "campaign_id": {
+                    "type": "string"
+                },
+                "click_id": {
+                    "description": "ClickId is the click a postback was recorded against.",
+                    "type": "string"
+                },
+                "commission": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "item_id": {
+                    "description": "ItemId is the order item, since commission is paid per item. Postbacks report whole\norders and leave it empty, so an order is only posted back once.",
+                    "type": "string"
+                },
+                "link_id": {
+                    "description": "LinkId, CampaignId and ProductId are set when one of the sub ids is the short code of one\nof the user's links. They are not foreign keys, so conversions outlive purged links.",
+                    "type": "string"
+                },
+                "marketplace": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "ordered_at": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is ConversionSourceReport or ConversionSourcePostback.",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is one of ConversionPending, ConversionApproved or ConversionCancelled.",
+                    "type": "string"
+                },
+                "sub_ids": {
+                    "description": "SubIds are the sub ids of the affiliate link the order came through, in order.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.Link": {
+            "type": "object",
+            "properties": {
+                "campaignId": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt is set while the link is soft deleted. It is purged for good once the\nretention period has passed.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "short_code": {
+                    "type": "string"
+                },
+                "target_url": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.Offer": {
+            "type": "object",
+            "properties": {
+                "availability": {
+                    "description": "Availability is one of OfferAvailable, OfferOutOfStock or OfferDelisted.",
+                    "type": "string"
+                },
+                "commission": {
+                    "type": "number"
+                },
+                "commission_rate": {
+                    "description": "CommissionRate is the affiliate commission as a fraction of the price (0.05 = 5%).",
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "Currency is the ISO 4217 code of Price and Commission, e.g. THB.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_checked_at": {
+                    "type": "string"
+                },
+                "marketplace": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "rating_star": {
+                    "type": "number"
+                },
+                "sales": {
+                    "description": "Sales is the sales volume reported by the marketplace (Lazada only reports the last 7 days).",
+                    "type": "integer"
+                },
+                "store_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Url is where shoppers land for this offer when a link is rerouted to it. Shopee offers\ncarry their own affiliate offer link; Lazada offers use the product url.",
+                    "type": "string"
+                }
+            }
+        },
+        "domains.Product": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt is set while the product is soft deleted. It is purged for good once the\nretention period has passed.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.ProductImage"
+                    }
+                },
+                "source_url": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Tag"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.ProductImage": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "domains.Tag": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domains.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "fallback_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "unavailable_link_policy": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BoolResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Operation successful"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.BulkLinkResponse": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LinkFailure"
+                    }
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Link"
+                    }
+                }
+            }
+        },
+        "dto.BulkLinkResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/dto.BulkLinkResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Links created successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.CampaignCloneResponse": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "$ref": "#/definitions/domains.Campaign"
+                },
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LinkFailure"
+                    }
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Link"
+                    }
+                }
+            }
+        },
+        "dto.CampaignCloneResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/dto.CampaignCloneResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Campaign cloned successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.CampaignGoalRequest": {
+            "type": "object",
+            "properties": {
+                "conversion_rate": {
+                    "description": "ConversionRate defaults to 0.02.",
+                    "type": "number",
+                    "maximum": 1
+                },
+                "deadline": {
+                    "description": "Deadline defaults to the campaign's EndAt.",
+                    "type": "string"
+                },
+                "notify_thresholds": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "target_clicks": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "target_commission": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "target_unique_visitors": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.CampaignGoalResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/domains.CampaignGoal"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Campaign goal saved successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.CampaignLinkReport": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "description": "Deleted links are only listed when they have clicks in the range.",
+                    "type": "boolean"
+                },
+                "link_id": {
+                    "type": "string"
+                },
+                "marketplace": {
+                    "description": "Marketplace is the marketplace of the product's primary offer.",
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_title": {
+                    "type": "string"
+                },
+                "share": {
+                    "description": "Share is the link's fraction of the campaign's clicks in the report range.",
+                    "type": "number"
+                },
+                "short_code": {
+                    "type": "string"
+                },
+                "unique_visitors": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CampaignProductReport": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer"
+                },
+                "marketplace": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_title": {
+                    "type": "string"
+                },
+                "share": {
+                    "type": "number"
+                },
+                "unique_visitors": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CampaignProgressResponse": {
+            "type": "object",
+            "properties": {
+                "elapsed_ratio": {
+                    "description": "ElapsedRatio is the share of the time between StartAt and the deadline that has passed.",
+                    "type": "number"
+                },
+                "goal": {
+                    "$ref": "#/definitions/domains.CampaignGoal"
+                },
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GoalMetricProgress"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CampaignProgressResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/dto.CampaignProgressResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Campaign progress fetched successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.CampaignReportComparison": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "$ref": "#/definitions/dto.MetricDelta"
+                },
+                "compare": {
+                    "type": "string"
+                },
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DailyClicks"
+                    }
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LinkComparison"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProductComparison"
+                    }
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "unique_visitors": {
+                    "$ref": "#/definitions/dto.MetricDelta"
+                }
+            }
+        },
+        "dto.CampaignReportResponse": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "$ref": "#/definitions/domains.Campaign"
+                },
+                "comparison": {
+                    "description": "Comparison is only set when the request asks for one.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.CampaignReportComparison"
+                        }
+                    ]
+                },
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DailyClicks"
+                    }
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CampaignLinkReport"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CampaignProductReport"
+                    }
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/dto.ClickTotals"
+                }
+            }
+        },
+        "dto.CampaignReportResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/dto.CampaignReportResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Campaign report fetched successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.CampaignResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/domains.Campaign"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Campaign created successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.CampaignStateRequest": {
+            "type": "object",
+            "required": [
+                "state"
+            ],
+            "properties": {
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "scheduled",
+                        "active",
+                        "paused",
+                        "ended",
+                        "archived"
+                    ]
+                }
+            }
+        },
+        "dto.CampaignUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "$ref": "#/definitions/domains.Campaign"
+                },
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LinkFailure"
+                    }
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Link"
+                    }
+                }
+            }
+        },
+        "dto.CampaignUpdateResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/dto.CampaignUpdateResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Campaign updated successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.CampaignsResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Campaign"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Campaigns retrieved successfully"
+                },
+                "meta": {
+                    "$ref": "#/definitions/dto.PageMeta"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.ClickTotals": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer"
+                },
+                "unique_visitors": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CloneCampaignRequest": {
+            "type": "object",
+            "required": [
+                "end_at",
+                "start_at",
+                "utm_campaign"
+            ],
+            "properties": {
+                "draft": {
+                    "type": "boolean"
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "utm_campaign": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                }
+            }
+        },
+        "dto.CollectionRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "product_ids": {
+                    "description": "ProductIds sets the products of the collection in order. Omit it to keep the current items.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CollectionResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/domains.Collection"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Collection fetched successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.CollectionsResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Collection"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Collections fetched successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.ConversionResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/domains.Conversion"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Conversion recorded"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.CreateCampaignRequest": {
+            "type": "object",
+            "required": [
+                "end_at",
+                "name",
+                "start_at",
+                "utm_campaign"
+            ],
+            "properties": {
+                "draft": {
+                    "description": "Draft keeps the campaign out of the scheduler until it is published.",
+                    "type": "boolean"
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
                 },
-                "user_id": {
-                    "type": "integer"
+                "start_at": {
+                    "type": "string"
                 },
                 "utm_campaign": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                }
+            }
+        },
+        "dto.CreateCollectionLinksRequest": {
+            "type": "object",
+            "required": [
+                "campaign_id"
+            ],
+            "properties": {
+                "campaign_id": {
                     "type": "string"
                 }
             }
         },
-        "domains.Link": {
+        "dto.CreateLinkRequest": {
             "type": "object",
+            "required": [
+                "campaign_id",
+                "product_id"
+            ],
             "properties": {
-                "campaignId": {
+                "campaign_id": {
                     "type": "string"
                 },
-                "created_at": {
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "marketplace",
+                "source_url"
+            ],
+            "properties": {
+                "marketplace": {
                     "type": "string"
                 },
-                "id": {
+                "source_url": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.DailyClicks": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer"
                 },
-                "productId": {
+                "date": {
                     "type": "string"
                 },
-                "short_code": {
+                "unique_visitors": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.DashboardBreakdownResponse": {
+            "type": "object",
+            "properties": {
+                "compare": {
                     "type": "string"
                 },
-                "target_url": {
+                "dimension": {
                     "type": "string"
                 },
-                "updated_at": {
+                "end_at": {
+                    "type": "string"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaderboardEntry"
+                    }
+                },
+                "previous_end_at": {
+                    "type": "string"
+                },
+                "previous_start_at": {
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "string"
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "tz": {
                     "type": "string"
                 }
             }
         },
-        "domains.Offer": {
+        "dto.DashboardBreakdownResult": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/dto.DashboardBreakdownResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Breakdown fetched successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.DashboardComparison": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "$ref": "#/definitions/dto.MetricDelta"
+                },
+                "compare": {
                     "type": "string"
                 },
-                "id": {
+                "end_at": {
                     "type": "string"
                 },
-                "last_checked_at": {
+                "metrics": {
+                    "description": "Metrics are the buckets of the comparison period.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MetrictItem"
+                    }
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SeriesComparison"
+                    }
+                },
+                "start_at": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.DashboardLeaderboardsResponse": {
+            "type": "object",
+            "properties": {
+                "campaigns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaderboardEntry"
+                    }
                 },
-                "marketplace": {
+                "compare": {
+                    "description": "Compare, PreviousStartAt and PreviousEndAt give the period the deltas compare with.",
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "end_at": {
+                    "type": "string"
                 },
-                "productId": {
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaderboardEntry"
+                    }
+                },
+                "marketplaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaderboardEntry"
+                    }
+                },
+                "previous_end_at": {
+                    "type": "string"
+                },
+                "previous_start_at": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LeaderboardEntry"
+                    }
+                },
+                "sort": {
+                    "type": "string"
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "tz": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DashboardLeaderboardsResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/dto.DashboardLeaderboardsResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Leaderboards fetched successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.DashboardMetricsResponse": {
+            "type": "object",
+            "properties": {
+                "comparison": {
+                    "description": "Comparison is only set when the request asks for one.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.DashboardComparison"
+                        }
+                    ]
+                },
+                "granularity": {
+                    "type": "string"
+                },
+                "metrics": {
+                    "description": "Metrics has an item for every bucket of every campaign and marketplace with clicks in the\nrange, with zero clicks for buckets without any.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MetrictItem"
+                    }
+                },
+                "performance": {
+                    "description": "Performance sums each series over the whole range.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SeriesPerformance"
+                    }
+                },
+                "top_product": {
+                    "description": "TopProduct is the most clicked product in the range, or null when nothing was clicked.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.TopProduct"
+                        }
+                    ]
+                },
+                "tz": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DashboardResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/dto.DashboardMetricsResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Metrics retrieved successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.EmptyResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Operation successful"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.FieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
                     "type": "string"
                 },
-                "store_name": {
+                "new": {},
+                "old": {}
+            }
+        },
+        "dto.GoalMetricProgress": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "type": "number"
+                },
+                "attainment": {
+                    "description": "Attainment is Actual / Target.",
+                    "type": "number"
+                },
+                "expected": {
+                    "description": "Expected is where Actual should be by now to reach Target at a constant pace.",
+                    "type": "number"
+                },
+                "metric": {
                     "type": "string"
                 },
-                "updated_at": {
+                "projected": {
+                    "description": "Projected is Actual extrapolated to the deadline at the pace so far.",
+                    "type": "number"
+                },
+                "status": {
                     "type": "string"
+                },
+                "target": {
+                    "type": "number"
                 }
             }
         },
-        "domains.Product": {
+        "dto.LeaderboardEntry": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "clicks": {
+                    "type": "integer"
                 },
-                "id": {
-                    "type": "string"
+                "delta": {
+                    "description": "Delta is the change of the sort metric since the previous period and DeltaRatio that\nchange relative to it, or null when the previous period had none.",
+                    "type": "integer"
                 },
-                "image_url": {
-                    "type": "string"
+                "delta_ratio": {
+                    "type": "number"
                 },
-                "source_url": {
+                "key": {
                     "type": "string"
                 },
-                "title": {
+                "label": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
+                "previous_clicks": {
+                    "type": "integer"
                 },
-                "user_id": {
+                "previous_unique_visitors": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "unique_visitors": {
                     "type": "integer"
                 }
             }
         },
-        "domains.User": {
+        "dto.LinkComparison": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "clicks": {
+                    "$ref": "#/definitions/dto.MetricDelta"
                 },
-                "email": {
+                "link_id": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "password": {
+                "short_code": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
+                "unique_visitors": {
+                    "$ref": "#/definitions/dto.MetricDelta"
                 }
             }
         },
-        "dto.BoolResponse": {
+        "dto.LinkFailure": {
             "type": "object",
             "properties": {
                 "code": {
-                    "type": "integer",
-                    "example": 0
-                },
-                "data": {
-                    "type": "boolean",
-                    "example": true
+                    "type": "integer"
                 },
                 "message": {
-                    "type": "string",
-                    "example": "Operation successful"
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LinkPolicyRequest": {
+            "type": "object",
+            "required": [
+                "policy"
+            ],
+            "properties": {
+                "fallback_url": {
+                    "type": "string"
                 },
-                "txn_id": {
+                "policy": {
                     "type": "string",
-                    "example": "txn_123456"
+                    "enum": [
+                        "keep",
+                        "reroute",
+                        "fallback"
+                    ]
                 }
             }
         },
-        "dto.CampaignResponse": {
+        "dto.LinkResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -1136,11 +4911,11 @@ const docTemplate = `{
                     "example": 0
                 },
                 "data": {
-                    "$ref": "#/definitions/domains.Campaign"
+                    "$ref": "#/definitions/domains.Link"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Campaign created successfully"
+                    "example": "Link created successfully"
                 },
                 "success": {
                     "type": "boolean",
@@ -1152,7 +4927,7 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.CampaignsResponse": {
+        "dto.LinksResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -1162,12 +4937,12 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domains.Campaign"
+                        "$ref": "#/definitions/domains.Link"
                     }
                 },
                 "message": {
                     "type": "string",
-                    "example": "Campaigns retrieved successfully"
+                    "example": "Links retrieved successfully"
                 },
                 "success": {
                     "type": "boolean",
@@ -1179,82 +4954,130 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.CreateCampaignRequest": {
+        "dto.LoginRequest": {
             "type": "object",
             "required": [
-                "end_at",
-                "name",
-                "start_at",
-                "utm_campaign"
+                "email",
+                "password"
             ],
             "properties": {
-                "end_at": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
-                },
-                "start_at": {
+                "email": {
                     "type": "string"
                 },
-                "utm_campaign": {
+                "password": {
                     "type": "string",
                     "maxLength": 100,
-                    "minLength": 3
+                    "minLength": 6
                 }
             }
         },
-        "dto.CreateLinkRequest": {
+        "dto.MarketplaceCredentialRequest": {
             "type": "object",
             "required": [
-                "campaign_id",
-                "product_id"
+                "platform"
             ],
             "properties": {
-                "campaign_id": {
+                "app_id": {
                     "type": "string"
                 },
-                "product_id": {
+                "app_key": {
+                    "type": "string"
+                },
+                "app_secret": {
+                    "type": "string"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "region": {
+                    "description": "Region defaults to th.",
+                    "type": "string",
+                    "enum": [
+                        "th",
+                        "my",
+                        "vn",
+                        "ph",
+                        "sg",
+                        "id"
+                    ]
+                },
+                "sign_method": {
+                    "type": "string"
+                },
+                "user_token": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreateProductRequest": {
+        "dto.MetricDelta": {
             "type": "object",
-            "required": [
-                "marketplace",
-                "source_url"
-            ],
             "properties": {
-                "marketplace": {
-                    "type": "string",
-                    "enum": [
-                        "shopee",
-                        "lazada"
-                    ]
+                "current": {
+                    "type": "integer"
                 },
-                "source_url": {
+                "delta": {
+                    "type": "integer"
+                },
+                "delta_ratio": {
+                    "type": "number"
+                },
+                "previous": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MetrictItem": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "type": "string"
+                },
+                "campaign_name": {
+                    "type": "string"
+                },
+                "click_count": {
+                    "type": "integer"
+                },
+                "conversions": {
+                    "description": "Conversions counts the orders placed in the bucket, Sales their amount and Revenue the\ncommission earned on them, in Currency. Cancelled orders are left out.",
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Date is the start of the bucket in the requested timezone: YYYY-MM-DD, or YYYY-MM-DDTHH:00\nfor hourly buckets.",
+                    "type": "string"
+                },
+                "marketplace": {
                     "type": "string"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "sales": {
+                    "type": "number"
                 }
             }
         },
-        "dto.DashboardMetricsResponse": {
+        "dto.OfferChange": {
             "type": "object",
             "properties": {
-                "metrics": {
+                "changes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.MetrictItem"
+                        "$ref": "#/definitions/dto.FieldChange"
                     }
                 },
-                "top_product": {
-                    "$ref": "#/definitions/dto.TopProduct"
+                "offer_id": {
+                    "type": "string"
+                },
+                "store_name": {
+                    "type": "string"
                 }
             }
         },
-        "dto.DashboardResponse": {
+        "dto.OfferResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -1262,32 +5085,141 @@ const docTemplate = `{
                     "example": 0
                 },
                 "data": {
-                    "$ref": "#/definitions/dto.DashboardMetricsResponse"
+                    "$ref": "#/definitions/domains.Offer"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Metrics retrieved successfully"
+                    "example": "Offer retrieved successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.PageMeta": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "NextCursor fetches the page after this one when passed as cursor.",
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PostbackSecretResponse": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PostbackSecretResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/dto.PostbackSecretResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Postback secret generated successfully"
                 },
                 "success": {
                     "type": "boolean",
                     "example": true
                 },
-                "txn_id": {
-                    "type": "string",
-                    "example": "txn_123456"
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.ProductComparison": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "$ref": "#/definitions/dto.MetricDelta"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_title": {
+                    "type": "string"
+                },
+                "unique_visitors": {
+                    "$ref": "#/definitions/dto.MetricDelta"
+                }
+            }
+        },
+        "dto.ProductRefreshResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldChange"
+                    }
+                },
+                "offers_added": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Offer"
+                    }
+                },
+                "offers_removed": {
+                    "description": "OffersRemoved are offers the marketplace no longer lists. They are kept, marked delisted.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Offer"
+                    }
+                },
+                "offers_updated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OfferChange"
+                    }
+                },
+                "product": {
+                    "$ref": "#/definitions/domains.Product"
                 }
             }
         },
-        "dto.EmptyResponse": {
+        "dto.ProductRefreshResult": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
                     "example": 0
                 },
+                "data": {
+                    "$ref": "#/definitions/dto.ProductRefreshResponse"
+                },
                 "message": {
                     "type": "string",
-                    "example": "Operation successful"
+                    "example": "Product refreshed successfully"
                 },
                 "success": {
                     "type": "boolean",
@@ -1299,7 +5231,7 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.LinkResponse": {
+        "dto.ProductResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -1307,11 +5239,11 @@ const docTemplate = `{
                     "example": 0
                 },
                 "data": {
-                    "$ref": "#/definitions/domains.Link"
+                    "$ref": "#/definitions/domains.Product"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Link created successfully"
+                    "example": "Product retrieved successfully"
                 },
                 "success": {
                     "type": "boolean",
@@ -1323,7 +5255,7 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.LinksResponse": {
+        "dto.ProductsResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -1333,12 +5265,12 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domains.Link"
+                        "$ref": "#/definitions/domains.Product"
                     }
                 },
                 "message": {
                     "type": "string",
-                    "example": "Links retrieved successfully"
+                    "example": "Products retrieved successfully"
                 },
                 "success": {
                     "type": "boolean",
@@ -1350,7 +5282,7 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.LoginRequest": {
+        "dto.RegisterRequest": {
             "type": "object",
             "required": [
                 "email",
@@ -1367,37 +5299,27 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.MarketplaceCredentialRequest": {
+        "dto.SeriesComparison": {
             "type": "object",
-            "required": [
-                "platform"
-            ],
             "properties": {
-                "app_id": {
-                    "type": "string"
-                },
-                "app_key": {
+                "campaign": {
                     "type": "string"
                 },
-                "app_secret": {
+                "campaign_name": {
                     "type": "string"
                 },
-                "platform": {
-                    "type": "string",
-                    "enum": [
-                        "shopee",
-                        "lazada"
-                    ]
+                "clicks": {
+                    "$ref": "#/definitions/dto.MetricDelta"
                 },
-                "sign_method": {
+                "currency": {
                     "type": "string"
                 },
-                "user_token": {
+                "marketplace": {
                     "type": "string"
                 }
             }
         },
-        "dto.MetrictItem": {
+        "dto.SeriesPerformance": {
             "type": "object",
             "properties": {
                 "campaign": {
@@ -1406,18 +5328,56 @@ const docTemplate = `{
                 "campaign_name": {
                     "type": "string"
                 },
-                "click_count": {
+                "clicks": {
                     "type": "integer"
                 },
-                "date": {
+                "conversion_rate": {
+                    "type": "number"
+                },
+                "conversions": {
+                    "type": "integer"
+                },
+                "currency": {
                     "type": "string"
                 },
+                "epc": {
+                    "type": "number"
+                },
                 "marketplace": {
                     "type": "string"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "sales": {
+                    "type": "number"
                 }
             }
         },
-        "dto.OfferResponse": {
+        "dto.StorefrontCampaign": {
+            "type": "object",
+            "properties": {
+                "end_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StorefrontProduct"
+                    }
+                },
+                "start_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.StorefrontCampaignResult": {
             "type": "object",
             "properties": {
                 "code": {
@@ -1425,11 +5385,11 @@ const docTemplate = `{
                     "example": 0
                 },
                 "data": {
-                    "$ref": "#/definitions/domains.Offer"
+                    "$ref": "#/definitions/dto.StorefrontCampaign"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Offer retrieved successfully"
+                    "example": "Storefront fetched successfully"
                 },
                 "success": {
                     "type": "boolean",
@@ -1441,7 +5401,65 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.ProductResponse": {
+        "dto.StorefrontOffer": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "marketplace": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "rating_star": {
+                    "type": "number"
+                },
+                "store_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.StorefrontProduct": {
+            "type": "object",
+            "properties": {
+                "best_offer": {
+                    "description": "BestOffer is the cheapest available offer, or nil when every offer is out of stock or delisted.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.StorefrontOffer"
+                        }
+                    ]
+                },
+                "category": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "short_code": {
+                    "type": "string"
+                },
+                "short_url": {
+                    "description": "ShortUrl is the tracked redirect shoppers should be sent to.",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.StringResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -1449,11 +5467,12 @@ const docTemplate = `{
                     "example": 0
                 },
                 "data": {
-                    "$ref": "#/definitions/domains.Product"
+                    "type": "string",
+                    "example": "data_value"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Product retrieved successfully"
+                    "example": "Operation successful"
                 },
                 "success": {
                     "type": "boolean",
@@ -1465,7 +5484,44 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.ProductsResponse": {
+        "dto.TagRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                }
+            }
+        },
+        "dto.TagResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/domains.Tag"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Tag created successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
+        },
+        "dto.TagsResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -1475,12 +5531,12 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domains.Product"
+                        "$ref": "#/definitions/domains.Tag"
                     }
                 },
                 "message": {
                     "type": "string",
-                    "example": "Products retrieved successfully"
+                    "example": "Tags fetched successfully"
                 },
                 "success": {
                     "type": "boolean",
@@ -1492,24 +5548,35 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.RegisterRequest": {
+        "dto.TopProduct": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "clicks": {
+                    "type": "integer"
                 },
-                "password": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 6
+                "product": {
+                    "$ref": "#/definitions/domains.Product"
                 }
             }
         },
-        "dto.StringResponse": {
+        "dto.UnavailableProduct": {
+            "type": "object",
+            "properties": {
+                "link_count": {
+                    "type": "integer"
+                },
+                "offers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domains.Offer"
+                    }
+                },
+                "product": {
+                    "$ref": "#/definitions/domains.Product"
+                }
+            }
+        },
+        "dto.UnavailableProductsResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -1517,12 +5584,14 @@ const docTemplate = `{
                     "example": 0
                 },
                 "data": {
-                    "type": "string",
-                    "example": "data_value"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UnavailableProduct"
+                    }
                 },
                 "message": {
                     "type": "string",
-                    "example": "Operation successful"
+                    "example": "Unavailable products fetched successfully"
                 },
                 "success": {
                     "type": "boolean",
@@ -1534,14 +5603,28 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.TopProduct": {
+        "dto.UpdateCampaignRequest": {
             "type": "object",
             "properties": {
-                "clicks": {
-                    "type": "integer"
+                "end_at": {
+                    "type": "string"
                 },
-                "product": {
-                    "$ref": "#/definitions/domains.Product"
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "regenerate_links": {
+                    "description": "RegenerateLinks asks the marketplaces for new affiliate urls for the campaign's links\nwhen UtmCampaign changes, so clicks are attributed to the new value.",
+                    "type": "boolean"
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "utm_campaign": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
                 }
             }
         },
@@ -1568,6 +5651,44 @@ const docTemplate = `{
                     "example": "txn_123456"
                 }
             }
+        },
+        "dto.UserStorefront": {
+            "type": "object",
+            "properties": {
+                "campaigns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StorefrontCampaign"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.UserStorefrontResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "data": {
+                    "$ref": "#/definitions/dto.UserStorefront"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Storefront fetched successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "txn_id": {
+                    "type": "string",
+                    "example": "txn_123456"
+                }
+            }
         }
     },
     "securityDefinitions": {
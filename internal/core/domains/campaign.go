@@ -1,19 +1,85 @@
 package domains
 
 import (
+	"slices"
 	"time"
 
 	"github.com/gofrs/uuid"
 )
 
+// Campaign states. Scheduled, active and ended follow StartAt and EndAt and are moved along by
+// the campaign scheduler; draft, paused and archived are only set by the owner.
+const (
+	CampaignDraft     = "draft"
+	CampaignScheduled = "scheduled"
+	CampaignActive    = "active"
+	CampaignPaused    = "paused"
+	CampaignEnded     = "ended"
+	CampaignArchived  = "archived"
+)
+
+// TopicCampaignStateChanged is published with a CampaignStateChanged payload.
+const TopicCampaignStateChanged = "campaign.state_changed"
+
+// campaignTransitions lists the states an owner may move a campaign to. Moving to scheduled
+// publishes or resumes the campaign, which then lands in whichever of scheduled, active or
+// ended its dates call for.
+var campaignTransitions = map[string][]string{
+	CampaignDraft:     {CampaignScheduled, CampaignArchived},
+	CampaignScheduled: {CampaignDraft, CampaignPaused, CampaignEnded, CampaignArchived},
+	CampaignActive:    {CampaignPaused, CampaignEnded, CampaignArchived},
+	CampaignPaused:    {CampaignScheduled, CampaignEnded, CampaignArchived},
+	CampaignEnded:     {CampaignScheduled, CampaignArchived},
+	CampaignArchived:  {},
+}
+
 type Campaign struct {
 	Id          uuid.UUID `json:"id" gorm:"primary_key;type:uuid;default:uuidv7()"`
 	Name        string    `json:"name" gorm:"column:name;type:text;not null"`
 	UtmCampaign string    `json:"utm_campaign" gorm:"column:utm_campaign;type:text;not null"`
 	StartAt     time.Time `json:"start_at" gorm:"column:start_at;not null"`
 	EndAt       time.Time `json:"end_at" gorm:"column:end_at;not null"`
+	State       string    `json:"state" gorm:"column:state;type:text;not null;default:scheduled;index"`
+	// Clicks is only filled in campaign listings.
+	Clicks *int64 `json:"clicks,omitempty" gorm:"->;-:migration;column:clicks"`
 
 	UserId    int64     `json:"user_id" gorm:"column:user_id;not null"`
 	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime:milli"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime:milli"`
+	// DeletedAt is set while the campaign is soft deleted. It is purged for good once the
+	// retention period has passed.
+	DeletedAt *time.Time `json:"deleted_at" gorm:"column:deleted_at;index"`
+}
+
+// ScheduledState is the state the dates call for at now. A campaign runs from midnight of the
+// StartAt date until the end of the EndAt date, both in loc.
+func (c Campaign) ScheduledState(now time.Time, loc *time.Location) string {
+	now = now.In(loc)
+	start := c.StartAt.In(loc)
+	end := c.EndAt.In(loc)
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	endDay := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	switch {
+	case now.Before(startDay):
+		return CampaignScheduled
+	case now.Before(endDay):
+		return CampaignActive
+	default:
+		return CampaignEnded
+	}
+}
+
+// CanTransition reports whether the owner may move the campaign to state.
+func (c Campaign) CanTransition(state string) bool {
+	return slices.Contains(campaignTransitions[c.State], state)
+}
+
+// CampaignStateChanged is published whenever a campaign enters a new state. From is empty for
+// a newly created campaign.
+type CampaignStateChanged struct {
+	CampaignId uuid.UUID `json:"campaign_id"`
+	UserId     int64     `json:"user_id"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	At         time.Time `json:"at"`
 }
@@ -0,0 +1,61 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Goal metrics.
+const (
+	GoalClicks              = "clicks"
+	GoalUniqueVisitors      = "unique_visitors"
+	GoalEstimatedCommission = "estimated_commission"
+)
+
+// Goal statuses, from best to worst.
+const (
+	GoalAchieved   = "achieved"
+	GoalOnTrack    = "on_track"
+	GoalNotStarted = "not_started"
+	GoalBehind     = "behind"
+	GoalMissed     = "missed"
+)
+
+// TopicCampaignGoalThreshold is published with a CampaignGoalThresholdReached payload.
+const TopicCampaignGoalThreshold = "campaign.goal_threshold"
+
+// DefaultGoalConversionRate is the share of clicks assumed to convert when estimating commission.
+const DefaultGoalConversionRate = 0.02
+
+// CampaignGoal holds the targets of a campaign. A target of zero is not tracked.
+type CampaignGoal struct {
+	Id                   uuid.UUID `json:"id" gorm:"primary_key;type:uuid;default:uuidv7()"`
+	CampaignId           uuid.UUID `json:"campaign_id" gorm:"column:campaign_id;type:uuid REFERENCES campaigns(id) ON DELETE CASCADE;not null;uniqueIndex"`
+	TargetClicks         int64     `json:"target_clicks" gorm:"column:target_clicks;not null;default:0"`
+	TargetUniqueVisitors int64     `json:"target_unique_visitors" gorm:"column:target_unique_visitors;not null;default:0"`
+	TargetCommission     float64   `json:"target_commission" gorm:"column:target_commission;type:decimal(12,2);not null;default:0"`
+	Deadline             time.Time `json:"deadline" gorm:"column:deadline;not null"`
+	// ConversionRate estimates commission from clicks: each click earns its offer's commission
+	// times this rate.
+	ConversionRate float64 `json:"conversion_rate" gorm:"column:conversion_rate;type:decimal(6,4);not null;default:0.02"`
+	// NotifyThresholds are attainment percentages, e.g. 50, 100, that publish a
+	// CampaignGoalThresholdReached event the first time a metric crosses them.
+	NotifyThresholds []int `json:"notify_thresholds" gorm:"column:notify_thresholds;type:text;serializer:json"`
+	// NotifiedThresholds is the highest threshold already notified per metric.
+	NotifiedThresholds map[string]int `json:"-" gorm:"column:notified_thresholds;type:text;serializer:json"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime:milli"`
+}
+
+// CampaignGoalThresholdReached is published when a goal metric reaches one of the goal's
+// notify thresholds.
+type CampaignGoalThresholdReached struct {
+	CampaignId uuid.UUID `json:"campaign_id"`
+	UserId     int64     `json:"user_id"`
+	Metric     string    `json:"metric"`
+	Threshold  int       `json:"threshold"`
+	Attainment float64   `json:"attainment"`
+	At         time.Time `json:"at"`
+}
@@ -1,15 +1,57 @@
 package domains
 
 import (
+	"encoding/hex"
 	"time"
 
 	"github.com/gofrs/uuid"
 )
 
+// The redirect passes the click id to the destination by replacing ClickIdMacro in the target
+// url or, without the macro, as the ClickIdParam query parameter.
+const (
+	ClickIdMacro = "{click_id}"
+	ClickIdParam = "sub_id"
+)
+
 type Click struct {
 	Id uuid.UUID `json:"id" gorm:"primary_key;type:uuid;default:uuidv7()"`
 	LinkId     uuid.UUID `gorm:"column:link_id;type:uuid REFERENCES links(id)"`
+	// VisitorId identifies the browser that clicked without storing its ip address.
+	VisitorId string `json:"visitor_id" gorm:"column:visitor_id;type:text;not null;default:''"`
+	// ReferrerDomain is the host of the page the visitor came from, without "www.", or empty
+	// when the browser sent no referrer.
+	ReferrerDomain string `json:"referrer_domain" gorm:"column:referrer_domain;type:text;not null;default:''"`
+	// Device, Os and Browser classify the visitor's user agent (see pkg/useragent) and Language
+	// is the primary subtag of its preferred language, such as "th". Each is empty when unknown.
+	Device   string `json:"device" gorm:"column:device;type:text;not null;default:''"`
+	Os       string `json:"os" gorm:"column:os;type:text;not null;default:''"`
+	Browser  string `json:"browser" gorm:"column:browser;type:text;not null;default:''"`
+	Language string `json:"language" gorm:"column:language;type:text;not null;default:''"`
+	// Marketplace is that of the offer the visitor was sent to, which differs from the link's
+	// own when the click was rerouted. It is empty when the visitor was sent elsewhere.
+	Marketplace string `json:"marketplace" gorm:"column:marketplace;type:text;not null;default:''"`
 
 	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime:milli"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime:milli"`
 }
+
+// ClickId is the click's id as sent to the destination and expected back in postbacks: the
+// uuid as 32 hex digits, since marketplaces only keep alphanumeric sub ids.
+func (c Click) ClickId() string {
+	return hex.EncodeToString(c.Id.Bytes())
+}
+
+// TopicClickRecorded is published with a ClickRecorded payload.
+const TopicClickRecorded = "click.recorded"
+
+// ClickRecorded is published for every redirect once its click is saved.
+type ClickRecorded struct {
+	ClickId    string    `json:"click_id"`
+	LinkId     uuid.UUID `json:"link_id"`
+	ShortCode  string    `json:"short_code"`
+	CampaignId uuid.UUID `json:"campaign_id"`
+	ProductId  uuid.UUID `json:"product_id"`
+	VisitorId  string    `json:"visitor_id"`
+	At         time.Time `json:"at"`
+}
@@ -0,0 +1,36 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// ClickRollup counts the clicks on a link in one hour. Dashboard and report queries read these
+// instead of scanning raw clicks; the campaign, product, user and marketplace of the link are
+// copied in so they can be grouped on without joins.
+type ClickRollup struct {
+	// Bucket is the start of the hour in UTC.
+	Bucket     time.Time `json:"bucket" gorm:"column:bucket;primaryKey"`
+	LinkId     uuid.UUID `json:"link_id" gorm:"column:link_id;type:uuid;primaryKey"`
+	CampaignId uuid.UUID `json:"campaign_id" gorm:"column:campaign_id;type:uuid;not null;index"`
+	ProductId  uuid.UUID `json:"product_id" gorm:"column:product_id;type:uuid;not null;index"`
+	UserId     int64     `json:"user_id" gorm:"column:user_id;not null;index"`
+	// Marketplace and Currency are those of the product's first offer when the hour was rolled up.
+	Marketplace string `json:"marketplace" gorm:"column:marketplace;type:text;not null;default:''"`
+	Currency    string `json:"currency" gorm:"column:currency;type:text;not null;default:''"`
+	Clicks      int64  `json:"clicks" gorm:"column:clicks;not null"`
+}
+
+// ClickVisitorRollup records that a visitor clicked a link on a day in CAMPAIGN_TIMEZONE, so
+// unique visitors can be counted over any range of days without scanning raw clicks.
+type ClickVisitorRollup struct {
+	Day        time.Time `json:"day" gorm:"column:day;type:date;primaryKey"`
+	LinkId     uuid.UUID `json:"link_id" gorm:"column:link_id;type:uuid;primaryKey"`
+	VisitorId  string    `json:"visitor_id" gorm:"column:visitor_id;type:text;primaryKey"`
+	CampaignId uuid.UUID `json:"campaign_id" gorm:"column:campaign_id;type:uuid;not null;index"`
+	ProductId  uuid.UUID `json:"product_id" gorm:"column:product_id;type:uuid;not null;index"`
+	UserId     int64     `json:"user_id" gorm:"column:user_id;not null;index"`
+	// Marketplace is that of the product's first offer when the day was rolled up.
+	Marketplace string `json:"marketplace" gorm:"column:marketplace;type:text;not null;default:''"`
+}
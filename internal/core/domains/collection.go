@@ -0,0 +1,27 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type Collection struct {
+	Id          uuid.UUID        `json:"id" gorm:"primary_key;type:uuid;default:uuidv7()"`
+	Name        string           `json:"name" gorm:"column:name;type:text;not null"`
+	Description string           `json:"description" gorm:"column:description;type:text;not null;default:''"`
+	Items       []CollectionItem `json:"items" gorm:"foreignKey:CollectionId"`
+
+	UserId    int64     `json:"user_id" gorm:"column:user_id;type:bigint REFERENCES users(id);not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime:milli"`
+}
+
+type CollectionItem struct {
+	CollectionId uuid.UUID `json:"collection_id" gorm:"primary_key;column:collection_id;type:uuid REFERENCES collections(id)"`
+	ProductId    uuid.UUID `json:"product_id" gorm:"primary_key;column:product_id;type:uuid REFERENCES products(id)"`
+	Position     int       `json:"position" gorm:"column:position;not null;default:0"`
+	Product      *Product  `json:"product,omitempty" gorm:"foreignKey:ProductId"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime:milli"`
+}
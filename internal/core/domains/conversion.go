@@ -0,0 +1,59 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Conversion statuses, normalised from the marketplace order statuses.
+const (
+	ConversionPending   = "pending"
+	ConversionApproved  = "approved"
+	ConversionCancelled = "cancelled"
+)
+
+// Where a conversion came from.
+const (
+	// ConversionSourceReport is an order item imported from the marketplace conversion report.
+	ConversionSourceReport = "report"
+	// ConversionSourcePostback is an order a network or advertiser posted back against a click.
+	ConversionSourcePostback = "postback"
+)
+
+// Conversion is an order item bought through one of the user's affiliate links, as reported by
+// the marketplace. Reports are imported again while orders settle, so an order item is saved
+// once and its status, amounts and attribution are updated in place.
+type Conversion struct {
+	Id          uuid.UUID `json:"id" gorm:"primary_key;type:uuid;default:uuidv7()"`
+	UserId      int64     `json:"user_id" gorm:"column:user_id;not null;uniqueIndex:idx_conversion_order_item"`
+	Marketplace string    `json:"marketplace" gorm:"column:marketplace;type:text;not null;uniqueIndex:idx_conversion_order_item"`
+	OrderId     string    `json:"order_id" gorm:"column:order_id;type:text;not null;uniqueIndex:idx_conversion_order_item"`
+	// ItemId is the order item, since commission is paid per item. Postbacks report whole
+	// orders and leave it empty, so an order is only posted back once.
+	ItemId string `json:"item_id" gorm:"column:item_id;type:text;not null;uniqueIndex:idx_conversion_order_item"`
+	// Source is ConversionSourceReport or ConversionSourcePostback.
+	Source string `json:"source" gorm:"column:source;type:text;not null;default:report"`
+	// Status is one of ConversionPending, ConversionApproved or ConversionCancelled.
+	Status string `json:"status" gorm:"column:status;type:text;not null"`
+	// SubIds are the sub ids of the affiliate link the order came through, in order.
+	SubIds []string `json:"sub_ids" gorm:"column:sub_ids;type:text;serializer:json"`
+
+	// LinkId, CampaignId and ProductId are set when one of the sub ids is the short code of one
+	// of the user's links. They are not foreign keys, so conversions outlive purged links.
+	LinkId     uuid.NullUUID `json:"link_id" gorm:"column:link_id;type:uuid;index" swaggertype:"string"`
+	CampaignId uuid.NullUUID `json:"campaign_id" gorm:"column:campaign_id;type:uuid;index" swaggertype:"string"`
+	ProductId  uuid.NullUUID `json:"product_id" gorm:"column:product_id;type:uuid" swaggertype:"string"`
+	// ClickId is the click a postback was recorded against.
+	ClickId uuid.NullUUID `json:"click_id" gorm:"column:click_id;type:uuid" swaggertype:"string"`
+
+	// Amount is what the shopper paid for the item and Commission what the affiliate earns on it,
+	// both in Currency.
+	Amount     float64   `json:"amount" gorm:"column:amount;type:decimal(12,2);not null;default:0"`
+	Commission float64   `json:"commission" gorm:"column:commission;type:decimal(12,2);not null;default:0"`
+	Currency   string    `json:"currency" gorm:"column:currency;type:text;not null;default:''"`
+	OrderedAt  time.Time `json:"ordered_at" gorm:"column:ordered_at;not null;index"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime:milli"`
+}
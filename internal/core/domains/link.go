@@ -15,4 +15,7 @@ type Link struct {
 
 	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime:milli"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime:milli"`
+	// DeletedAt is set while the link is soft deleted. It is purged for good once the
+	// retention period has passed.
+	DeletedAt *time.Time `json:"deleted_at" gorm:"column:deleted_at;index"`
 }
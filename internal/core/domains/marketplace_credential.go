@@ -2,6 +2,9 @@ package domains
 
 import "time"
 
+// DefaultMarketplaceRegion is used for credentials saved without a region.
+const DefaultMarketplaceRegion = "th"
+
 type MarketplaceCredential struct {
 	Id          int64  `json:"id" gorm:"primary_key;autoIncrement"`
 	UserId      int64  `json:"user_id" gorm:"column:user_id;type:bigint REFERENCES users(id);not null;uniqueIndex:idx_user_marketplace"`
@@ -11,6 +14,8 @@ type MarketplaceCredential struct {
 	AppKey    string `json:"app_key" gorm:"column:app_key;type:text;not null"`
 	AppSecret string `json:"app_secret" gorm:"column:app_secret;type:text;not null"`
 	UserToken string `json:"user_token" gorm:"column:user_token;type:text;not null"`
+	// Region selects the country API the credential belongs to: th, my, vn, ph, sg or id.
+	Region string `json:"region" gorm:"column:region;type:text;not null;default:th"`
 
 	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
@@ -6,14 +6,35 @@ import (
 	"github.com/gofrs/uuid"
 )
 
+// Offer availability as last seen by import or refresh.
+const (
+	OfferAvailable  = "available"
+	OfferOutOfStock = "out_of_stock"
+	OfferDelisted   = "delisted"
+)
+
 type Offer struct {
-	Id            uuid.UUID `json:"id" gorm:"primary_key;type:uuid;default:uuidv7()"`
-	ProductId     uuid.UUID `gorm:"column:product_id;type:uuid REFERENCES products(id)"`
-	Marketplace   string    `json:"marketplace" gorm:"column:marketplace;type:text;not null"`
-	StoreName     string    `json:"store_name" gorm:"column:store_name;type:text;not null"`
-	Price         float64   `json:"price" gorm:"column:price;type:decimal(10,2);not null"`
+	Id          uuid.UUID `json:"id" gorm:"primary_key;type:uuid;default:uuidv7()"`
+	ProductId   uuid.UUID `gorm:"column:product_id;type:uuid REFERENCES products(id)"`
+	Marketplace string    `json:"marketplace" gorm:"column:marketplace;type:text;not null"`
+	StoreName   string    `json:"store_name" gorm:"column:store_name;type:text;not null"`
+	Price       float64   `json:"price" gorm:"column:price;type:decimal(10,2);not null"`
+	// Currency is the ISO 4217 code of Price and Commission, e.g. THB.
+	Currency      string    `json:"currency" gorm:"column:currency;type:text;not null;default:THB"`
 	LastCheckedAt time.Time `json:"last_checked_at" gorm:"column:last_checked_at;not null"`
 
+	// CommissionRate is the affiliate commission as a fraction of the price (0.05 = 5%).
+	CommissionRate float64 `json:"commission_rate" gorm:"column:commission_rate;type:decimal(6,4);not null;default:0"`
+	Commission     float64 `json:"commission" gorm:"column:commission;type:decimal(10,2);not null;default:0"`
+	RatingStar     float64 `json:"rating_star" gorm:"column:rating_star;type:decimal(3,2);not null;default:0"`
+	// Sales is the sales volume reported by the marketplace (Lazada only reports the last 7 days).
+	Sales int `json:"sales" gorm:"column:sales;not null;default:0"`
+	// Availability is one of OfferAvailable, OfferOutOfStock or OfferDelisted.
+	Availability string `json:"availability" gorm:"column:availability;type:text;not null;default:available"`
+	// Url is where shoppers land for this offer when a link is rerouted to it. Shopee offers
+	// carry their own affiliate offer link; Lazada offers use the product url.
+	Url string `json:"url" gorm:"column:url;type:text;not null;default:''"`
+
 	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime:milli"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime:milli"`
 }
@@ -7,12 +7,18 @@ import (
 )
 
 type Product struct {
-	Id       uuid.UUID `json:"id" gorm:"primary_key;type:uuid;default:uuidv7()"`
-	Title    string    `json:"title" gorm:"column:title;type:text;not null"`
-	ImageUrl string    `json:"image_url" gorm:"column:image_url;type:text;not null"`
+	Id       uuid.UUID      `json:"id" gorm:"primary_key;type:uuid;default:uuidv7()"`
+	Title    string         `json:"title" gorm:"column:title;type:text;not null"`
+	ImageUrl string         `json:"image_url" gorm:"column:image_url;type:text;not null"`
+	Images   []ProductImage `json:"images" gorm:"foreignKey:ProductId"`
+	Category string         `json:"category" gorm:"column:category;type:text;not null;default:''"`
+	Tags     []Tag          `json:"tags" gorm:"many2many:product_tags"`
 
 	SourceUrl string    `json:"source_url" gorm:"column:source_url;type:text;not null"`
 	UserId    int64     `json:"user_id" gorm:"column:user_id;type:bigint REFERENCES users(id);not null"`
 	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime:milli"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime:milli"`
+	// DeletedAt is set while the product is soft deleted. It is purged for good once the
+	// retention period has passed.
+	DeletedAt *time.Time `json:"deleted_at" gorm:"column:deleted_at;index"`
 }
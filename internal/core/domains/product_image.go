@@ -0,0 +1,17 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type ProductImage struct {
+	Id        uuid.UUID `json:"id" gorm:"primary_key;type:uuid;default:uuidv7()"`
+	ProductId uuid.UUID `json:"product_id" gorm:"column:product_id;type:uuid REFERENCES products(id);not null;index"`
+	Url       string    `json:"url" gorm:"column:url;type:text;not null"`
+	Position  int       `json:"position" gorm:"column:position;not null;default:0"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime:milli"`
+}
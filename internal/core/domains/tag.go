@@ -0,0 +1,16 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type Tag struct {
+	Id     uuid.UUID `json:"id" gorm:"primary_key;type:uuid;default:uuidv7()"`
+	Name   string    `json:"name" gorm:"column:name;type:text;not null;uniqueIndex:idx_user_tag_name"`
+	UserId int64     `json:"user_id" gorm:"column:user_id;type:bigint REFERENCES users(id);not null;uniqueIndex:idx_user_tag_name"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime:milli"`
+}
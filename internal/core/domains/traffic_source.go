@@ -0,0 +1,30 @@
+package domains
+
+import "github.com/market-place-affiliate/api/pkg/useragent"
+
+// Traffic sources of clicks that do not come from one of the SocialNetworks.
+const (
+	TrafficSourceDirect = "direct"
+	TrafficSourceOther  = "other"
+)
+
+// SocialNetwork is a traffic source recognised by the referrer domains of its sites, including
+// their subdomains, or by its in-app browser, which often sends no referrer.
+type SocialNetwork struct {
+	Key     string
+	Name    string
+	Domains []string
+	Browser string
+}
+
+// SocialNetworks are the traffic sources clicks are grouped into, besides direct and other.
+var SocialNetworks = []SocialNetwork{
+	{Key: "facebook", Name: "Facebook", Domains: []string{"facebook.com", "fb.com", "fb.me", "messenger.com"}, Browser: useragent.BrowserFacebook},
+	{Key: "instagram", Name: "Instagram", Domains: []string{"instagram.com"}, Browser: useragent.BrowserInstagram},
+	{Key: "tiktok", Name: "TikTok", Domains: []string{"tiktok.com"}, Browser: useragent.BrowserTikTok},
+	{Key: "line", Name: "LINE", Domains: []string{"line.me"}, Browser: useragent.BrowserLine},
+	{Key: "youtube", Name: "YouTube", Domains: []string{"youtube.com", "youtu.be"}},
+	{Key: "x", Name: "X", Domains: []string{"x.com", "twitter.com", "t.co"}},
+	{Key: "pinterest", Name: "Pinterest", Domains: []string{"pinterest.com", "pin.it"}},
+	{Key: "reddit", Name: "Reddit", Domains: []string{"reddit.com"}},
+}
@@ -2,10 +2,23 @@ package domains
 
 import "time"
 
+// What the redirect does when a link's product is no longer available.
+const (
+	// LinkPolicyKeep sends shoppers to the original affiliate url anyway.
+	LinkPolicyKeep = "keep"
+	// LinkPolicyReroute sends shoppers to another available offer of the same product.
+	LinkPolicyReroute = "reroute"
+	// LinkPolicyFallback sends shoppers to the user's fallback url.
+	LinkPolicyFallback = "fallback"
+)
+
 type User struct {
-	Id       int64  `json:"id" gorm:"primary_key;autoIncrement"`
-	Email    string `json:"email" gorm:"column:email;type:text;not null;unique"`
-	Password string `json:"password" gorm:"column:password;type:text;not null"`
-	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	Id                    int64     `json:"id" gorm:"primary_key;autoIncrement"`
+	Email                 string    `json:"email" gorm:"column:email;type:text;not null;unique"`
+	Password              string    `json:"password" gorm:"column:password;type:text;not null"`
+	UnavailableLinkPolicy string    `json:"unavailable_link_policy" gorm:"column:unavailable_link_policy;type:text;not null;default:keep"`
+	FallbackUrl           string    `json:"fallback_url" gorm:"column:fallback_url;type:text;not null;default:''"`
+	PostbackSecret        string    `json:"-" gorm:"column:postback_secret;type:text;not null;default:''"`
+	CreatedAt             time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt             time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
 }
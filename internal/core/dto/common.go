@@ -1,11 +1,24 @@
 package dto
 
-
 type Response[T any] struct {
-	HttpCode int               `json:"-"`
-	Success  bool              `json:"success"`
-	Code     int               `json:"code"`
-	Message  string            `json:"message"`
-	TxnID    string            `json:"txn_id"`
-	Data     T                 `json:"data,omitempty"`
+	HttpCode int    `json:"-"`
+	Success  bool   `json:"success"`
+	Code     int    `json:"code"`
+	Message  string `json:"message"`
+	TxnID    string `json:"txn_id"`
+	Data     T      `json:"data,omitempty"`
+	// Meta describes the page of a paginated listing.
+	Meta *PageMeta `json:"meta,omitempty"`
+}
+
+// PageMeta is returned with paginated listings. Page and TotalPages are zero when the listing
+// was requested with a cursor.
+type PageMeta struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+	HasMore    bool  `json:"has_more"`
+	// NextCursor fetches the page after this one when passed as cursor.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -18,7 +19,7 @@ type LoginRequest struct {
 
 type CreateProductRequest struct {
 	SourceUrl   string `json:"source_url" binding:"required,url"`
-	Marketplace string `json:"marketplace" binding:"required,oneof=shopee lazada"`
+	Marketplace string `json:"marketplace" binding:"required"`
 }
 
 type CreateCampaignRequest struct {
@@ -26,6 +27,151 @@ type CreateCampaignRequest struct {
 	UtmCampaign string    `json:"utm_campaign" binding:"required,min=3,max=100"`
 	StartAt     time.Time `json:"start_at" binding:"required"`
 	EndAt       time.Time `json:"end_at" binding:"required,gtefield=StartAt"`
+	// Draft keeps the campaign out of the scheduler until it is published.
+	Draft bool `json:"draft"`
+}
+
+// CampaignStateRequest moves a campaign to another state. Scheduled (or active) publishes a
+// draft or resumes a paused campaign; it becomes active once its start date is reached.
+type CampaignStateRequest struct {
+	State string `json:"state" binding:"required,oneof=draft scheduled active paused ended archived"`
+}
+
+// UpdateCampaignRequest changes only the fields that are set.
+type UpdateCampaignRequest struct {
+	Name        *string    `json:"name" binding:"omitempty,min=3,max=100"`
+	UtmCampaign *string    `json:"utm_campaign" binding:"omitempty,min=3,max=100"`
+	StartAt     *time.Time `json:"start_at"`
+	EndAt       *time.Time `json:"end_at"`
+	// RegenerateLinks asks the marketplaces for new affiliate urls for the campaign's links
+	// when UtmCampaign changes, so clicks are attributed to the new value.
+	RegenerateLinks bool `json:"regenerate_links"`
+}
+
+// DashboardMetricsRequest selects the clicks shown on the dashboard. StartAt and EndAt are
+// YYYY-MM-DD dates or RFC 3339 times; dates are midnight in Timezone. EndAt is exclusive.
+type DashboardMetricsRequest struct {
+	StartAt     string `form:"start_at" binding:"omitempty,max=35"`
+	EndAt       string `form:"end_at" binding:"omitempty,max=35"`
+	Granularity string `form:"granularity" binding:"omitempty,oneof=hour day week month"`
+	// Timezone is an IANA name such as Asia/Bangkok. Buckets start at midnight (or the hour) in it.
+	Timezone string `form:"tz" binding:"omitempty,max=64"`
+	// Compare also counts the clicks of a comparison period (see ComparePreviousPeriod) when set.
+	Compare string `form:"compare" binding:"omitempty,oneof=previous_period previous_month previous_year"`
+}
+
+// Periods analytics can be compared with: the period of the same length just before, or the same
+// dates a month or a year earlier.
+const (
+	ComparePreviousPeriod = "previous_period"
+	ComparePreviousMonth  = "previous_month"
+	ComparePreviousYear   = "previous_year"
+)
+
+// Leaderboard dimensions.
+const (
+	LeaderboardProducts     = "products"
+	LeaderboardLinks        = "links"
+	LeaderboardCampaigns    = "campaigns"
+	LeaderboardMarketplaces = "marketplaces"
+)
+
+// DashboardLeaderboardRequest selects the range, size and ranking of the dashboard leaderboards.
+// The range works like in DashboardMetricsRequest.
+type DashboardLeaderboardRequest struct {
+	StartAt  string `form:"start_at" binding:"omitempty,max=35"`
+	EndAt    string `form:"end_at" binding:"omitempty,max=35"`
+	Timezone string `form:"tz" binding:"omitempty,max=64"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=50"`
+	Sort     string `form:"sort" binding:"omitempty,oneof=clicks unique_visitors"`
+	// Compare is the period the deltas compare with, previous_period by default.
+	Compare string `form:"compare" binding:"omitempty,oneof=previous_period previous_month previous_year"`
+}
+
+// Breakdown dimensions, which group clicks by what their visitor sent. Sources are the
+// domains.SocialNetworks, direct and other.
+const (
+	BreakdownSource   = "source"
+	BreakdownReferrer = "referrer"
+	BreakdownDevice   = "device"
+	BreakdownOs       = "os"
+	BreakdownBrowser  = "browser"
+	BreakdownLanguage = "language"
+)
+
+// DashboardBreakdownRequest groups the clicks of a range by one dimension. The range and the
+// comparison work like in DashboardLeaderboardRequest; CampaignId and LinkId narrow the clicks
+// down.
+type DashboardBreakdownRequest struct {
+	Dimension  string `form:"dimension" binding:"required,oneof=source referrer device os browser language"`
+	StartAt    string `form:"start_at" binding:"omitempty,max=35"`
+	EndAt      string `form:"end_at" binding:"omitempty,max=35"`
+	Timezone   string `form:"tz" binding:"omitempty,max=64"`
+	CampaignId string `form:"campaign_id" binding:"omitempty,uuid"`
+	LinkId     string `form:"link_id" binding:"omitempty,uuid"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Sort       string `form:"sort" binding:"omitempty,oneof=clicks unique_visitors"`
+	Compare    string `form:"compare" binding:"omitempty,oneof=previous_period previous_month previous_year"`
+}
+
+// CampaignReportRequest selects the days of a campaign report, as YYYY-MM-DD dates in the
+// campaign timezone. They default to the campaign's start date and today or its end date.
+type CampaignReportRequest struct {
+	StartAt string `form:"start_at" binding:"omitempty,datetime=2006-01-02"`
+	EndAt   string `form:"end_at" binding:"omitempty,datetime=2006-01-02"`
+	// Compare also reports a comparison period (see ComparePreviousPeriod) when set.
+	Compare string `form:"compare" binding:"omitempty,oneof=previous_period previous_month previous_year"`
+}
+
+// ExportRequest picks the file format of an export, csv by default.
+type ExportRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+}
+
+// ClickLogRequest selects the clicks of a click log export. The range works like in
+// DashboardMetricsRequest; CampaignId and LinkId narrow it down.
+type ClickLogRequest struct {
+	StartAt    string `form:"start_at" binding:"omitempty,max=35"`
+	EndAt      string `form:"end_at" binding:"omitempty,max=35"`
+	Timezone   string `form:"tz" binding:"omitempty,max=64"`
+	CampaignId string `form:"campaign_id" binding:"omitempty,uuid"`
+	LinkId     string `form:"link_id" binding:"omitempty,uuid"`
+}
+
+// ClickStreamRequest narrows a click stream down to one campaign or link.
+type ClickStreamRequest struct {
+	CampaignId string `form:"campaign_id" binding:"omitempty,uuid"`
+	LinkId     string `form:"link_id" binding:"omitempty,uuid"`
+}
+
+// CloneCampaignRequest copies a campaign and its products under new dates and UTM value. Name
+// defaults to the source campaign's name.
+type CloneCampaignRequest struct {
+	Name        string    `json:"name" binding:"omitempty,min=3,max=100"`
+	UtmCampaign string    `json:"utm_campaign" binding:"required,min=3,max=100"`
+	StartAt     time.Time `json:"start_at" binding:"required"`
+	EndAt       time.Time `json:"end_at" binding:"required"`
+	Draft       bool      `json:"draft"`
+}
+
+// ClickRequest describes the visitor behind a redirect.
+type ClickRequest struct {
+	IpAddress      string
+	UserAgent      string
+	Referrer       string
+	AcceptLanguage string
+}
+
+// CampaignGoalRequest sets the targets of a campaign. At least one target is required.
+type CampaignGoalRequest struct {
+	TargetClicks         int64   `json:"target_clicks" binding:"omitempty,min=0"`
+	TargetUniqueVisitors int64   `json:"target_unique_visitors" binding:"omitempty,min=0"`
+	TargetCommission     float64 `json:"target_commission" binding:"omitempty,min=0"`
+	// Deadline defaults to the campaign's EndAt.
+	Deadline *time.Time `json:"deadline"`
+	// ConversionRate defaults to 0.02.
+	ConversionRate   float64 `json:"conversion_rate" binding:"omitempty,gt=0,lte=1"`
+	NotifyThresholds []int   `json:"notify_thresholds" binding:"omitempty,max=10,dive,min=1,max=1000"`
 }
 
 type CreateLinkRequest struct {
@@ -37,16 +183,91 @@ type GetCampaignByQueryRequest struct {
 	Name    string    `form:"name" binding:"omitempty,min=3,max=100"`
 	StartAt time.Time `form:"start_at" binding:"omitempty"`
 	EndAt   time.Time `form:"end_at" binding:"omitempty,gtfield=StartAt"`
+	State   string    `form:"state" binding:"omitempty,oneof=draft scheduled active paused ended archived"`
+	// Deleted lists soft deleted campaigns instead, e.g. to find one to restore.
+	Deleted bool `form:"deleted"`
 
 	Page  int `form:"page" binding:"omitempty,min=1"`
 	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+	// Cursor is the next_cursor of a previous page. When set, Page is ignored.
+	Cursor string `form:"cursor" binding:"omitempty,max=500"`
+	Sort   string `form:"sort" binding:"omitempty,oneof=start_at end_at name clicks"`
+	Order  string `form:"order" binding:"omitempty,oneof=asc desc"`
 }
 
 type MarketplaceCredentialRequest struct {
-	Platform   string `json:"platform" binding:"required,oneof=shopee lazada"`
+	Platform   string `json:"platform" binding:"required"`
 	AppKey     string `json:"app_key"`
 	SignMethod string `json:"sign_method"`
 	UserToken  string `json:"user_token"`
 	AppId      string `json:"app_id"`
 	AppSecret  string `json:"app_secret"`
+	// Region defaults to th.
+	Region string `json:"region" binding:"omitempty,oneof=th my vn ph sg id"`
+}
+
+// LinkPolicyRequest sets what the redirect does when a link's product becomes unavailable.
+type LinkPolicyRequest struct {
+	Policy      string `json:"policy" binding:"required,oneof=keep reroute fallback"`
+	FallbackUrl string `json:"fallback_url" binding:"required_if=Policy fallback,omitempty,url"`
+}
+
+// PostbackRequest is a conversion a network or advertiser posts back against a click id, by
+// query string, form or JSON. Amounts are kept as sent so the signature can be checked. Secret
+// is not bound from forms, which include the query string: urls end up in access logs.
+type PostbackRequest struct {
+	ClickId string `form:"click_id" json:"click_id" binding:"required"`
+	OrderId string `form:"order_id" json:"order_id" binding:"required,max=200"`
+	// Payout is the affiliate's commission and Amount the order value, both in Currency.
+	Payout   string `form:"payout" json:"payout" binding:"omitempty,numeric"`
+	Amount   string `form:"amount" json:"amount" binding:"omitempty,numeric"`
+	Currency string `form:"currency" json:"currency" binding:"omitempty,len=3"`
+	// Status defaults to approved.
+	Status string `form:"status" json:"status" binding:"omitempty,oneof=pending approved cancelled"`
+	// Either Secret is the user's postback secret, or Signature is the hex HMAC-SHA256 of the
+	// other parameters keyed by it. See PostbackRequest.SignedPayload.
+	Secret    string `form:"-" json:"secret"`
+	Signature string `form:"signature" json:"signature"`
+}
+
+// SignedPayload is what the signature of a postback signs: the non-empty parameters other
+// than secret and signature, query encoded in key order, e.g.
+// amount=599&click_id=...&order_id=LZ-9001&payout=71.88.
+func (r PostbackRequest) SignedPayload() string {
+	params := url.Values{}
+	for key, value := range map[string]string{
+		"click_id": r.ClickId,
+		"order_id": r.OrderId,
+		"payout":   r.Payout,
+		"amount":   r.Amount,
+		"currency": r.Currency,
+		"status":   r.Status,
+	} {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+	return params.Encode()
+}
+
+type GetProductsQueryRequest struct {
+	TagId        string `form:"tag_id" binding:"omitempty,uuid"`
+	CollectionId string `form:"collection_id" binding:"omitempty,uuid"`
+	// Deleted lists soft deleted products instead, e.g. to find one to restore.
+	Deleted bool `form:"deleted"`
+}
+
+type TagRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=50"`
+}
+
+type CollectionRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=100"`
+	Description string `json:"description" binding:"omitempty,max=500"`
+	// ProductIds sets the products of the collection in order. Omit it to keep the current items.
+	ProductIds []uuid.UUID `json:"product_ids"`
+}
+
+type CreateCollectionLinksRequest struct {
+	CampaignId uuid.UUID `json:"campaign_id" binding:"required"`
 }
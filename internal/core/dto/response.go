@@ -1,24 +1,362 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/gofrs/uuid"
 	"github.com/market-place-affiliate/api/internal/core/domains"
 )
 
 type DashboardMetricsResponse struct {
-	TopProduct TopProduct    `json:"top_product"`
-	Metrics    []MetrictItem `json:"metrics"`
+	// TopProduct is the most clicked product in the range, or null when nothing was clicked.
+	TopProduct *TopProduct `json:"top_product"`
+	// Metrics has an item for every bucket of every campaign and marketplace with clicks in the
+	// range, with zero clicks for buckets without any.
+	Metrics     []MetrictItem `json:"metrics"`
+	Granularity string        `json:"granularity"`
+	Timezone    string        `json:"tz"`
+	// Performance sums each series over the whole range.
+	Performance []SeriesPerformance `json:"performance"`
+	// Comparison is only set when the request asks for one.
+	Comparison *DashboardComparison `json:"comparison,omitempty"`
+}
+
+// SeriesPerformance relates the orders of a campaign and marketplace to its clicks.
+// ConversionRate is conversions per click and Epc the revenue per click; both are null without
+// clicks. Orders not attributed to a link are listed under the nil campaign id.
+type SeriesPerformance struct {
+	CampaignId     uuid.UUID `json:"campaign"`
+	CampaignName   string    `json:"campaign_name"`
+	Marketplace    string    `json:"marketplace"`
+	Currency       string    `json:"currency"`
+	Clicks         int64     `json:"clicks"`
+	Conversions    int64     `json:"conversions"`
+	Sales          float64   `json:"sales"`
+	Revenue        float64   `json:"revenue"`
+	ConversionRate *float64  `json:"conversion_rate"`
+	Epc            *float64  `json:"epc"`
+}
+
+// DashboardComparison is the dashboard of the comparison period [StartAt, EndAt). Series
+// without clicks in one of the periods are listed in both with zero clicks.
+type DashboardComparison struct {
+	Compare string    `json:"compare"`
+	StartAt time.Time `json:"start_at"`
+	EndAt   time.Time `json:"end_at"`
+	// Metrics are the buckets of the comparison period.
+	Metrics []MetrictItem      `json:"metrics"`
+	Series  []SeriesComparison `json:"series"`
+	Clicks  MetricDelta        `json:"clicks"`
+}
+
+// SeriesComparison compares the clicks of a campaign and marketplace over the whole range.
+type SeriesComparison struct {
+	CampaignId   uuid.UUID   `json:"campaign"`
+	CampaignName string      `json:"campaign_name"`
+	Marketplace  string      `json:"marketplace"`
+	Currency     string      `json:"currency"`
+	Clicks       MetricDelta `json:"clicks"`
+}
+
+// MetricDelta compares a metric with the comparison period. DeltaRatio is the change relative
+// to Previous (0.5 is 50% up), or null when Previous is 0.
+type MetricDelta struct {
+	Current    int64    `json:"current"`
+	Previous   int64    `json:"previous"`
+	Delta      int64    `json:"delta"`
+	DeltaRatio *float64 `json:"delta_ratio"`
 }
 
 type MetrictItem struct {
+	// Date is the start of the bucket in the requested timezone: YYYY-MM-DD, or YYYY-MM-DDTHH:00
+	// for hourly buckets.
 	Date         string    `json:"date" gorm:"column:date"`
 	ClickCount   int       `json:"click_count" gorm:"column:click_count"`
 	CampaignId   uuid.UUID `json:"campaign" gorm:"column:campaign_id"`
 	CampaignName string    `json:"campaign_name" gorm:"column:campaign_name;type:text;not null"`
 	Marketplace  string    `json:"marketplace" gorm:"column:marketplace"`
+	Currency     string    `json:"currency" gorm:"column:currency"`
+	// Conversions counts the orders placed in the bucket, Sales their amount and Revenue the
+	// commission earned on them, in Currency. Cancelled orders are left out.
+	Conversions int     `json:"conversions" gorm:"column:conversions"`
+	Sales       float64 `json:"sales" gorm:"column:sales"`
+	Revenue     float64 `json:"revenue" gorm:"column:revenue"`
+}
+
+// ClickLogEntry is a click of the click log export. Marketplace is that of the product's primary
+// offer.
+type ClickLogEntry struct {
+	ClickId        uuid.UUID `gorm:"column:click_id"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+	LinkId         uuid.UUID `gorm:"column:link_id"`
+	ShortCode      string    `gorm:"column:short_code"`
+	CampaignId     uuid.UUID `gorm:"column:campaign_id"`
+	CampaignName   string    `gorm:"column:campaign_name"`
+	ProductId      uuid.UUID `gorm:"column:product_id"`
+	ProductTitle   string    `gorm:"column:product_title"`
+	Marketplace    string    `gorm:"column:marketplace"`
+	VisitorId      string    `gorm:"column:visitor_id"`
+	ReferrerDomain string    `gorm:"column:referrer_domain"`
+	Device         string    `gorm:"column:device"`
+	Os             string    `gorm:"column:os"`
+	Browser        string    `gorm:"column:browser"`
+	Language       string    `gorm:"column:language"`
 }
 
 type TopProduct struct {
 	Product domains.Product `json:"product" `
 	Clicks  int64           `json:"clicks"`
 }
+
+// LeaderboardEntry ranks a product, link, campaign or marketplace. Key is its id, or the
+// marketplace name, and Label its title, short code, name or marketplace name.
+type LeaderboardEntry struct {
+	Rank                   int    `json:"rank" gorm:"-"`
+	Key                    string `json:"key" gorm:"column:key"`
+	Label                  string `json:"label" gorm:"column:label"`
+	Clicks                 int64  `json:"clicks" gorm:"column:clicks"`
+	UniqueVisitors         int64  `json:"unique_visitors" gorm:"column:unique_visitors"`
+	PreviousClicks         int64  `json:"previous_clicks" gorm:"column:previous_clicks"`
+	PreviousUniqueVisitors int64  `json:"previous_unique_visitors" gorm:"column:previous_unique_visitors"`
+	// Delta is the change of the sort metric since the previous period and DeltaRatio that
+	// change relative to it, or null when the previous period had none.
+	Delta      int64    `json:"delta" gorm:"-"`
+	DeltaRatio *float64 `json:"delta_ratio" gorm:"-"`
+}
+
+type DashboardLeaderboardsResponse struct {
+	StartAt time.Time `json:"start_at"`
+	EndAt   time.Time `json:"end_at"`
+	// Compare, PreviousStartAt and PreviousEndAt give the period the deltas compare with.
+	Compare         string             `json:"compare"`
+	PreviousStartAt time.Time          `json:"previous_start_at"`
+	PreviousEndAt   time.Time          `json:"previous_end_at"`
+	Timezone        string             `json:"tz"`
+	Sort            string             `json:"sort"`
+	Products        []LeaderboardEntry `json:"products"`
+	Links           []LeaderboardEntry `json:"links"`
+	Campaigns       []LeaderboardEntry `json:"campaigns"`
+	Marketplaces    []LeaderboardEntry `json:"marketplaces"`
+}
+
+// DashboardBreakdownResponse ranks the values of a breakdown dimension. Key is the value, empty
+// when unknown, and Label its display name.
+type DashboardBreakdownResponse struct {
+	Dimension       string             `json:"dimension"`
+	StartAt         time.Time          `json:"start_at"`
+	EndAt           time.Time          `json:"end_at"`
+	Compare         string             `json:"compare"`
+	PreviousStartAt time.Time          `json:"previous_start_at"`
+	PreviousEndAt   time.Time          `json:"previous_end_at"`
+	Timezone        string             `json:"tz"`
+	Sort            string             `json:"sort"`
+	Entries         []LeaderboardEntry `json:"entries"`
+}
+
+type BulkLinkResponse struct {
+	Links    []domains.Link `json:"links"`
+	Failures []LinkFailure  `json:"failures"`
+}
+
+// CampaignUpdateResponse is the updated campaign and, when links were regenerated, the links
+// that got a new affiliate url and the ones that failed.
+type CampaignUpdateResponse struct {
+	Campaign domains.Campaign `json:"campaign"`
+	Links    []domains.Link   `json:"links"`
+	Failures []LinkFailure    `json:"failures"`
+}
+
+// CampaignCloneResponse is the new campaign with the links created for it and the products
+// whose link could not be created.
+type CampaignCloneResponse struct {
+	Campaign domains.Campaign `json:"campaign"`
+	Links    []domains.Link   `json:"links"`
+	Failures []LinkFailure    `json:"failures"`
+}
+
+type CampaignClickStats struct {
+	ClickCount     int64 `gorm:"column:click_count"`
+	UniqueVisitors int64 `gorm:"column:unique_visitors"`
+	// Commission is the sum of the offer commission of every click, before any conversion rate.
+	Commission float64 `gorm:"column:commission"`
+}
+
+// CampaignReportResponse is a campaign's traffic between StartAt and EndAt, both inclusive dates
+// in the campaign timezone.
+type CampaignReportResponse struct {
+	Campaign domains.Campaign        `json:"campaign"`
+	StartAt  string                  `json:"start_at"`
+	EndAt    string                  `json:"end_at"`
+	Totals   ClickTotals             `json:"totals"`
+	Daily    []DailyClicks           `json:"daily"`
+	Links    []CampaignLinkReport    `json:"links"`
+	Products []CampaignProductReport `json:"products"`
+	// Comparison is only set when the request asks for one.
+	Comparison *CampaignReportComparison `json:"comparison,omitempty"`
+}
+
+// CampaignReportComparison is the campaign's traffic between StartAt and EndAt, the inclusive
+// dates of the comparison period, and how the report compares with it. Links and products
+// with clicks in only one of the periods are compared with zero.
+type CampaignReportComparison struct {
+	Compare        string              `json:"compare"`
+	StartAt        string              `json:"start_at"`
+	EndAt          string              `json:"end_at"`
+	Clicks         MetricDelta         `json:"clicks"`
+	UniqueVisitors MetricDelta         `json:"unique_visitors"`
+	Daily          []DailyClicks       `json:"daily"`
+	Links          []LinkComparison    `json:"links"`
+	Products       []ProductComparison `json:"products"`
+}
+
+type LinkComparison struct {
+	LinkId         uuid.UUID   `json:"link_id"`
+	ShortCode      string      `json:"short_code"`
+	Clicks         MetricDelta `json:"clicks"`
+	UniqueVisitors MetricDelta `json:"unique_visitors"`
+}
+
+type ProductComparison struct {
+	ProductId      uuid.UUID   `json:"product_id"`
+	ProductTitle   string      `json:"product_title"`
+	Clicks         MetricDelta `json:"clicks"`
+	UniqueVisitors MetricDelta `json:"unique_visitors"`
+}
+
+type ClickTotals struct {
+	Clicks         int64 `json:"clicks"`
+	UniqueVisitors int64 `json:"unique_visitors"`
+}
+
+type DailyClicks struct {
+	Date           string `json:"date" gorm:"column:date"`
+	Clicks         int64  `json:"clicks" gorm:"column:clicks"`
+	UniqueVisitors int64  `json:"unique_visitors" gorm:"column:unique_visitors"`
+}
+
+type CampaignLinkReport struct {
+	LinkId       uuid.UUID `json:"link_id" gorm:"column:link_id"`
+	ShortCode    string    `json:"short_code" gorm:"column:short_code"`
+	ProductId    uuid.UUID `json:"product_id" gorm:"column:product_id"`
+	ProductTitle string    `json:"product_title" gorm:"column:product_title"`
+	// Marketplace is the marketplace of the product's primary offer.
+	Marketplace    string `json:"marketplace" gorm:"column:marketplace"`
+	Clicks         int64  `json:"clicks" gorm:"column:clicks"`
+	UniqueVisitors int64  `json:"unique_visitors" gorm:"column:unique_visitors"`
+	// Share is the link's fraction of the campaign's clicks in the report range.
+	Share float64 `json:"share" gorm:"-"`
+	// Deleted links are only listed when they have clicks in the range.
+	Deleted bool `json:"deleted" gorm:"column:deleted"`
+}
+
+type CampaignProductReport struct {
+	ProductId      uuid.UUID `json:"product_id" gorm:"column:product_id"`
+	ProductTitle   string    `json:"product_title" gorm:"column:product_title"`
+	Marketplace    string    `json:"marketplace" gorm:"column:marketplace"`
+	Clicks         int64     `json:"clicks" gorm:"column:clicks"`
+	UniqueVisitors int64     `json:"unique_visitors" gorm:"column:unique_visitors"`
+	Share          float64   `json:"share" gorm:"-"`
+}
+
+// CampaignProgressResponse compares a campaign's clicks so far with its goal.
+type CampaignProgressResponse struct {
+	Goal domains.CampaignGoal `json:"goal"`
+	// ElapsedRatio is the share of the time between StartAt and the deadline that has passed.
+	ElapsedRatio float64              `json:"elapsed_ratio"`
+	Status       string               `json:"status"`
+	Metrics      []GoalMetricProgress `json:"metrics"`
+}
+
+type GoalMetricProgress struct {
+	Metric string  `json:"metric"`
+	Target float64 `json:"target"`
+	Actual float64 `json:"actual"`
+	// Attainment is Actual / Target.
+	Attainment float64 `json:"attainment"`
+	// Expected is where Actual should be by now to reach Target at a constant pace.
+	Expected float64 `json:"expected"`
+	// Projected is Actual extrapolated to the deadline at the pace so far.
+	Projected float64 `json:"projected"`
+	Status    string  `json:"status"`
+}
+
+type LinkFailure struct {
+	ProductId uuid.UUID `json:"product_id"`
+	Code      int       `json:"code"`
+	Message   string    `json:"message"`
+}
+
+// UnavailableProduct is a product whose offers are all out of stock or delisted, with the
+// number of links still pointing at it.
+type UnavailableProduct struct {
+	Product   domains.Product `json:"product"`
+	Offers    []domains.Offer `json:"offers"`
+	LinkCount int             `json:"link_count"`
+}
+
+// ProductRefreshResponse is the refreshed product and what the marketplace changed about it.
+type ProductRefreshResponse struct {
+	Product       domains.Product `json:"product"`
+	Changes       []FieldChange   `json:"changes"`
+	OffersAdded   []domains.Offer `json:"offers_added"`
+	OffersUpdated []OfferChange   `json:"offers_updated"`
+	// OffersRemoved are offers the marketplace no longer lists. They are kept, marked delisted.
+	OffersRemoved []domains.Offer `json:"offers_removed"`
+}
+
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+type OfferChange struct {
+	OfferId   uuid.UUID     `json:"offer_id"`
+	StoreName string        `json:"store_name"`
+	Changes   []FieldChange `json:"changes"`
+}
+
+type MarketplaceProduct struct {
+	Product domains.Product `json:"product"`
+	Offers  []domains.Offer `json:"offers"`
+}
+
+// StorefrontCampaign is what a landing page needs to render an active campaign.
+type StorefrontCampaign struct {
+	Id       uuid.UUID           `json:"id"`
+	Name     string              `json:"name"`
+	StartAt  time.Time           `json:"start_at"`
+	EndAt    time.Time           `json:"end_at"`
+	Products []StorefrontProduct `json:"products"`
+}
+
+type StorefrontProduct struct {
+	Id       uuid.UUID `json:"id"`
+	Title    string    `json:"title"`
+	ImageUrl string    `json:"image_url"`
+	Images   []string  `json:"images"`
+	Category string    `json:"category"`
+	// BestOffer is the cheapest available offer, or nil when every offer is out of stock or delisted.
+	BestOffer *StorefrontOffer `json:"best_offer"`
+	ShortCode string           `json:"short_code"`
+	// ShortUrl is the tracked redirect shoppers should be sent to.
+	ShortUrl string `json:"short_url"`
+}
+
+type StorefrontOffer struct {
+	Marketplace string  `json:"marketplace"`
+	StoreName   string  `json:"store_name"`
+	Price       float64 `json:"price"`
+	Currency    string  `json:"currency"`
+	RatingStar  float64 `json:"rating_star"`
+}
+
+type UserStorefront struct {
+	UserId    int64                `json:"user_id"`
+	Campaigns []StorefrontCampaign `json:"campaigns"`
+}
+
+// PostbackSecretResponse is a newly generated postback secret.
+type PostbackSecretResponse struct {
+	Secret string `json:"secret"`
+}
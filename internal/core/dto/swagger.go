@@ -56,6 +56,7 @@ type CampaignsResponse struct {
 	Message string             `json:"message" example:"Campaigns retrieved successfully"`
 	TxnID   string             `json:"txn_id" example:"txn_123456"`
 	Data    []domains.Campaign `json:"data,omitempty"`
+	Meta    PageMeta           `json:"meta,omitempty"`
 }
 
 // LinkResponse represents a response with link data
@@ -110,3 +111,165 @@ type DashboardResponse struct {
 	TxnID   string                   `json:"txn_id" example:"txn_123456"`
 	Data    DashboardMetricsResponse `json:"data,omitempty"`
 }
+
+// DashboardLeaderboardsResult represents a response with the dashboard leaderboards
+type DashboardLeaderboardsResult struct {
+	Success bool                          `json:"success" example:"true"`
+	Code    int                           `json:"code" example:"0"`
+	Message string                        `json:"message" example:"Leaderboards fetched successfully"`
+	TxnID   string                        `json:"txn_id" example:"txn_123456"`
+	Data    DashboardLeaderboardsResponse `json:"data,omitempty"`
+}
+
+// DashboardBreakdownResult represents a response with a dashboard breakdown
+type DashboardBreakdownResult struct {
+	Success bool                       `json:"success" example:"true"`
+	Code    int                        `json:"code" example:"0"`
+	Message string                     `json:"message" example:"Breakdown fetched successfully"`
+	TxnID   string                     `json:"txn_id" example:"txn_123456"`
+	Data    DashboardBreakdownResponse `json:"data,omitempty"`
+}
+
+// TagResponse represents a response with tag data
+type TagResponse struct {
+	Success bool        `json:"success" example:"true"`
+	Code    int         `json:"code" example:"0"`
+	Message string      `json:"message" example:"Tag created successfully"`
+	TxnID   string      `json:"txn_id" example:"txn_123456"`
+	Data    domains.Tag `json:"data,omitempty"`
+}
+
+// TagsResponse represents a response with tag array
+type TagsResponse struct {
+	Success bool          `json:"success" example:"true"`
+	Code    int           `json:"code" example:"0"`
+	Message string        `json:"message" example:"Tags fetched successfully"`
+	TxnID   string        `json:"txn_id" example:"txn_123456"`
+	Data    []domains.Tag `json:"data,omitempty"`
+}
+
+// CollectionResponse represents a response with collection data
+type CollectionResponse struct {
+	Success bool               `json:"success" example:"true"`
+	Code    int                `json:"code" example:"0"`
+	Message string             `json:"message" example:"Collection fetched successfully"`
+	TxnID   string             `json:"txn_id" example:"txn_123456"`
+	Data    domains.Collection `json:"data,omitempty"`
+}
+
+// CollectionsResponse represents a response with collection array
+type CollectionsResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Code    int                  `json:"code" example:"0"`
+	Message string               `json:"message" example:"Collections fetched successfully"`
+	TxnID   string               `json:"txn_id" example:"txn_123456"`
+	Data    []domains.Collection `json:"data,omitempty"`
+}
+
+// BulkLinkResult represents a response with created links and per-product failures
+type BulkLinkResult struct {
+	Success bool             `json:"success" example:"true"`
+	Code    int              `json:"code" example:"0"`
+	Message string           `json:"message" example:"Links created successfully"`
+	TxnID   string           `json:"txn_id" example:"txn_123456"`
+	Data    BulkLinkResponse `json:"data,omitempty"`
+}
+
+// UnavailableProductsResponse represents a response with products that have no available offer
+type UnavailableProductsResponse struct {
+	Success bool                 `json:"success" example:"true"`
+	Code    int                  `json:"code" example:"0"`
+	Message string               `json:"message" example:"Unavailable products fetched successfully"`
+	TxnID   string               `json:"txn_id" example:"txn_123456"`
+	Data    []UnavailableProduct `json:"data,omitempty"`
+}
+
+// ProductRefreshResult represents a response with a refreshed product and its changes
+type ProductRefreshResult struct {
+	Success bool                   `json:"success" example:"true"`
+	Code    int                    `json:"code" example:"0"`
+	Message string                 `json:"message" example:"Product refreshed successfully"`
+	TxnID   string                 `json:"txn_id" example:"txn_123456"`
+	Data    ProductRefreshResponse `json:"data,omitempty"`
+}
+
+// CampaignUpdateResult represents a response with an updated campaign and its regenerated links
+type CampaignUpdateResult struct {
+	Success bool                   `json:"success" example:"true"`
+	Code    int                    `json:"code" example:"0"`
+	Message string                 `json:"message" example:"Campaign updated successfully"`
+	TxnID   string                 `json:"txn_id" example:"txn_123456"`
+	Data    CampaignUpdateResponse `json:"data,omitempty"`
+}
+
+// CampaignCloneResult represents a response with a cloned campaign and its new links
+type CampaignCloneResult struct {
+	Success bool                  `json:"success" example:"true"`
+	Code    int                   `json:"code" example:"0"`
+	Message string                `json:"message" example:"Campaign cloned successfully"`
+	TxnID   string                `json:"txn_id" example:"txn_123456"`
+	Data    CampaignCloneResponse `json:"data,omitempty"`
+}
+
+// CampaignGoalResult represents a response with a campaign goal
+type CampaignGoalResult struct {
+	Success bool                 `json:"success" example:"true"`
+	Code    int                  `json:"code" example:"0"`
+	Message string               `json:"message" example:"Campaign goal saved successfully"`
+	TxnID   string               `json:"txn_id" example:"txn_123456"`
+	Data    domains.CampaignGoal `json:"data,omitempty"`
+}
+
+// CampaignProgressResult represents a response with campaign goal progress
+type CampaignProgressResult struct {
+	Success bool                     `json:"success" example:"true"`
+	Code    int                      `json:"code" example:"0"`
+	Message string                   `json:"message" example:"Campaign progress fetched successfully"`
+	TxnID   string                   `json:"txn_id" example:"txn_123456"`
+	Data    CampaignProgressResponse `json:"data,omitempty"`
+}
+
+// StorefrontCampaignResult represents a response with a campaign storefront
+type StorefrontCampaignResult struct {
+	Success bool               `json:"success" example:"true"`
+	Code    int                `json:"code" example:"0"`
+	Message string             `json:"message" example:"Storefront fetched successfully"`
+	TxnID   string             `json:"txn_id" example:"txn_123456"`
+	Data    StorefrontCampaign `json:"data,omitempty"`
+}
+
+// UserStorefrontResult represents a response with the storefronts of a user's active campaigns
+type UserStorefrontResult struct {
+	Success bool           `json:"success" example:"true"`
+	Code    int            `json:"code" example:"0"`
+	Message string         `json:"message" example:"Storefront fetched successfully"`
+	TxnID   string         `json:"txn_id" example:"txn_123456"`
+	Data    UserStorefront `json:"data,omitempty"`
+}
+
+// CampaignReportResult represents a response with a campaign performance report
+type CampaignReportResult struct {
+	Success bool                   `json:"success" example:"true"`
+	Code    int                    `json:"code" example:"0"`
+	Message string                 `json:"message" example:"Campaign report fetched successfully"`
+	TxnID   string                 `json:"txn_id" example:"txn_123456"`
+	Data    CampaignReportResponse `json:"data,omitempty"`
+}
+
+// PostbackSecretResult represents a response with a new postback secret
+type PostbackSecretResult struct {
+	Success bool                   `json:"success" example:"true"`
+	Code    int                    `json:"code" example:"0"`
+	Message string                 `json:"message" example:"Postback secret generated successfully"`
+	TxnID   string                 `json:"txn_id" example:"txn_123456"`
+	Data    PostbackSecretResponse `json:"data,omitempty"`
+}
+
+// ConversionResult represents a response with a recorded conversion
+type ConversionResult struct {
+	Success bool               `json:"success" example:"true"`
+	Code    int                `json:"code" example:"0"`
+	Message string             `json:"message" example:"Conversion recorded"`
+	TxnID   string             `json:"txn_id" example:"txn_123456"`
+	Data    domains.Conversion `json:"data,omitempty"`
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/market-place-affiliate/api/pkg/eventbus"
+)
+
+// EventPublisher delivers domain events, such as domains.CampaignStateChanged, to subscribers.
+type EventPublisher interface {
+	Publish(ctx context.Context, topic string, payload any)
+}
+
+// EventSubscriber registers handlers for the events published on a topic. The returned function
+// removes the handler.
+type EventSubscriber interface {
+	Subscribe(topic string, handler eventbus.Handler) func()
+}
@@ -0,0 +1,47 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+)
+
+var (
+	ErrUnsupportedMarketplace = errors.New("unsupported marketplace")
+	ErrUnsupportedRegion      = errors.New("unsupported marketplace region")
+	ErrInvalidProductUrl      = errors.New("invalid product url")
+	ErrProductNotAvailable    = errors.New("product is not available for affiliation")
+	ErrUrlHostNotAllowed      = errors.New("url host is not allowed")
+	ErrTooManyRedirects       = errors.New("too many redirects")
+	ErrUrlResolveFailed       = errors.New("failed to resolve url")
+)
+
+// MarketplaceProvider is implemented once per marketplace so services never need to know
+// which affiliate API they are talking to.
+type MarketplaceProvider interface {
+	Name() string
+	ValidateCredential(cred domains.MarketplaceCredential) error
+	// FetchProduct returns the products found at sourceUrl with their offers. Products and offers
+	// are not saved and have no user, source url or product id set.
+	FetchProduct(ctx context.Context, cred domains.MarketplaceCredential, sourceUrl string) ([]dto.MarketplaceProduct, error)
+	ListOffers(ctx context.Context, cred domains.MarketplaceCredential, sourceUrl string) ([]domains.Offer, error)
+	GenerateAffiliateLink(ctx context.Context, cred domains.MarketplaceCredential, sourceUrl string, subIds []string) (string, error)
+	// FetchConversions returns the order items bought through the credential's affiliate links
+	// between start and end. Conversions are not saved and have no user or attribution set.
+	FetchConversions(ctx context.Context, cred domains.MarketplaceCredential, start, end time.Time) ([]domains.Conversion, error)
+}
+
+type MarketplaceRegistry interface {
+	// Get returns ErrUnsupportedMarketplace when no provider is registered under name.
+	Get(name string) (MarketplaceProvider, error)
+	Names() []string
+}
+
+// UrlResolver expands share links such as s.shopee.co.th/xxxx into the product url they point to.
+// Urls that are not short links are returned unchanged once their host has been checked.
+type UrlResolver interface {
+	Resolve(ctx context.Context, rawUrl string) (string, error)
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -9,10 +10,18 @@ import (
 	"github.com/market-place-affiliate/api/internal/core/dto"
 )
 
+var (
+	ErrCampaignNotFound     = errors.New("campaign not found")
+	ErrCampaignGoalNotFound = errors.New("campaign goal not found")
+	// ErrInvalidCursor is returned by paginated listings for a cursor they did not create.
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
 type UserRepository interface {
 	CreateUser(ctx context.Context, user domains.User) (domains.User, error)
 	GetUserByID(ctx context.Context, userId int64) (domains.User, error)
 	GetUserByEmail(ctx context.Context, email string) (domains.User, error)
+	UpdateUser(ctx context.Context, user domains.User) (domains.User, error)
 }
 
 type ProductRepository interface {
@@ -20,13 +29,30 @@ type ProductRepository interface {
 	DeleteProduct(ctx context.Context, productId string) error
 	GetProductById(ctx context.Context, productId string) (domains.Product, error)
 	GetAllProducts(ctx context.Context, userId int64) ([]domains.Product, error)
+	GetProductsByQuery(ctx context.Context, userId int64, query dto.GetProductsQueryRequest) ([]domains.Product, error)
+	// DeleteProductById soft deletes the product together with its links. Offers, images and
+	// clicks are kept until the product is purged.
 	DeleteProductById(ctx context.Context, productId string) error
+	// GetProductByIdWithDeleted also finds soft deleted products.
+	GetProductByIdWithDeleted(ctx context.Context, productId string) (domains.Product, error)
+	// RestoreProduct undeletes the product and the links deleted with it, except links whose
+	// campaign is still deleted.
+	RestoreProduct(ctx context.Context, productId string) error
+	// PurgeDeletedProducts hard deletes products soft deleted before the cutoff.
+	PurgeDeletedProducts(ctx context.Context, before time.Time) (int64, error)
+	// GetUnavailableProducts returns the user's products that have no available offer left.
+	GetUnavailableProducts(ctx context.Context, userId int64) ([]domains.Product, error)
+	ReplaceProductImages(ctx context.Context, productId string, images []domains.ProductImage) error
 }
 
 type OfferRepository interface {
 	SaveOffer(ctx context.Context, offer domains.Offer) error
 	DeleteOffer(ctx context.Context, offerId string) error
+	// GetOffersByProductId returns the primary offer of a product: the oldest, the one imported
+	// with it.
 	GetOffersByProductId(ctx context.Context, productId string) (domains.Offer, error)
+	// ListOffersByProductId returns every offer of a product, oldest and so primary offer first.
+	ListOffersByProductId(ctx context.Context, productId string) ([]domains.Offer, error)
 	GetOfferById(ctx context.Context, offerId string) (domains.Offer, error)
 	DeleteOfferByProductId(ctx context.Context, productId string) error
 }
@@ -37,28 +63,139 @@ type LinkRepository interface {
 	GetLinksByProductId(ctx context.Context, productId string) ([]domains.Link, error)
 	GetLinkById(ctx context.Context, linkId string) (domains.Link, error)
 	GetLinkByShortCode(ctx context.Context, shortCode string) (domains.Link, error)
+	// GetLinkByShortCodeWithDeleted also finds soft deleted links.
+	GetLinkByShortCodeWithDeleted(ctx context.Context, shortCode string) (domains.Link, error)
 	GetLinksByCampaignId(ctx context.Context, campaignId string) ([]domains.Link, error)
 	DeleteLinkByProductId(ctx context.Context, productId string) error
 	DeleteLinkByCampaignId(ctx context.Context, campaignId string) error
+	// PurgeDeletedLinks hard deletes links soft deleted before the cutoff, with their clicks.
+	PurgeDeletedLinks(ctx context.Context, before time.Time) (int64, error)
 }
 
 type ClickRepository interface {
 	SaveClick(ctx context.Context, click domains.Click) error
-	CountClicksByDateRange(ctx context.Context, userId int64, startDate, endDate time.Time) ([]dto.MetrictItem, error)
-	CountTopProductClickByDateRange(ctx context.Context, userId int64, startDate, endDate time.Time) (uuid.UUID, int64, error)
-	DeleteClicksByLinkId(ctx context.Context, linkId string) error
+	GetClickById(ctx context.Context, clickId string) (domains.Click, error)
+	// StreamClicks calls fn with each click of userId in [startDate, endDate), oldest first,
+	// optionally only those of campaignId and linkId, while reading them from the database.
+	// It stops at the first error fn returns.
+	StreamClicks(ctx context.Context, userId int64, startDate, endDate time.Time, campaignId, linkId string, fn func(dto.ClickLogEntry) error) error
+	// CountClicksByDateRange counts rolled up clicks in [startDate, endDate) per granularity
+	// bucket in timezone, campaign and marketplace. Empty buckets are left out.
+	CountClicksByDateRange(ctx context.Context, userId int64, startDate, endDate time.Time, granularity, timezone string) ([]dto.MetrictItem, error)
+	// GetClickLeaderboard ranks the products, links, campaigns or marketplaces (see
+	// dto.LeaderboardProducts and friends) of userId by their rolled up clicks or unique_visitors
+	// in [startDate, endDate), and counts the same in [previousStart, previousEnd). Only entries
+	// with some of the sort metric in [startDate, endDate) are ranked. Unique visitors are
+	// counted per day in timezone, which should be the rollup timezone.
+	GetClickLeaderboard(ctx context.Context, userId int64, dimension string, previousStart, previousEnd, startDate, endDate time.Time, timezone, sort string, limit int) ([]dto.LeaderboardEntry, error)
+	// GetClickBreakdown ranks the values of a breakdown dimension (see dto.BreakdownSource and
+	// friends) among the raw clicks of userId like GetClickLeaderboard, optionally only the
+	// clicks of campaignId and linkId. Unique visitors are counted over each whole period.
+	GetClickBreakdown(ctx context.Context, userId int64, dimension string, previousStart, previousEnd, startDate, endDate time.Time, campaignId, linkId, sort string, limit int) ([]dto.LeaderboardEntry, error)
+	// GetCampaignClickStats counts the raw clicks on a campaign's links in [startDate, endDate).
+	GetCampaignClickStats(ctx context.Context, campaignId string, startDate, endDate time.Time) (dto.CampaignClickStats, error)
+
+	// The queries below read the click rollups, so they only see clicks that have been rolled
+	// up. Ranges start at the hour startDate falls in. Unique visitors are counted per day in
+	// the rollup timezone, which timezone should match.
+
+	// GetCampaignClickTotals counts a campaign's clicks and unique visitors in [startDate, endDate).
+	GetCampaignClickTotals(ctx context.Context, campaignId string, startDate, endDate time.Time, timezone string) (dto.ClickTotals, error)
+	// GetCampaignDailyClicks counts a campaign's clicks in [startDate, endDate) per day in timezone.
+	// Days without clicks are left out.
+	GetCampaignDailyClicks(ctx context.Context, campaignId string, startDate, endDate time.Time, timezone string) ([]dto.DailyClicks, error)
+	// GetCampaignLinkClicks counts clicks in [startDate, endDate) per link of the campaign, most
+	// clicked first. Deleted links are included when they have clicks.
+	GetCampaignLinkClicks(ctx context.Context, campaignId string, startDate, endDate time.Time, timezone string) ([]dto.CampaignLinkReport, error)
+	// GetCampaignProductClicks counts clicks in [startDate, endDate) per product linked from the
+	// campaign, most clicked first.
+	GetCampaignProductClicks(ctx context.Context, campaignId string, startDate, endDate time.Time, timezone string) ([]dto.CampaignProductReport, error)
+}
+
+// ClickRollupRepository maintains the hourly click rollups and daily visitor rollups that
+// dashboard and report queries read.
+type ClickRollupRepository interface {
+	// RollupClicks recounts the hourly rollups in [from, to) from raw clicks and records their
+	// visitors per day in timezone. from must be the start of an hour.
+	RollupClicks(ctx context.Context, from, to time.Time, timezone string) error
+	// GetLatestRollupBucket returns the start of the latest rolled up hour with clicks, or the
+	// zero time when nothing has been rolled up.
+	GetLatestRollupBucket(ctx context.Context) (time.Time, error)
+	// GetEarliestClickAt returns when the first click was recorded, or the zero time when there
+	// are no clicks.
+	GetEarliestClickAt(ctx context.Context) (time.Time, error)
+	// ResetRollups deletes every rollup so they can be rebuilt from scratch.
+	ResetRollups(ctx context.Context) error
+}
+
+// ConversionRepository stores the conversions imported from marketplace reports.
+type ConversionRepository interface {
+	// SaveConversions inserts new order items and updates the status, amounts and sub ids of those
+	// already imported. An attribution is kept when the order item is imported again without one.
+	SaveConversions(ctx context.Context, conversions []domains.Conversion) error
+	// SavePostbackConversion inserts a posted back order, or updates the status and amounts of
+	// the one already posted back, as networks post orders again when they change.
+	SavePostbackConversion(ctx context.Context, conversion domains.Conversion) error
+	// CountConversionsByDateRange counts the orders of userId placed in [startDate, endDate) per
+	// granularity bucket in timezone, campaign, marketplace and currency, with their amounts and
+	// commission. Cancelled order items are left out, as are empty buckets. Unattributed orders
+	// are counted under the nil campaign id.
+	CountConversionsByDateRange(ctx context.Context, userId int64, startDate, endDate time.Time, granularity, timezone string) ([]dto.MetrictItem, error)
 }
 
 type CampaignRepository interface {
 	SaveCampaign(ctx context.Context, campaign domains.Campaign) (domains.Campaign, error)
+	// DeleteCampaign soft deletes the campaign together with its links. Clicks are kept so
+	// historical analytics still count them.
 	DeleteCampaign(ctx context.Context, campaignId string) error
+	// GetCampaignByIdWithDeleted also finds soft deleted campaigns.
+	GetCampaignByIdWithDeleted(ctx context.Context, campaignId string) (domains.Campaign, error)
+	// RestoreCampaign undeletes the campaign and the links deleted with it, except links whose
+	// product is still deleted.
+	RestoreCampaign(ctx context.Context, campaignId string) error
+	// PurgeDeletedCampaigns hard deletes campaigns soft deleted before the cutoff, with their
+	// links, clicks and goal.
+	PurgeDeletedCampaigns(ctx context.Context, before time.Time) (int64, error)
+	// GetCampaignById returns ErrCampaignNotFound when there is no such campaign.
 	GetCampaignById(ctx context.Context, campaignId string) (domains.Campaign, error)
-	GetCampaignByQuery(ctx context.Context, userId int64, query dto.GetCampaignByQueryRequest) ([]domains.Campaign, error)
-	GetAvailableCampaign(ctx context.Context) ([]domains.Campaign, error)
+	// GetCampaignByQuery lists the campaigns of userId with their click counts, or of every user
+	// without them when userId is 0.
+	GetCampaignByQuery(ctx context.Context, userId int64, query dto.GetCampaignByQueryRequest) ([]domains.Campaign, dto.PageMeta, error)
+	GetCampaignsByStates(ctx context.Context, states []string) ([]domains.Campaign, error)
+	// UpdateCampaignState moves the campaign to state only if it is still in from, and reports
+	// whether it did.
+	UpdateCampaignState(ctx context.Context, campaignId string, from, to string) (bool, error)
+	SaveCampaignGoal(ctx context.Context, goal domains.CampaignGoal) (domains.CampaignGoal, error)
+	// GetCampaignGoal returns ErrCampaignGoalNotFound when the campaign has no goal.
+	GetCampaignGoal(ctx context.Context, campaignId string) (domains.CampaignGoal, error)
+	// GetNotifiableCampaignGoals returns goals with notify thresholds whose campaign is active and
+	// not deleted.
+	GetNotifiableCampaignGoals(ctx context.Context) ([]domains.CampaignGoal, error)
 }
 
 type MarketplaceRepository interface {
 	Save(ctx context.Context, marketplace domains.MarketplaceCredential) (domains.MarketplaceCredential, error)
 	GetByUserIdAndPlatform(ctx context.Context, userId int64, platform string) (domains.MarketplaceCredential, error)
 	DeleteByUserIdAndPlatform(ctx context.Context, userId int64, platform string) error
+	// ListCredentials returns the credentials of every user.
+	ListCredentials(ctx context.Context) ([]domains.MarketplaceCredential, error)
+}
+
+type TagRepository interface {
+	SaveTag(ctx context.Context, tag domains.Tag) (domains.Tag, error)
+	GetTagById(ctx context.Context, tagId string) (domains.Tag, error)
+	GetTagsByUserId(ctx context.Context, userId int64) ([]domains.Tag, error)
+	DeleteTag(ctx context.Context, tagId string) error
+	AddProductTag(ctx context.Context, productId, tagId string) error
+	RemoveProductTag(ctx context.Context, productId, tagId string) error
+}
+
+type CollectionRepository interface {
+	SaveCollection(ctx context.Context, collection domains.Collection) (domains.Collection, error)
+	GetCollectionById(ctx context.Context, collectionId string) (domains.Collection, error)
+	GetCollectionsByUserId(ctx context.Context, userId int64) ([]domains.Collection, error)
+	DeleteCollection(ctx context.Context, collectionId string) error
+	ReplaceCollectionItems(ctx context.Context, collectionId string, productIds []uuid.UUID) error
+	AddCollectionItem(ctx context.Context, collectionId, productId string) error
+	RemoveCollectionItem(ctx context.Context, collectionId, productId string) error
 }
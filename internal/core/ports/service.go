@@ -2,10 +2,10 @@ package ports
 
 import (
 	"context"
-	"time"
 
 	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/pkg/export"
 )
 
 type UserService interface {
@@ -16,32 +16,84 @@ type UserService interface {
 	SaveMarketplaceCredential(ctx context.Context, userId int64, cred dto.MarketplaceCredentialRequest) (dto.Response[string], error)
 	CheckMarketplaceCredential(ctx context.Context, userId int64, platform string) (dto.Response[bool], error)
 	DeleteMarketplaceCredential(ctx context.Context, userId int64, platform string) (dto.Response[string], error)
+	UpdateLinkPolicy(ctx context.Context, userId int64, policy dto.LinkPolicyRequest) (dto.Response[domains.User], error)
+	RotatePostbackSecret(ctx context.Context, userId int64) (dto.Response[dto.PostbackSecretResponse], error)
 }
 
 type ProductService interface {
 	CreateProduct(ctx context.Context, userId int64, product dto.CreateProductRequest) (dto.Response[[]domains.Product], error)
 	GetOffer(ctx context.Context, userId int64, productId string) (dto.Response[domains.Offer], error)
-	GetProductsByUserId(ctx context.Context, userId int64) (dto.Response[[]domains.Product], error)
+	GetProductsByUserId(ctx context.Context, userId int64, query dto.GetProductsQueryRequest) (dto.Response[[]domains.Product], error)
 	DeleteProductById(ctx context.Context, userId int64, productId string) (dto.Response[any], error)
+	RestoreProduct(ctx context.Context, userId int64, productId string) (dto.Response[domains.Product], error)
 	GetProductById(ctx context.Context, productId string) (dto.Response[domains.Product], error)
+	GetUnavailableProducts(ctx context.Context, userId int64) (dto.Response[[]dto.UnavailableProduct], error)
+	RefreshProduct(ctx context.Context, userId int64, productId string) (dto.Response[dto.ProductRefreshResponse], error)
 }
 
 type CampaignService interface {
 	CreateCampaign(ctx context.Context, userId int64, campaign dto.CreateCampaignRequest) (dto.Response[domains.Campaign], error)
 	GetCampaignByQuery(ctx context.Context, userId int64, query dto.GetCampaignByQueryRequest) (dto.Response[[]domains.Campaign], error)
 	DeleteCampaignById(ctx context.Context, userId int64, campaignId string) (dto.Response[any], error)
+	RestoreCampaign(ctx context.Context, userId int64, campaignId string) (dto.Response[domains.Campaign], error)
 	GetPublicCampaigns(ctx context.Context, query dto.GetCampaignByQueryRequest) (dto.Response[[]domains.Campaign], error)
+	UpdateCampaign(ctx context.Context, userId int64, campaignId string, campaign dto.UpdateCampaignRequest) (dto.Response[dto.CampaignUpdateResponse], error)
+	CloneCampaign(ctx context.Context, userId int64, campaignId string, campaign dto.CloneCampaignRequest) (dto.Response[dto.CampaignCloneResponse], error)
+	ChangeCampaignState(ctx context.Context, userId int64, campaignId string, state dto.CampaignStateRequest) (dto.Response[domains.Campaign], error)
+	SetCampaignGoal(ctx context.Context, userId int64, campaignId string, goal dto.CampaignGoalRequest) (dto.Response[domains.CampaignGoal], error)
+	GetCampaignProgress(ctx context.Context, userId int64, campaignId string) (dto.Response[dto.CampaignProgressResponse], error)
+	GetCampaignReport(ctx context.Context, userId int64, campaignId string, query dto.CampaignReportRequest) (dto.Response[dto.CampaignReportResponse], error)
+	ExportCampaignReport(ctx context.Context, userId int64, campaignId string, query dto.CampaignReportRequest, w export.Writer) (dto.Response[any], error)
 }
 
 type LinkService interface {
 	CreateLink(ctx context.Context, userId int64, link dto.CreateLinkRequest) (dto.Response[domains.Link], error)
 	GetLinkByCampaign(ctx context.Context, campaignId string) (dto.Response[[]domains.Link], error)
-	ClickByShortCode(ctx context.Context, shortCode string) (dto.Response[domains.Link], error)
+	ClickByShortCode(ctx context.Context, shortCode string, click dto.ClickRequest) (dto.Response[domains.Link], error)
 	DeleteLinkById(ctx context.Context, userId int64, linkId string) (dto.Response[any], error)
 	GetLinkById(ctx context.Context, linkId string) (dto.Response[domains.Link], error)
 	GetLinkByShortCode(ctx context.Context, shortCode string) (dto.Response[domains.Link], error)
+	RegenerateLink(ctx context.Context, userId int64, linkId string) (dto.Response[domains.Link], error)
+	ExportCampaignLinks(ctx context.Context, userId int64, campaignId string, w export.Writer) (dto.Response[any], error)
 }
 
 type DashboardService interface {
-	GetDashboardMetrics(ctx context.Context, userId int64, startDate, endDate time.Time) (dto.Response[dto.DashboardMetricsResponse], error)
+	GetDashboardMetrics(ctx context.Context, userId int64, query dto.DashboardMetricsRequest) (dto.Response[dto.DashboardMetricsResponse], error)
+	GetDashboardLeaderboards(ctx context.Context, userId int64, query dto.DashboardLeaderboardRequest) (dto.Response[dto.DashboardLeaderboardsResponse], error)
+	GetDashboardBreakdown(ctx context.Context, userId int64, query dto.DashboardBreakdownRequest) (dto.Response[dto.DashboardBreakdownResponse], error)
+	ExportDashboardMetrics(ctx context.Context, userId int64, query dto.DashboardMetricsRequest, w export.Writer) (dto.Response[any], error)
+	ExportClickLog(ctx context.Context, userId int64, query dto.ClickLogRequest, w export.Writer) (dto.Response[any], error)
+}
+
+type TagService interface {
+	CreateTag(ctx context.Context, userId int64, tag dto.TagRequest) (dto.Response[domains.Tag], error)
+	GetTags(ctx context.Context, userId int64) (dto.Response[[]domains.Tag], error)
+	UpdateTag(ctx context.Context, userId int64, tagId string, tag dto.TagRequest) (dto.Response[domains.Tag], error)
+	DeleteTag(ctx context.Context, userId int64, tagId string) (dto.Response[any], error)
+	AddProductTag(ctx context.Context, userId int64, productId, tagId string) (dto.Response[any], error)
+	RemoveProductTag(ctx context.Context, userId int64, productId, tagId string) (dto.Response[any], error)
+}
+
+type CollectionService interface {
+	CreateCollection(ctx context.Context, userId int64, collection dto.CollectionRequest) (dto.Response[domains.Collection], error)
+	GetCollections(ctx context.Context, userId int64) (dto.Response[[]domains.Collection], error)
+	GetCollectionById(ctx context.Context, userId int64, collectionId string) (dto.Response[domains.Collection], error)
+	UpdateCollection(ctx context.Context, userId int64, collectionId string, collection dto.CollectionRequest) (dto.Response[domains.Collection], error)
+	DeleteCollection(ctx context.Context, userId int64, collectionId string) (dto.Response[any], error)
+	AddCollectionProduct(ctx context.Context, userId int64, collectionId, productId string) (dto.Response[any], error)
+	RemoveCollectionProduct(ctx context.Context, userId int64, collectionId, productId string) (dto.Response[any], error)
+	CreateCollectionLinks(ctx context.Context, userId int64, collectionId string, req dto.CreateCollectionLinksRequest) (dto.Response[dto.BulkLinkResponse], error)
+}
+
+type StorefrontService interface {
+	GetCampaignStorefront(ctx context.Context, campaignId string) (dto.Response[dto.StorefrontCampaign], error)
+	GetUserStorefront(ctx context.Context, userId int64) (dto.Response[dto.UserStorefront], error)
+}
+
+type PostbackService interface {
+	RecordPostback(ctx context.Context, postback dto.PostbackRequest) (dto.Response[domains.Conversion], error)
+}
+
+type ClickStreamService interface {
+	Subscribe(ctx context.Context, userId int64, query dto.ClickStreamRequest) (dto.Response[<-chan domains.ClickRecorded], error)
 }
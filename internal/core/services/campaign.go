@@ -2,31 +2,71 @@ package services
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"regexp"
+	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/market-place-affiliate/api/internal/core/dto"
 	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/market-place-affiliate/api/pkg/customtime"
 )
 
+// utmCampaignPattern keeps UtmCampaign safe to pass to the marketplaces as a sub id.
+var utmCampaignPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// validateCampaign checks the dates and, when checkUtm is set, the UtmCampaign of a campaign
+// being updated or cloned. Campaigns saved before the UTM value was restricted keep it until it
+// is changed. Creation keeps its own, looser request validation.
+func validateCampaign[T any](campaign domains.Campaign, checkUtm bool) (dto.Response[T], error) {
+	if !campaign.EndAt.After(campaign.StartAt) {
+		return dto.Response[T]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     3009,
+			Message:  "Campaign end_at must be after start_at",
+		}, errors.New("campaign end_at must be after start_at")
+	}
+	if checkUtm && !utmCampaignPattern.MatchString(campaign.UtmCampaign) {
+		return dto.Response[T]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     3010,
+			Message:  "utm_campaign may only contain letters, digits, '-' and '_'",
+		}, errors.New("invalid utm_campaign")
+	}
+	return dto.Response[T]{Success: true}, nil
+}
+
 type campaignService struct {
 	campaignRepo ports.CampaignRepository
 	linkRepo     ports.LinkRepository
 	clickRepo    ports.ClickRepository
+	linkService  ports.LinkService
+	events       ports.EventPublisher
+	// location is the timezone campaign dates are interpreted in.
+	location *time.Location
 }
 
-func NewCampaignService(campaignRepo ports.CampaignRepository, linkRepo ports.LinkRepository, clickRepo ports.ClickRepository) ports.CampaignService {
-	return &campaignService{campaignRepo: campaignRepo, linkRepo: linkRepo, clickRepo: clickRepo}
+func NewCampaignService(campaignRepo ports.CampaignRepository, linkRepo ports.LinkRepository, clickRepo ports.ClickRepository, linkService ports.LinkService, events ports.EventPublisher, location *time.Location) ports.CampaignService {
+	return &campaignService{campaignRepo: campaignRepo, linkRepo: linkRepo, clickRepo: clickRepo, linkService: linkService, events: events, location: location}
 }
 
 func (c *campaignService) CreateCampaign(ctx context.Context, userId int64, campaign dto.CreateCampaignRequest) (dto.Response[domains.Campaign], error) {
-	newCampaign, err := c.campaignRepo.SaveCampaign(ctx, domains.Campaign{
+	newCampaign := domains.Campaign{
 		Name:        campaign.Name,
 		UtmCampaign: campaign.UtmCampaign,
 		StartAt:     campaign.StartAt,
 		EndAt:       campaign.EndAt,
 		UserId:      userId,
-	})
+		State:       domains.CampaignDraft,
+	}
+	if !campaign.Draft {
+		newCampaign.State = newCampaign.ScheduledState(customtime.Now(), c.location)
+	}
+	newCampaign, err := c.campaignRepo.SaveCampaign(ctx, newCampaign)
 	if err != nil {
 		return dto.Response[domains.Campaign]{
 			HttpCode: http.StatusInternalServerError,
@@ -34,6 +74,7 @@ func (c *campaignService) CreateCampaign(ctx context.Context, userId int64, camp
 			Code:     3001,
 		}, err
 	}
+	c.publishStateChange(ctx, newCampaign, "")
 	return dto.Response[domains.Campaign]{
 		HttpCode: http.StatusOK,
 		Success:  true,
@@ -42,7 +83,20 @@ func (c *campaignService) CreateCampaign(ctx context.Context, userId int64, camp
 	}, nil
 }
 func (c *campaignService) GetCampaignByQuery(ctx context.Context, userId int64, query dto.GetCampaignByQueryRequest) (dto.Response[[]domains.Campaign], error) {
-	campaigns, err := c.campaignRepo.GetCampaignByQuery(ctx, userId, query)
+	return c.listCampaigns(ctx, userId, query)
+}
+
+// listCampaigns lists one page of campaigns with its page metadata.
+func (c *campaignService) listCampaigns(ctx context.Context, userId int64, query dto.GetCampaignByQueryRequest) (dto.Response[[]domains.Campaign], error) {
+	campaigns, meta, err := c.campaignRepo.GetCampaignByQuery(ctx, userId, query)
+	if errors.Is(err, ports.ErrInvalidCursor) {
+		return dto.Response[[]domains.Campaign]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     3019,
+			Message:  "Invalid cursor, it must come from a listing with the same sort and order",
+		}, err
+	}
 	if err != nil {
 		return dto.Response[[]domains.Campaign]{
 			HttpCode: http.StatusInternalServerError,
@@ -55,13 +109,13 @@ func (c *campaignService) GetCampaignByQuery(ctx context.Context, userId int64,
 		Success:  true,
 		Code:     0,
 		Data:     campaigns,
+		Meta:     &meta,
 	}, nil
 }
-func (c *campaignService) DeleteCampaignById(ctx context.Context, userId int64, campaignId string) (dto.Response[any], error) {
-
+func (c *campaignService) UpdateCampaign(ctx context.Context, userId int64, campaignId string, req dto.UpdateCampaignRequest) (dto.Response[dto.CampaignUpdateResponse], error) {
 	campaign, err := c.campaignRepo.GetCampaignById(ctx, campaignId)
 	if err != nil {
-		return dto.Response[any]{
+		return dto.Response[dto.CampaignUpdateResponse]{
 			HttpCode: http.StatusInternalServerError,
 			Success:  false,
 			Code:     3004,
@@ -69,43 +123,285 @@ func (c *campaignService) DeleteCampaignById(ctx context.Context, userId int64,
 		}, err
 	}
 	if campaign.UserId != userId {
-		return dto.Response[any]{
+		return dto.Response[dto.CampaignUpdateResponse]{
 			HttpCode: http.StatusForbidden,
 			Success:  false,
 			Code:     3005,
-			Message:  "You do not have permission to delete this campaign",
+			Message:  "You do not have permission to update this campaign",
+		}, nil
+	}
+
+	utmChanged := req.UtmCampaign != nil && *req.UtmCampaign != campaign.UtmCampaign
+	if req.Name != nil {
+		campaign.Name = *req.Name
+	}
+	if req.UtmCampaign != nil {
+		campaign.UtmCampaign = *req.UtmCampaign
+	}
+	if req.StartAt != nil {
+		campaign.StartAt = *req.StartAt
+	}
+	if req.EndAt != nil {
+		campaign.EndAt = *req.EndAt
+	}
+	previousState := campaign.State
+	if campaign.State == domains.CampaignScheduled || campaign.State == domains.CampaignActive {
+		campaign.State = campaign.ScheduledState(customtime.Now(), c.location)
+	}
+	if res, err := validateCampaign[dto.CampaignUpdateResponse](campaign, utmChanged); err != nil {
+		return res, err
+	}
+
+	updated, err := c.campaignRepo.SaveCampaign(ctx, campaign)
+	if err != nil {
+		return dto.Response[dto.CampaignUpdateResponse]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     3011,
+			Message:  "Failed to update campaign",
+		}, err
+	}
+	if updated.State != previousState {
+		c.publishStateChange(ctx, updated, previousState)
+	}
+	result := dto.CampaignUpdateResponse{
+		Campaign: updated,
+		Links:    []domains.Link{},
+		Failures: []dto.LinkFailure{},
+	}
+	if !utmChanged || !req.RegenerateLinks {
+		return dto.Response[dto.CampaignUpdateResponse]{
+			HttpCode: http.StatusOK,
+			Success:  true,
+			Code:     0,
+			Message:  "Campaign updated successfully",
+			Data:     result,
 		}, nil
 	}
 
 	links, err := c.linkRepo.GetLinksByCampaignId(ctx, campaignId)
 	if err != nil {
-		return dto.Response[any]{
+		return dto.Response[dto.CampaignUpdateResponse]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     3006,
+			Message:  "Campaign updated but its links could not be fetched",
+			Data:     result,
+		}, err
+	}
+	for _, link := range links {
+		linkRes, err := c.linkService.RegenerateLink(ctx, userId, link.Id.String())
+		if err != nil || !linkRes.Success {
+			result.Failures = append(result.Failures, dto.LinkFailure{
+				ProductId: link.ProductId,
+				Code:      linkRes.Code,
+				Message:   linkRes.Message,
+			})
+			continue
+		}
+		result.Links = append(result.Links, linkRes.Data)
+	}
+	message := "Campaign updated and links regenerated successfully"
+	if len(result.Failures) > 0 {
+		message = "Campaign updated but some links could not be regenerated"
+	}
+	return dto.Response[dto.CampaignUpdateResponse]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  message,
+		Data:     result,
+	}, nil
+}
+
+// CloneCampaign creates a campaign with the source's products and new dates and UTM value. Each
+// product gets a fresh affiliate link from its marketplace; products whose link fails are
+// reported without failing the clone.
+func (c *campaignService) CloneCampaign(ctx context.Context, userId int64, campaignId string, req dto.CloneCampaignRequest) (dto.Response[dto.CampaignCloneResponse], error) {
+	source, res, err := c.getOwnedCampaign(ctx, userId, campaignId, false)
+	if err != nil || !res.Success {
+		return dto.Response[dto.CampaignCloneResponse]{
+			HttpCode: res.HttpCode,
+			Success:  false,
+			Code:     res.Code,
+			Message:  res.Message,
+		}, err
+	}
+	if res, err := validateCampaign[dto.CampaignCloneResponse](domains.Campaign{UtmCampaign: req.UtmCampaign, StartAt: req.StartAt, EndAt: req.EndAt}, true); err != nil {
+		return res, err
+	}
+	links, err := c.linkRepo.GetLinksByCampaignId(ctx, campaignId)
+	if err != nil {
+		return dto.Response[dto.CampaignCloneResponse]{
 			HttpCode: http.StatusInternalServerError,
 			Success:  false,
 			Code:     3006,
-			Message:  "Failed to fetch links for the campaign",
+			Message:  "Failed to fetch campaign links",
+		}, err
+	}
+
+	clone := domains.Campaign{
+		Name:        req.Name,
+		UtmCampaign: req.UtmCampaign,
+		StartAt:     req.StartAt,
+		EndAt:       req.EndAt,
+		UserId:      userId,
+		State:       domains.CampaignDraft,
+	}
+	if clone.Name == "" {
+		clone.Name = source.Name
+	}
+	if !req.Draft {
+		clone.State = clone.ScheduledState(customtime.Now(), c.location)
+	}
+	clone, err = c.campaignRepo.SaveCampaign(ctx, clone)
+	if err != nil {
+		return dto.Response[dto.CampaignCloneResponse]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     3001,
+			Message:  "Failed to create campaign",
 		}, err
 	}
+	c.publishStateChange(ctx, clone, "")
 
+	result := dto.CampaignCloneResponse{
+		Campaign: clone,
+		Links:    []domains.Link{},
+		Failures: []dto.LinkFailure{},
+	}
+	seen := map[uuid.UUID]bool{}
 	for _, link := range links {
-		err = c.clickRepo.DeleteClicksByLinkId(ctx, link.Id.String())
-		if err != nil {
-			return dto.Response[any]{
-				HttpCode: http.StatusInternalServerError,
-				Success:  false,
-				Code:     3007,
-				Message:  "Failed to delete clicks for link " + link.Id.String(),
-			}, err
+		if seen[link.ProductId] {
+			continue
 		}
-		err = c.linkRepo.DeleteLink(ctx, link.Id.String())
-		if err != nil {
-			return dto.Response[any]{
-				HttpCode: http.StatusInternalServerError,
-				Success:  false,
-				Code:     3008,
-				Message:  "Failed to delete link " + link.Id.String(),
-			}, err
+		seen[link.ProductId] = true
+		linkRes, err := c.linkService.CreateLink(ctx, userId, dto.CreateLinkRequest{
+			ProductId:  link.ProductId,
+			CampaignId: clone.Id,
+		})
+		if err != nil || !linkRes.Success {
+			result.Failures = append(result.Failures, dto.LinkFailure{
+				ProductId: link.ProductId,
+				Code:      linkRes.Code,
+				Message:   linkRes.Message,
+			})
+			continue
+		}
+		result.Links = append(result.Links, linkRes.Data)
+	}
+	message := "Campaign cloned successfully"
+	if len(result.Failures) > 0 {
+		message = "Campaign cloned but some links could not be created"
+	}
+	return dto.Response[dto.CampaignCloneResponse]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  message,
+		Data:     result,
+	}, nil
+}
+
+// ChangeCampaignState moves a campaign to another state when the transition is allowed.
+func (c *campaignService) ChangeCampaignState(ctx context.Context, userId int64, campaignId string, req dto.CampaignStateRequest) (dto.Response[domains.Campaign], error) {
+	campaign, err := c.campaignRepo.GetCampaignById(ctx, campaignId)
+	if err != nil {
+		return dto.Response[domains.Campaign]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     3004,
+			Message:  "Failed to fetch campaign",
+		}, err
+	}
+	if campaign.UserId != userId {
+		return dto.Response[domains.Campaign]{
+			HttpCode: http.StatusForbidden,
+			Success:  false,
+			Code:     3005,
+			Message:  "You do not have permission to update this campaign",
+		}, nil
+	}
+
+	requested := req.State
+	if requested == domains.CampaignActive {
+		requested = domains.CampaignScheduled
+	}
+	if !campaign.CanTransition(requested) {
+		return dto.Response[domains.Campaign]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     3012,
+			Message:  "Campaign cannot move from " + campaign.State + " to " + req.State,
+		}, errors.New("invalid campaign state transition")
+	}
+	next := requested
+	if requested == domains.CampaignScheduled {
+		next = campaign.ScheduledState(customtime.Now(), c.location)
+	}
+	if next == campaign.State {
+		return dto.Response[domains.Campaign]{
+			HttpCode: http.StatusOK,
+			Success:  true,
+			Code:     0,
+			Data:     campaign,
+		}, nil
+	}
+
+	ok, err := c.campaignRepo.UpdateCampaignState(ctx, campaignId, campaign.State, next)
+	if err != nil || !ok {
+		if err == nil {
+			err = errors.New("campaign state changed concurrently")
 		}
+		return dto.Response[domains.Campaign]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     3013,
+			Message:  "Failed to update campaign state",
+		}, err
+	}
+	previousState := campaign.State
+	campaign.State = next
+	c.publishStateChange(ctx, campaign, previousState)
+	return dto.Response[domains.Campaign]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Campaign is now " + next,
+		Data:     campaign,
+	}, nil
+}
+
+func (c *campaignService) publishStateChange(ctx context.Context, campaign domains.Campaign, from string) {
+	c.events.Publish(ctx, domains.TopicCampaignStateChanged, domains.CampaignStateChanged{
+		CampaignId: campaign.Id,
+		UserId:     campaign.UserId,
+		From:       from,
+		To:         campaign.State,
+		At:         customtime.Now(),
+	})
+}
+
+// DeleteCampaignById soft deletes the campaign and its links. It can be restored until the
+// retention period has passed; its clicks stay in analytics either way.
+func (c *campaignService) DeleteCampaignById(ctx context.Context, userId int64, campaignId string) (dto.Response[any], error) {
+
+	campaign, err := c.campaignRepo.GetCampaignById(ctx, campaignId)
+	if err != nil {
+		return dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     3004,
+			Message:  "Failed to fetch campaign",
+		}, err
+	}
+	if campaign.UserId != userId {
+		return dto.Response[any]{
+			HttpCode: http.StatusForbidden,
+			Success:  false,
+			Code:     3005,
+			Message:  "You do not have permission to delete this campaign",
+		}, nil
 	}
 
 	err = c.campaignRepo.DeleteCampaign(ctx, campaignId)
@@ -122,19 +418,71 @@ func (c *campaignService) DeleteCampaignById(ctx context.Context, userId int64,
 		Code:     0,
 	}, nil
 }
-func (c *campaignService) GetPublicCampaigns(ctx context.Context, query dto.GetCampaignByQueryRequest) (dto.Response[[]domains.Campaign], error) {
-	campaigns, err := c.campaignRepo.GetAvailableCampaign(ctx)
+
+func (c *campaignService) RestoreCampaign(ctx context.Context, userId int64, campaignId string) (dto.Response[domains.Campaign], error) {
+	campaign, err := c.campaignRepo.GetCampaignByIdWithDeleted(ctx, campaignId)
+	if errors.Is(err, ports.ErrCampaignNotFound) {
+		return dto.Response[domains.Campaign]{
+			HttpCode: http.StatusNotFound,
+			Success:  false,
+			Code:     3026,
+			Message:  "Campaign not found",
+		}, err
+	}
 	if err != nil {
-		return dto.Response[[]domains.Campaign]{
+		return dto.Response[domains.Campaign]{
 			HttpCode: http.StatusInternalServerError,
 			Success:  false,
-			Code:     3002,
+			Code:     3004,
+			Message:  "Failed to fetch campaign",
 		}, err
 	}
-	return dto.Response[[]domains.Campaign]{
+	if campaign.UserId != userId {
+		return dto.Response[domains.Campaign]{
+			HttpCode: http.StatusForbidden,
+			Success:  false,
+			Code:     3005,
+			Message:  "You do not have permission to restore this campaign",
+		}, nil
+	}
+	if campaign.DeletedAt == nil {
+		return dto.Response[domains.Campaign]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     3020,
+			Message:  "Campaign is not deleted",
+		}, errors.New("campaign is not deleted")
+	}
+	err = c.campaignRepo.RestoreCampaign(ctx, campaignId)
+	if err != nil {
+		return dto.Response[domains.Campaign]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     3021,
+			Message:  "Failed to restore campaign",
+		}, err
+	}
+	campaign.DeletedAt = nil
+	return dto.Response[domains.Campaign]{
 		HttpCode: http.StatusOK,
 		Success:  true,
 		Code:     0,
-		Data:     campaigns,
+		Message:  "Campaign restored successfully",
+		Data:     campaign,
 	}, nil
 }
+
+// GetPublicCampaigns lists the active campaigns of every user, without their click counts.
+func (c *campaignService) GetPublicCampaigns(ctx context.Context, query dto.GetCampaignByQueryRequest) (dto.Response[[]domains.Campaign], error) {
+	if query.Sort == "clicks" {
+		return dto.Response[[]domains.Campaign]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     3025,
+			Message:  "Public campaigns cannot be sorted by clicks",
+		}, errors.New("public campaigns sorted by clicks")
+	}
+	query.State = domains.CampaignActive
+	query.Deleted = false
+	return c.listCampaigns(ctx, 0, query)
+}
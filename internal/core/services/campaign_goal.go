@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/market-place-affiliate/api/pkg/customtime"
+)
+
+func (c *campaignService) SetCampaignGoal(ctx context.Context, userId int64, campaignId string, req dto.CampaignGoalRequest) (dto.Response[domains.CampaignGoal], error) {
+	campaign, res, err := c.getOwnedCampaign(ctx, userId, campaignId, false)
+	if err != nil || !res.Success {
+		return dto.Response[domains.CampaignGoal]{
+			HttpCode: res.HttpCode,
+			Success:  false,
+			Code:     res.Code,
+			Message:  res.Message,
+		}, err
+	}
+	if req.TargetClicks == 0 && req.TargetUniqueVisitors == 0 && req.TargetCommission == 0 {
+		return dto.Response[domains.CampaignGoal]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     3014,
+			Message:  "Set at least one of target_clicks, target_unique_visitors or target_commission",
+		}, errors.New("campaign goal has no target")
+	}
+	deadline := campaign.EndAt
+	if req.Deadline != nil {
+		deadline = *req.Deadline
+	}
+	if !deadline.After(campaign.StartAt) {
+		return dto.Response[domains.CampaignGoal]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     3015,
+			Message:  "Goal deadline must be after the campaign start_at",
+		}, errors.New("campaign goal deadline before start")
+	}
+
+	goal, err := c.campaignRepo.GetCampaignGoal(ctx, campaignId)
+	if err != nil && !errors.Is(err, ports.ErrCampaignGoalNotFound) {
+		return dto.Response[domains.CampaignGoal]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     3016,
+			Message:  "Failed to fetch campaign goal",
+		}, err
+	}
+	goal.CampaignId = campaign.Id
+	goal.TargetClicks = req.TargetClicks
+	goal.TargetUniqueVisitors = req.TargetUniqueVisitors
+	goal.TargetCommission = req.TargetCommission
+	goal.Deadline = deadline
+	goal.ConversionRate = req.ConversionRate
+	if goal.ConversionRate == 0 {
+		goal.ConversionRate = domains.DefaultGoalConversionRate
+	}
+	goal.NotifyThresholds = slices.Sorted(slices.Values(req.NotifyThresholds))
+	// New targets make earlier notifications meaningless.
+	goal.NotifiedThresholds = map[string]int{}
+
+	saved, err := c.campaignRepo.SaveCampaignGoal(ctx, goal)
+	if err != nil {
+		return dto.Response[domains.CampaignGoal]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     3016,
+			Message:  "Failed to save campaign goal",
+		}, err
+	}
+	return dto.Response[domains.CampaignGoal]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Campaign goal saved successfully",
+		Data:     saved,
+	}, nil
+}
+
+func (c *campaignService) GetCampaignProgress(ctx context.Context, userId int64, campaignId string) (dto.Response[dto.CampaignProgressResponse], error) {
+	campaign, res, err := c.getOwnedCampaign(ctx, userId, campaignId, true)
+	if err != nil || !res.Success {
+		return dto.Response[dto.CampaignProgressResponse]{
+			HttpCode: res.HttpCode,
+			Success:  false,
+			Code:     res.Code,
+			Message:  res.Message,
+		}, err
+	}
+	goal, err := c.campaignRepo.GetCampaignGoal(ctx, campaignId)
+	if errors.Is(err, ports.ErrCampaignGoalNotFound) {
+		return dto.Response[dto.CampaignProgressResponse]{
+			HttpCode: http.StatusNotFound,
+			Success:  false,
+			Code:     3017,
+			Message:  "This campaign has no goal",
+		}, err
+	}
+	if err != nil {
+		return dto.Response[dto.CampaignProgressResponse]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     3016,
+			Message:  "Failed to fetch campaign goal",
+		}, err
+	}
+	progress, err := campaignProgress(ctx, c.clickRepo, campaign, goal, customtime.Now())
+	if err != nil {
+		return dto.Response[dto.CampaignProgressResponse]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     3018,
+			Message:  "Failed to count campaign clicks",
+		}, err
+	}
+	return dto.Response[dto.CampaignProgressResponse]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Data:     progress,
+	}, nil
+}
+
+// getOwnedCampaign fetches a campaign of userId. withDeleted also finds soft deleted campaigns,
+// for the read-only views of their clicks.
+func (c *campaignService) getOwnedCampaign(ctx context.Context, userId int64, campaignId string, withDeleted bool) (domains.Campaign, dto.Response[any], error) {
+	lookup := c.campaignRepo.GetCampaignById
+	if withDeleted {
+		lookup = c.campaignRepo.GetCampaignByIdWithDeleted
+	}
+	campaign, err := lookup(ctx, campaignId)
+	if errors.Is(err, ports.ErrCampaignNotFound) {
+		return domains.Campaign{}, dto.Response[any]{
+			HttpCode: http.StatusNotFound,
+			Success:  false,
+			Code:     3026,
+			Message:  "Campaign not found",
+		}, err
+	}
+	if err != nil {
+		return domains.Campaign{}, dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     3004,
+			Message:  "Failed to fetch campaign",
+		}, err
+	}
+	if campaign.UserId != userId {
+		return domains.Campaign{}, dto.Response[any]{
+			HttpCode: http.StatusForbidden,
+			Success:  false,
+			Code:     3005,
+			Message:  "You do not have access to this campaign",
+		}, nil
+	}
+	return campaign, dto.Response[any]{Success: true}, nil
+}
+
+// campaignProgress counts the campaign's clicks from StartAt until now, or the deadline if it
+// has passed, and compares them with the goal.
+func campaignProgress(ctx context.Context, clickRepo ports.ClickRepository, campaign domains.Campaign, goal domains.CampaignGoal, now time.Time) (dto.CampaignProgressResponse, error) {
+	until := now
+	if goal.Deadline.Before(until) {
+		until = goal.Deadline
+	}
+	stats := dto.CampaignClickStats{}
+	if until.After(campaign.StartAt) {
+		var err error
+		stats, err = clickRepo.GetCampaignClickStats(ctx, campaign.Id.String(), campaign.StartAt, until)
+		if err != nil {
+			return dto.CampaignProgressResponse{}, err
+		}
+	}
+
+	elapsedRatio := 1.0
+	if total := goal.Deadline.Sub(campaign.StartAt); total > 0 {
+		elapsedRatio = math.Min(math.Max(float64(now.Sub(campaign.StartAt))/float64(total), 0), 1)
+	}
+	progress := dto.CampaignProgressResponse{
+		Goal:         goal,
+		ElapsedRatio: elapsedRatio,
+		Metrics:      []dto.GoalMetricProgress{},
+	}
+	targets := []struct {
+		metric string
+		target float64
+		actual float64
+	}{
+		{domains.GoalClicks, float64(goal.TargetClicks), float64(stats.ClickCount)},
+		{domains.GoalUniqueVisitors, float64(goal.TargetUniqueVisitors), float64(stats.UniqueVisitors)},
+		{domains.GoalEstimatedCommission, goal.TargetCommission, math.Round(stats.Commission*goal.ConversionRate*100) / 100},
+	}
+	for _, t := range targets {
+		if t.target <= 0 {
+			continue
+		}
+		metric := dto.GoalMetricProgress{
+			Metric:     t.metric,
+			Target:     t.target,
+			Actual:     t.actual,
+			Attainment: t.actual / t.target,
+			Expected:   t.target * elapsedRatio,
+		}
+		if elapsedRatio > 0 {
+			metric.Projected = t.actual / elapsedRatio
+		}
+		switch {
+		case t.actual >= t.target:
+			metric.Status = domains.GoalAchieved
+		case !now.Before(goal.Deadline):
+			metric.Status = domains.GoalMissed
+		case elapsedRatio == 0:
+			metric.Status = domains.GoalNotStarted
+		case metric.Projected >= t.target:
+			metric.Status = domains.GoalOnTrack
+		default:
+			metric.Status = domains.GoalBehind
+		}
+		progress.Metrics = append(progress.Metrics, metric)
+	}
+	progress.Status = overallGoalStatus(progress.Metrics)
+	return progress, nil
+}
+
+// overallGoalStatus is the worst status of any metric.
+func overallGoalStatus(metrics []dto.GoalMetricProgress) string {
+	order := []string{domains.GoalAchieved, domains.GoalOnTrack, domains.GoalNotStarted, domains.GoalBehind, domains.GoalMissed}
+	worst := 0
+	for _, metric := range metrics {
+		worst = max(worst, slices.Index(order, metric.Status))
+	}
+	return order[worst]
+}
+
+// CampaignGoalMonitor publishes a CampaignGoalThresholdReached event the first time a goal
+// metric of an active campaign reaches one of the goal's notify thresholds.
+type CampaignGoalMonitor struct {
+	campaignRepo ports.CampaignRepository
+	clickRepo    ports.ClickRepository
+	events       ports.EventPublisher
+	interval     time.Duration
+}
+
+func NewCampaignGoalMonitor(campaignRepo ports.CampaignRepository, clickRepo ports.ClickRepository, events ports.EventPublisher, interval time.Duration) *CampaignGoalMonitor {
+	return &CampaignGoalMonitor{campaignRepo: campaignRepo, clickRepo: clickRepo, events: events, interval: interval}
+}
+
+// Run checks immediately and then every interval until ctx is done.
+func (m *CampaignGoalMonitor) Run(ctx context.Context) {
+	runEvery(ctx, m.interval, "campaign goal monitor", m.Tick)
+}
+
+// Tick notifies the thresholds the goals reached by now. A goal that cannot be checked is
+// logged and does not stop the others.
+func (m *CampaignGoalMonitor) Tick(ctx context.Context, now time.Time) error {
+	goals, err := m.campaignRepo.GetNotifiableCampaignGoals(ctx)
+	if err != nil {
+		return err
+	}
+	for _, goal := range goals {
+		if err := m.notify(ctx, goal, now); err != nil {
+			log.Printf("campaign goal monitor: goal of campaign %s: %v\n", goal.CampaignId, err)
+		}
+	}
+	return nil
+}
+
+func (m *CampaignGoalMonitor) notify(ctx context.Context, goal domains.CampaignGoal, now time.Time) error {
+	campaign, err := m.campaignRepo.GetCampaignById(ctx, goal.CampaignId.String())
+	if err != nil {
+		return err
+	}
+	progress, err := campaignProgress(ctx, m.clickRepo, campaign, goal, now)
+	if err != nil {
+		return err
+	}
+	reached := reachedThresholds(&goal, progress)
+	if len(reached) == 0 {
+		return nil
+	}
+	// Save first so a failed save repeats the notification instead of losing it.
+	_, err = m.campaignRepo.SaveCampaignGoal(ctx, goal)
+	if err != nil {
+		return err
+	}
+	for _, event := range reached {
+		event.CampaignId = campaign.Id
+		event.UserId = campaign.UserId
+		event.At = now
+		m.events.Publish(ctx, domains.TopicCampaignGoalThreshold, event)
+	}
+	return nil
+}
+
+// reachedThresholds returns the thresholds each metric crossed since the last notification and
+// records them in goal.NotifiedThresholds. Only the highest crossed threshold is reported per
+// metric.
+func reachedThresholds(goal *domains.CampaignGoal, progress dto.CampaignProgressResponse) []domains.CampaignGoalThresholdReached {
+	if goal.NotifiedThresholds == nil {
+		goal.NotifiedThresholds = map[string]int{}
+	}
+	reached := []domains.CampaignGoalThresholdReached{}
+	for _, metric := range progress.Metrics {
+		percent := metric.Attainment * 100
+		highest := 0
+		for _, threshold := range goal.NotifyThresholds {
+			if float64(threshold) <= percent && threshold > goal.NotifiedThresholds[metric.Metric] {
+				highest = max(highest, threshold)
+			}
+		}
+		if highest == 0 {
+			continue
+		}
+		goal.NotifiedThresholds[metric.Metric] = highest
+		reached = append(reached, domains.CampaignGoalThresholdReached{
+			Metric:     metric.Metric,
+			Threshold:  highest,
+			Attainment: metric.Attainment,
+		})
+	}
+	return reached
+}
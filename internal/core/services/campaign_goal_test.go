@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/market-place-affiliate/api/pkg/customtime"
+	"github.com/market-place-affiliate/api/pkg/eventbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetCampaignProgress_Pacing(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockClickRepo := new(mocks.MockClickRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), mockClickRepo, new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := start.AddDate(0, 0, 5)
+	customtime.Now = func() time.Time { return now }
+	defer func() { customtime.Now = time.Now }()
+
+	campaignId := uuid.Must(uuid.NewV4())
+	campaign := domains.Campaign{Id: campaignId, UserId: 1, StartAt: start, EndAt: start.AddDate(0, 0, 10)}
+	goal := domains.CampaignGoal{
+		CampaignId:           campaignId,
+		TargetClicks:         1000,
+		TargetUniqueVisitors: 400,
+		TargetCommission:     50,
+		Deadline:             campaign.EndAt,
+		ConversionRate:       0.02,
+	}
+	mockCampaignRepo.On("GetCampaignByIdWithDeleted", ctx, campaignId.String()).Return(campaign, nil)
+	mockCampaignRepo.On("GetCampaignGoal", ctx, campaignId.String()).Return(goal, nil)
+	mockClickRepo.On("GetCampaignClickStats", ctx, campaignId.String(), start, now).Return(dto.CampaignClickStats{
+		ClickCount:     600,
+		UniqueVisitors: 150,
+		Commission:     2600,
+	}, nil)
+
+	result, err := service.GetCampaignProgress(ctx, 1, campaignId.String())
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, 0.5, result.Data.ElapsedRatio)
+	assert.Equal(t, []dto.GoalMetricProgress{
+		{Metric: domains.GoalClicks, Target: 1000, Actual: 600, Attainment: 0.6, Expected: 500, Projected: 1200, Status: domains.GoalOnTrack},
+		{Metric: domains.GoalUniqueVisitors, Target: 400, Actual: 150, Attainment: 0.375, Expected: 200, Projected: 300, Status: domains.GoalBehind},
+		{Metric: domains.GoalEstimatedCommission, Target: 50, Actual: 52, Attainment: 1.04, Expected: 25, Projected: 104, Status: domains.GoalAchieved},
+	}, result.Data.Metrics)
+	assert.Equal(t, domains.GoalBehind, result.Data.Status)
+}
+
+func TestGetCampaignProgress_NoGoal(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository), new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignByIdWithDeleted", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 1}, nil)
+	mockCampaignRepo.On("GetCampaignGoal", ctx, campaignId.String()).Return(domains.CampaignGoal{}, ports.ErrCampaignGoalNotFound)
+
+	result, err := service.GetCampaignProgress(ctx, 1, campaignId.String())
+
+	assert.Error(t, err)
+	assert.Equal(t, 404, result.HttpCode)
+	assert.Equal(t, 3017, result.Code)
+}
+
+func TestSetCampaignGoal_RequiresTarget(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository), new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 1}, nil)
+
+	result, err := service.SetCampaignGoal(ctx, 1, campaignId.String(), dto.CampaignGoalRequest{NotifyThresholds: []int{50}})
+
+	assert.Error(t, err)
+	assert.Equal(t, 3014, result.Code)
+	mockCampaignRepo.AssertNotCalled(t, "SaveCampaignGoal", mock.Anything, mock.Anything)
+}
+
+func TestCampaignGoalMonitor_NotifiesEachThresholdOnce(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockClickRepo := new(mocks.MockClickRepository)
+	bus := eventbus.New()
+	events := []domains.CampaignGoalThresholdReached{}
+	bus.Subscribe(domains.TopicCampaignGoalThreshold, func(ctx context.Context, payload any) {
+		events = append(events, payload.(domains.CampaignGoalThresholdReached))
+	})
+	monitor := NewCampaignGoalMonitor(mockCampaignRepo, mockClickRepo, bus, time.Minute)
+
+	ctx := context.Background()
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := start.AddDate(0, 0, 5)
+	campaignId := uuid.Must(uuid.NewV4())
+	campaign := domains.Campaign{Id: campaignId, UserId: 7, StartAt: start, EndAt: start.AddDate(0, 0, 10)}
+	goal := domains.CampaignGoal{
+		CampaignId:         campaignId,
+		TargetClicks:       1000,
+		Deadline:           campaign.EndAt,
+		NotifyThresholds:   []int{25, 50, 100},
+		NotifiedThresholds: map[string]int{domains.GoalClicks: 25},
+	}
+	mockCampaignRepo.On("GetNotifiableCampaignGoals", ctx).Return([]domains.CampaignGoal{goal}, nil)
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(campaign, nil)
+	mockClickRepo.On("GetCampaignClickStats", ctx, campaignId.String(), start, now).Return(dto.CampaignClickStats{ClickCount: 600}, nil)
+	mockCampaignRepo.On("SaveCampaignGoal", ctx, mock.MatchedBy(func(g domains.CampaignGoal) bool {
+		return g.NotifiedThresholds[domains.GoalClicks] == 50
+	})).Return(goal, nil)
+
+	err := monitor.Tick(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domains.CampaignGoalThresholdReached{
+		{CampaignId: campaignId, UserId: 7, Metric: domains.GoalClicks, Threshold: 50, Attainment: 0.6, At: now},
+	}, events)
+	mockCampaignRepo.AssertExpectations(t)
+}
+
+func TestCampaignGoalMonitor_SkipsGoalsThatCannotBeChecked(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockClickRepo := new(mocks.MockClickRepository)
+	bus := eventbus.New()
+	events := []domains.CampaignGoalThresholdReached{}
+	bus.Subscribe(domains.TopicCampaignGoalThreshold, func(ctx context.Context, payload any) {
+		events = append(events, payload.(domains.CampaignGoalThresholdReached))
+	})
+	monitor := NewCampaignGoalMonitor(mockCampaignRepo, mockClickRepo, bus, time.Minute)
+
+	ctx := context.Background()
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := start.AddDate(0, 0, 5)
+	deletedId := uuid.Must(uuid.NewV4())
+	campaignId := uuid.Must(uuid.NewV4())
+	campaign := domains.Campaign{Id: campaignId, UserId: 7, StartAt: start, EndAt: start.AddDate(0, 0, 10)}
+	goal := domains.CampaignGoal{CampaignId: campaignId, TargetClicks: 1000, Deadline: campaign.EndAt, NotifyThresholds: []int{50}}
+	mockCampaignRepo.On("GetNotifiableCampaignGoals", ctx).Return([]domains.CampaignGoal{
+		{CampaignId: deletedId, TargetClicks: 1000, Deadline: campaign.EndAt, NotifyThresholds: []int{50}},
+		goal,
+	}, nil)
+	mockCampaignRepo.On("GetCampaignById", ctx, deletedId.String()).Return(domains.Campaign{}, ports.ErrCampaignNotFound)
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(campaign, nil)
+	mockClickRepo.On("GetCampaignClickStats", ctx, campaignId.String(), start, now).Return(dto.CampaignClickStats{ClickCount: 600}, nil)
+	mockCampaignRepo.On("SaveCampaignGoal", ctx, mock.MatchedBy(func(g domains.CampaignGoal) bool {
+		return g.CampaignId == campaignId
+	})).Return(goal, nil)
+
+	err := monitor.Tick(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domains.CampaignGoalThresholdReached{
+		{CampaignId: campaignId, UserId: 7, Metric: domains.GoalClicks, Threshold: 50, Attainment: 0.6, At: now},
+	}, events)
+	mockCampaignRepo.AssertExpectations(t)
+}
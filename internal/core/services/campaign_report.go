@@ -0,0 +1,329 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/pkg/customtime"
+	"github.com/market-place-affiliate/api/pkg/export"
+)
+
+// maxReportDays bounds the daily series of a campaign report.
+const maxReportDays = 366
+
+func (c *campaignService) GetCampaignReport(ctx context.Context, userId int64, campaignId string, query dto.CampaignReportRequest) (dto.Response[dto.CampaignReportResponse], error) {
+	campaign, res, err := c.getOwnedCampaign(ctx, userId, campaignId, true)
+	if err != nil || !res.Success {
+		return dto.Response[dto.CampaignReportResponse]{
+			HttpCode: res.HttpCode,
+			Success:  false,
+			Code:     res.Code,
+			Message:  res.Message,
+		}, err
+	}
+
+	startDay := startOfDay(campaign.StartAt, c.location)
+	if query.StartAt != "" {
+		startDay, err = time.ParseInLocation(time.DateOnly, query.StartAt, c.location)
+		if err != nil {
+			return invalidReportRange(err)
+		}
+	}
+	endDay := startOfDay(campaign.EndAt, c.location)
+	if query.EndAt != "" {
+		endDay, err = time.ParseInLocation(time.DateOnly, query.EndAt, c.location)
+		if err != nil {
+			return invalidReportRange(err)
+		}
+	} else {
+		// Up to today while the campaign runs, and the start day before it does.
+		endDay = minTime(endDay, startOfDay(customtime.Now(), c.location))
+		endDay = maxTime(endDay, startDay)
+	}
+	if endDay.Before(startDay) {
+		return invalidReportRange(errors.New("report end_at before start_at"))
+	}
+	if endDay.Sub(startDay) >= maxReportDays*24*time.Hour {
+		return invalidReportRange(errors.New("report range too long"))
+	}
+	// end is exclusive: the start of the day after endDay.
+	end := endDay.AddDate(0, 0, 1)
+
+	// Reports read the click rollups, whose visitor days are in c.location.
+	timezone := c.location.String()
+	totals, err := c.clickRepo.GetCampaignClickTotals(ctx, campaignId, startDay, end, timezone)
+	if err != nil {
+		return failedReport(err)
+	}
+	daily, err := c.clickRepo.GetCampaignDailyClicks(ctx, campaignId, startDay, end, timezone)
+	if err != nil {
+		return failedReport(err)
+	}
+	links, err := c.clickRepo.GetCampaignLinkClicks(ctx, campaignId, startDay, end, timezone)
+	if err != nil {
+		return failedReport(err)
+	}
+	products, err := c.clickRepo.GetCampaignProductClicks(ctx, campaignId, startDay, end, timezone)
+	if err != nil {
+		return failedReport(err)
+	}
+
+	for i := range links {
+		links[i].Share = clickShare(links[i].Clicks, totals.Clicks)
+	}
+	for i := range products {
+		products[i].Share = clickShare(products[i].Clicks, totals.Clicks)
+	}
+	report := dto.CampaignReportResponse{
+		Campaign: campaign,
+		StartAt:  startDay.Format(time.DateOnly),
+		EndAt:    endDay.Format(time.DateOnly),
+		Totals:   totals,
+		Daily:    fillDailyClicks(daily, startDay, endDay),
+		Links:    append([]dto.CampaignLinkReport{}, links...),
+		Products: append([]dto.CampaignProductReport{}, products...),
+	}
+	if query.Compare != "" {
+		report.Comparison, err = c.compareReport(ctx, campaignId, query.Compare, report, startDay, end)
+		if err != nil {
+			return failedReport(err)
+		}
+	}
+	return dto.Response[dto.CampaignReportResponse]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Campaign report fetched successfully",
+		Data:     report,
+	}, nil
+}
+
+// ExportCampaignReport writes the report of query to w: Daily, Links and Products sheets, and
+// when query asks for a comparison, the comparison period's Previous daily sheet and Links and
+// Products comparison sheets.
+func (c *campaignService) ExportCampaignReport(ctx context.Context, userId int64, campaignId string, query dto.CampaignReportRequest, w export.Writer) (dto.Response[any], error) {
+	res, err := c.GetCampaignReport(ctx, userId, campaignId, query)
+	if err != nil || !res.Success {
+		return dto.Response[any]{
+			HttpCode: res.HttpCode,
+			Success:  false,
+			Code:     res.Code,
+			Message:  res.Message,
+		}, err
+	}
+	err = writeReport(w, res.Data)
+	if err != nil {
+		return failedExport(3024, err)
+	}
+	return exported()
+}
+
+func writeReport(w export.Writer, report dto.CampaignReportResponse) error {
+	err := writeDailyClicks(w, "Daily", report.Daily)
+	if err != nil {
+		return err
+	}
+	links := report.Links
+	err = writeTable(w, "Links", []any{"link_id", "short_code", "product_id", "product_title", "marketplace", "clicks", "unique_visitors", "share", "deleted"}, len(links), func(n int) []any {
+		link := links[n]
+		return []any{link.LinkId, link.ShortCode, link.ProductId, link.ProductTitle, link.Marketplace, link.Clicks, link.UniqueVisitors, link.Share, link.Deleted}
+	})
+	if err != nil {
+		return err
+	}
+	products := report.Products
+	err = writeTable(w, "Products", []any{"product_id", "product_title", "marketplace", "clicks", "unique_visitors", "share"}, len(products), func(n int) []any {
+		product := products[n]
+		return []any{product.ProductId, product.ProductTitle, product.Marketplace, product.Clicks, product.UniqueVisitors, product.Share}
+	})
+	if err != nil || report.Comparison == nil {
+		return err
+	}
+
+	comparison := report.Comparison
+	err = writeDailyClicks(w, "Previous daily", comparison.Daily)
+	if err != nil {
+		return err
+	}
+	deltaColumns := []any{"clicks", "previous_clicks", "clicks_delta", "clicks_delta_ratio", "unique_visitors", "previous_unique_visitors", "unique_visitors_delta", "unique_visitors_delta_ratio"}
+	err = writeTable(w, "Links comparison", append([]any{"link_id", "short_code"}, deltaColumns...), len(comparison.Links), func(n int) []any {
+		link := comparison.Links[n]
+		return append([]any{link.LinkId, link.ShortCode}, deltaValues(link.Clicks, link.UniqueVisitors)...)
+	})
+	if err != nil {
+		return err
+	}
+	return writeTable(w, "Products comparison", append([]any{"product_id", "product_title"}, deltaColumns...), len(comparison.Products), func(n int) []any {
+		product := comparison.Products[n]
+		return append([]any{product.ProductId, product.ProductTitle}, deltaValues(product.Clicks, product.UniqueVisitors)...)
+	})
+}
+
+func writeDailyClicks(w export.Writer, sheet string, daily []dto.DailyClicks) error {
+	return writeTable(w, sheet, []any{"date", "clicks", "unique_visitors"}, len(daily), func(n int) []any {
+		return []any{daily[n].Date, daily[n].Clicks, daily[n].UniqueVisitors}
+	})
+}
+
+func deltaValues(deltas ...dto.MetricDelta) []any {
+	var values []any
+	for _, delta := range deltas {
+		values = append(values, delta.Current, delta.Previous, delta.Delta, delta.DeltaRatio)
+	}
+	return values
+}
+
+// compareReport counts the comparison period of [start, end) and compares the report with it.
+func (c *campaignService) compareReport(ctx context.Context, campaignId, compare string, report dto.CampaignReportResponse, start, end time.Time) (*dto.CampaignReportComparison, error) {
+	previousStart, previousEnd := comparisonRange(compare, start, end)
+	timezone := c.location.String()
+	totals, err := c.clickRepo.GetCampaignClickTotals(ctx, campaignId, previousStart, previousEnd, timezone)
+	if err != nil {
+		return nil, err
+	}
+	daily, err := c.clickRepo.GetCampaignDailyClicks(ctx, campaignId, previousStart, previousEnd, timezone)
+	if err != nil {
+		return nil, err
+	}
+	links, err := c.clickRepo.GetCampaignLinkClicks(ctx, campaignId, previousStart, previousEnd, timezone)
+	if err != nil {
+		return nil, err
+	}
+	products, err := c.clickRepo.GetCampaignProductClicks(ctx, campaignId, previousStart, previousEnd, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	previousEndDay := previousEnd.AddDate(0, 0, -1)
+	comparison := &dto.CampaignReportComparison{
+		Compare:        compare,
+		StartAt:        previousStart.Format(time.DateOnly),
+		EndAt:          previousEndDay.Format(time.DateOnly),
+		Clicks:         compareMetric(report.Totals.Clicks, totals.Clicks),
+		UniqueVisitors: compareMetric(report.Totals.UniqueVisitors, totals.UniqueVisitors),
+		Daily:          fillDailyClicks(daily, previousStart, previousEndDay),
+		Links:          []dto.LinkComparison{},
+		Products:       []dto.ProductComparison{},
+	}
+
+	// Current links and products first, then those only clicked in the comparison period.
+	currentLinks := map[uuid.UUID]dto.CampaignLinkReport{}
+	for _, link := range report.Links {
+		currentLinks[link.LinkId] = link
+	}
+	previousLinks := map[uuid.UUID]dto.CampaignLinkReport{}
+	for _, link := range links {
+		previousLinks[link.LinkId] = link
+	}
+	for _, link := range report.Links {
+		comparison.Links = append(comparison.Links, compareLink(link, link, previousLinks[link.LinkId]))
+	}
+	for _, link := range links {
+		if _, ok := currentLinks[link.LinkId]; !ok {
+			comparison.Links = append(comparison.Links, compareLink(link, dto.CampaignLinkReport{}, link))
+		}
+	}
+
+	currentProducts := map[uuid.UUID]dto.CampaignProductReport{}
+	for _, product := range report.Products {
+		currentProducts[product.ProductId] = product
+	}
+	previousProducts := map[uuid.UUID]dto.CampaignProductReport{}
+	for _, product := range products {
+		previousProducts[product.ProductId] = product
+	}
+	for _, product := range report.Products {
+		comparison.Products = append(comparison.Products, compareProduct(product, product, previousProducts[product.ProductId]))
+	}
+	for _, product := range products {
+		if _, ok := currentProducts[product.ProductId]; !ok {
+			comparison.Products = append(comparison.Products, compareProduct(product, dto.CampaignProductReport{}, product))
+		}
+	}
+	return comparison, nil
+}
+
+func compareLink(link, current, previous dto.CampaignLinkReport) dto.LinkComparison {
+	return dto.LinkComparison{
+		LinkId:         link.LinkId,
+		ShortCode:      link.ShortCode,
+		Clicks:         compareMetric(current.Clicks, previous.Clicks),
+		UniqueVisitors: compareMetric(current.UniqueVisitors, previous.UniqueVisitors),
+	}
+}
+
+func compareProduct(product, current, previous dto.CampaignProductReport) dto.ProductComparison {
+	return dto.ProductComparison{
+		ProductId:      product.ProductId,
+		ProductTitle:   product.ProductTitle,
+		Clicks:         compareMetric(current.Clicks, previous.Clicks),
+		UniqueVisitors: compareMetric(current.UniqueVisitors, previous.UniqueVisitors),
+	}
+}
+
+func invalidReportRange(err error) (dto.Response[dto.CampaignReportResponse], error) {
+	return dto.Response[dto.CampaignReportResponse]{
+		HttpCode: http.StatusBadRequest,
+		Success:  false,
+		Code:     3022,
+		Message:  "Report end_at must not be before start_at and the range may span at most 366 days",
+	}, err
+}
+
+func failedReport(err error) (dto.Response[dto.CampaignReportResponse], error) {
+	return dto.Response[dto.CampaignReportResponse]{
+		HttpCode: http.StatusInternalServerError,
+		Success:  false,
+		Code:     3023,
+		Message:  "Failed to aggregate campaign clicks",
+	}, err
+}
+
+// fillDailyClicks returns one entry per day from startDay to endDay, with zeros for days
+// without clicks.
+func fillDailyClicks(daily []dto.DailyClicks, startDay, endDay time.Time) []dto.DailyClicks {
+	byDate := map[string]dto.DailyClicks{}
+	for _, day := range daily {
+		byDate[day.Date] = day
+	}
+	filled := []dto.DailyClicks{}
+	for day := startDay; !day.After(endDay); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		entry, ok := byDate[date]
+		if !ok {
+			entry = dto.DailyClicks{Date: date}
+		}
+		filled = append(filled, entry)
+	}
+	return filled
+}
+
+func clickShare(clicks, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(clicks) / float64(total)
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
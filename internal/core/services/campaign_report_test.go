@@ -0,0 +1,197 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/market-place-affiliate/api/pkg/customtime"
+	"github.com/market-place-affiliate/api/pkg/eventbus"
+	"github.com/market-place-affiliate/api/pkg/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetCampaignReport(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockClickRepo := new(mocks.MockClickRepository)
+	bangkok := time.FixedZone("Asia/Bangkok", 7*60*60)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), mockClickRepo, new(mocks.MockLinkService), eventbus.New(), bangkok)
+
+	ctx := context.Background()
+	// The campaign started on 3 March and runs until the 31st; it is now the 5th in Bangkok.
+	customtime.Now = func() time.Time { return time.Date(2026, 3, 5, 10, 0, 0, 0, bangkok) }
+	defer func() { customtime.Now = time.Now }()
+	campaignId := uuid.Must(uuid.NewV4())
+	campaign := domains.Campaign{
+		Id:      campaignId,
+		UserId:  1,
+		StartAt: time.Date(2026, 3, 3, 9, 0, 0, 0, bangkok),
+		EndAt:   time.Date(2026, 3, 31, 9, 0, 0, 0, bangkok),
+	}
+	start := time.Date(2026, 3, 3, 0, 0, 0, 0, bangkok)
+	end := time.Date(2026, 3, 6, 0, 0, 0, 0, bangkok)
+	linkA := dto.CampaignLinkReport{LinkId: uuid.Must(uuid.NewV4()), ShortCode: "aaa", Marketplace: "lazada", Clicks: 3, UniqueVisitors: 2}
+	linkB := dto.CampaignLinkReport{LinkId: uuid.Must(uuid.NewV4()), ShortCode: "bbb", Marketplace: "shopee", Clicks: 1, UniqueVisitors: 1, Deleted: true}
+	mockCampaignRepo.On("GetCampaignByIdWithDeleted", ctx, campaignId.String()).Return(campaign, nil)
+	mockClickRepo.On("GetCampaignClickTotals", ctx, campaignId.String(), start, end, "Asia/Bangkok").Return(dto.ClickTotals{Clicks: 4, UniqueVisitors: 3}, nil)
+	mockClickRepo.On("GetCampaignDailyClicks", ctx, campaignId.String(), start, end, "Asia/Bangkok").Return([]dto.DailyClicks{
+		{Date: "2026-03-03", Clicks: 1, UniqueVisitors: 1},
+		{Date: "2026-03-05", Clicks: 3, UniqueVisitors: 2},
+	}, nil)
+	mockClickRepo.On("GetCampaignLinkClicks", ctx, campaignId.String(), start, end, "Asia/Bangkok").Return([]dto.CampaignLinkReport{linkA, linkB}, nil)
+	mockClickRepo.On("GetCampaignProductClicks", ctx, campaignId.String(), start, end, "Asia/Bangkok").Return([]dto.CampaignProductReport{
+		{ProductTitle: "Earbuds", Clicks: 4, UniqueVisitors: 3},
+	}, nil)
+
+	result, err := service.GetCampaignReport(ctx, 1, campaignId.String(), dto.CampaignReportRequest{})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "2026-03-03", result.Data.StartAt)
+	assert.Equal(t, "2026-03-05", result.Data.EndAt)
+	assert.Equal(t, dto.ClickTotals{Clicks: 4, UniqueVisitors: 3}, result.Data.Totals)
+	assert.Equal(t, []dto.DailyClicks{
+		{Date: "2026-03-03", Clicks: 1, UniqueVisitors: 1},
+		{Date: "2026-03-04"},
+		{Date: "2026-03-05", Clicks: 3, UniqueVisitors: 2},
+	}, result.Data.Daily)
+	assert.Equal(t, 0.75, result.Data.Links[0].Share)
+	assert.Equal(t, 0.25, result.Data.Links[1].Share)
+	assert.Equal(t, 1.0, result.Data.Products[0].Share)
+}
+
+func TestExportCampaignReport_ForbiddenWritesNothing(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository), new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignByIdWithDeleted", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 2}, nil)
+
+	var out bytes.Buffer
+	w, _ := export.NewWriter(export.FormatCSV, &out)
+	result, err := service.ExportCampaignReport(ctx, 1, campaignId.String(), dto.CampaignReportRequest{}, w)
+	assert.NoError(t, w.Close())
+
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 403, result.HttpCode)
+	assert.Zero(t, out.Len())
+}
+
+func TestWriteReport_WithComparison(t *testing.T) {
+	linkId := uuid.Must(uuid.FromString("0195a1b2-0000-7000-8000-000000000001"))
+	productId := uuid.Must(uuid.FromString("0195a1b2-0000-7000-8000-000000000002"))
+	report := dto.CampaignReportResponse{
+		Daily:    []dto.DailyClicks{{Date: "2026-03-01", Clicks: 9, UniqueVisitors: 6}},
+		Links:    []dto.CampaignLinkReport{{LinkId: linkId, ShortCode: "live", ProductId: productId, ProductTitle: "=cmd", Marketplace: "lazada", Clicks: 9, UniqueVisitors: 6, Share: 1}},
+		Products: []dto.CampaignProductReport{},
+		Comparison: &dto.CampaignReportComparison{
+			Daily:    []dto.DailyClicks{{Date: "2026-02-01", Clicks: 6, UniqueVisitors: 6}},
+			Links:    []dto.LinkComparison{{LinkId: linkId, ShortCode: "live", Clicks: compareMetric(9, 6), UniqueVisitors: compareMetric(6, 0)}},
+			Products: []dto.ProductComparison{},
+		},
+	}
+
+	var out bytes.Buffer
+	w, _ := export.NewWriter(export.FormatCSV, &out)
+	assert.NoError(t, writeReport(w, report))
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, "date,clicks,unique_visitors\n"+
+		"2026-03-01,9,6\n"+
+		"\n"+
+		"link_id,short_code,product_id,product_title,marketplace,clicks,unique_visitors,share,deleted\n"+
+		"0195a1b2-0000-7000-8000-000000000001,live,0195a1b2-0000-7000-8000-000000000002,'=cmd,lazada,9,6,1,false\n"+
+		"\n"+
+		"product_id,product_title,marketplace,clicks,unique_visitors,share\n"+
+		"\n"+
+		"date,clicks,unique_visitors\n"+
+		"2026-02-01,6,6\n"+
+		"\n"+
+		"link_id,short_code,clicks,previous_clicks,clicks_delta,clicks_delta_ratio,unique_visitors,previous_unique_visitors,unique_visitors_delta,unique_visitors_delta_ratio\n"+
+		"0195a1b2-0000-7000-8000-000000000001,live,9,6,3,0.5,6,0,6,\n"+
+		"\n"+
+		"product_id,product_title,clicks,previous_clicks,clicks_delta,clicks_delta_ratio,unique_visitors,previous_unique_visitors,unique_visitors_delta,unique_visitors_delta_ratio\n", out.String())
+}
+
+func TestGetCampaignReport_InvalidRange(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockClickRepo := new(mocks.MockClickRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), mockClickRepo, new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignByIdWithDeleted", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 1}, nil)
+
+	result, err := service.GetCampaignReport(ctx, 1, campaignId.String(), dto.CampaignReportRequest{StartAt: "2026-03-10", EndAt: "2026-03-01"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 400, result.HttpCode)
+	assert.Equal(t, 3022, result.Code)
+	mockClickRepo.AssertNotCalled(t, "GetCampaignClickTotals", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetCampaignReport_ComparesWithPreviousMonth(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockClickRepo := new(mocks.MockClickRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), mockClickRepo, new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignByIdWithDeleted", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 1}, nil)
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	previousStart := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	previousEnd := time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)
+	live := dto.CampaignLinkReport{LinkId: uuid.Must(uuid.NewV4()), ShortCode: "live"}
+	gone := dto.CampaignLinkReport{LinkId: uuid.Must(uuid.NewV4()), ShortCode: "gone", Deleted: true}
+	product := dto.CampaignProductReport{ProductId: uuid.Must(uuid.NewV4()), ProductTitle: "Earbuds"}
+
+	mockClickRepo.On("GetCampaignClickTotals", ctx, campaignId.String(), start, end, "UTC").Return(dto.ClickTotals{Clicks: 9, UniqueVisitors: 6}, nil)
+	mockClickRepo.On("GetCampaignDailyClicks", ctx, campaignId.String(), start, end, "UTC").Return([]dto.DailyClicks{{Date: "2026-03-01", Clicks: 9, UniqueVisitors: 6}}, nil)
+	mockClickRepo.On("GetCampaignLinkClicks", ctx, campaignId.String(), start, end, "UTC").Return([]dto.CampaignLinkReport{
+		{LinkId: live.LinkId, ShortCode: "live", Clicks: 9, UniqueVisitors: 6},
+	}, nil)
+	mockClickRepo.On("GetCampaignProductClicks", ctx, campaignId.String(), start, end, "UTC").Return([]dto.CampaignProductReport{
+		{ProductId: product.ProductId, ProductTitle: "Earbuds", Clicks: 9, UniqueVisitors: 6},
+	}, nil)
+	mockClickRepo.On("GetCampaignClickTotals", ctx, campaignId.String(), previousStart, previousEnd, "UTC").Return(dto.ClickTotals{Clicks: 6, UniqueVisitors: 6}, nil)
+	mockClickRepo.On("GetCampaignDailyClicks", ctx, campaignId.String(), previousStart, previousEnd, "UTC").Return([]dto.DailyClicks{{Date: "2026-02-02", Clicks: 6, UniqueVisitors: 6}}, nil)
+	mockClickRepo.On("GetCampaignLinkClicks", ctx, campaignId.String(), previousStart, previousEnd, "UTC").Return([]dto.CampaignLinkReport{
+		{LinkId: live.LinkId, ShortCode: "live", Clicks: 2, UniqueVisitors: 2},
+		{LinkId: gone.LinkId, ShortCode: "gone", Clicks: 4, UniqueVisitors: 4, Deleted: true},
+	}, nil)
+	mockClickRepo.On("GetCampaignProductClicks", ctx, campaignId.String(), previousStart, previousEnd, "UTC").Return([]dto.CampaignProductReport{
+		{ProductId: product.ProductId, ProductTitle: "Earbuds", Clicks: 6, UniqueVisitors: 6},
+	}, nil)
+
+	result, err := service.GetCampaignReport(ctx, 1, campaignId.String(), dto.CampaignReportRequest{StartAt: "2026-03-01", EndAt: "2026-03-02", Compare: dto.ComparePreviousMonth})
+
+	assert.NoError(t, err)
+	comparison := result.Data.Comparison
+	assert.Equal(t, "2026-02-01", comparison.StartAt)
+	assert.Equal(t, "2026-02-02", comparison.EndAt)
+	assert.Equal(t, int64(3), comparison.Clicks.Delta)
+	assert.Equal(t, 0.5, *comparison.Clicks.DeltaRatio)
+	assert.Equal(t, int64(0), comparison.UniqueVisitors.Delta)
+	assert.Equal(t, []dto.DailyClicks{{Date: "2026-02-01"}, {Date: "2026-02-02", Clicks: 6, UniqueVisitors: 6}}, comparison.Daily)
+	assert.Len(t, comparison.Links, 2)
+	assert.Equal(t, "live", comparison.Links[0].ShortCode)
+	assert.Equal(t, int64(7), comparison.Links[0].Clicks.Delta)
+	// A link only clicked last month is compared with nothing this month.
+	assert.Equal(t, "gone", comparison.Links[1].ShortCode)
+	assert.Equal(t, int64(-4), comparison.Links[1].Clicks.Delta)
+	assert.Equal(t, []dto.ProductComparison{{
+		ProductId:      product.ProductId,
+		ProductTitle:   "Earbuds",
+		Clicks:         compareMetric(9, 6),
+		UniqueVisitors: compareMetric(6, 6),
+	}}, comparison.Products)
+}
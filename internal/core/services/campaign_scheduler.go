@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+// CampaignScheduler moves scheduled campaigns to active and active or paused campaigns to
+// ended as their dates pass.
+type CampaignScheduler struct {
+	campaignRepo ports.CampaignRepository
+	events       ports.EventPublisher
+	location     *time.Location
+	interval     time.Duration
+}
+
+func NewCampaignScheduler(campaignRepo ports.CampaignRepository, events ports.EventPublisher, location *time.Location, interval time.Duration) *CampaignScheduler {
+	return &CampaignScheduler{campaignRepo: campaignRepo, events: events, location: location, interval: interval}
+}
+
+// Run ticks immediately and then every interval until ctx is done.
+func (s *CampaignScheduler) Run(ctx context.Context) {
+	runEvery(ctx, s.interval, "campaign scheduler", s.Tick)
+}
+
+// Tick applies every transition due at now.
+func (s *CampaignScheduler) Tick(ctx context.Context, now time.Time) error {
+	campaigns, err := s.campaignRepo.GetCampaignsByStates(ctx, []string{
+		domains.CampaignScheduled,
+		domains.CampaignActive,
+		domains.CampaignPaused,
+	})
+	if err != nil {
+		return err
+	}
+	for _, campaign := range campaigns {
+		next := campaign.ScheduledState(now, s.location)
+		if next == campaign.State {
+			continue
+		}
+		// A paused campaign stays paused until its owner resumes it, unless it runs out.
+		if campaign.State == domains.CampaignPaused && next != domains.CampaignEnded {
+			continue
+		}
+		ok, err := s.campaignRepo.UpdateCampaignState(ctx, campaign.Id.String(), campaign.State, next)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// The owner changed the state since it was read; leave it to the next tick.
+			continue
+		}
+		s.events.Publish(ctx, domains.TopicCampaignStateChanged, domains.CampaignStateChanged{
+			CampaignId: campaign.Id,
+			UserId:     campaign.UserId,
+			From:       campaign.State,
+			To:         next,
+			At:         now,
+		})
+	}
+	return nil
+}
+
+// runEvery calls tick immediately and then every interval until ctx is done. Errors are logged
+// and retried on the next tick.
+func runEvery(ctx context.Context, interval time.Duration, name string, tick func(ctx context.Context, now time.Time) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := tick(ctx, time.Now()); err != nil {
+			log.Printf("%s: %v\n", name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/market-place-affiliate/api/pkg/eventbus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignSchedulerTick(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	bus := eventbus.New()
+	events := []domains.CampaignStateChanged{}
+	bus.Subscribe(domains.TopicCampaignStateChanged, func(ctx context.Context, payload any) {
+		events = append(events, payload.(domains.CampaignStateChanged))
+	})
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	scheduler := NewCampaignScheduler(mockCampaignRepo, bus, bangkok, time.Minute)
+
+	ctx := context.Background()
+	// 2026-03-01 00:30 in Bangkok is still 2026-02-28 in UTC.
+	now := time.Date(2026, 2, 28, 17, 30, 0, 0, time.UTC)
+	starting := domains.Campaign{
+		Id:      uuid.Must(uuid.NewV4()),
+		State:   domains.CampaignScheduled,
+		StartAt: time.Date(2026, 3, 1, 9, 0, 0, 0, bangkok),
+		EndAt:   time.Date(2026, 3, 31, 9, 0, 0, 0, bangkok),
+	}
+	ending := domains.Campaign{
+		Id:      uuid.Must(uuid.NewV4()),
+		State:   domains.CampaignPaused,
+		StartAt: time.Date(2026, 2, 1, 9, 0, 0, 0, bangkok),
+		EndAt:   time.Date(2026, 2, 28, 9, 0, 0, 0, bangkok),
+	}
+	pausedRunning := domains.Campaign{
+		Id:      uuid.Must(uuid.NewV4()),
+		State:   domains.CampaignPaused,
+		StartAt: time.Date(2026, 2, 1, 9, 0, 0, 0, bangkok),
+		EndAt:   time.Date(2026, 3, 31, 9, 0, 0, 0, bangkok),
+	}
+	mockCampaignRepo.On("GetCampaignsByStates", ctx, []string{domains.CampaignScheduled, domains.CampaignActive, domains.CampaignPaused}).
+		Return([]domains.Campaign{starting, ending, pausedRunning}, nil)
+	mockCampaignRepo.On("UpdateCampaignState", ctx, starting.Id.String(), domains.CampaignScheduled, domains.CampaignActive).Return(true, nil)
+	mockCampaignRepo.On("UpdateCampaignState", ctx, ending.Id.String(), domains.CampaignPaused, domains.CampaignEnded).Return(true, nil)
+
+	err := scheduler.Tick(ctx, now)
+
+	assert.NoError(t, err)
+	mockCampaignRepo.AssertExpectations(t)
+	mockCampaignRepo.AssertNotCalled(t, "UpdateCampaignState", ctx, pausedRunning.Id.String(), domains.CampaignPaused, domains.CampaignActive)
+	assert.Equal(t, []domains.CampaignStateChanged{
+		{CampaignId: starting.Id, From: domains.CampaignScheduled, To: domains.CampaignActive, At: now},
+		{CampaignId: ending.Id, From: domains.CampaignPaused, To: domains.CampaignEnded, At: now},
+	}, events)
+}
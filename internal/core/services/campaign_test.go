@@ -8,7 +8,10 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
 	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/market-place-affiliate/api/pkg/customtime"
+	"github.com/market-place-affiliate/api/pkg/eventbus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -18,7 +21,7 @@ func TestCreateCampaign(t *testing.T) {
 	mockLinkRepo := new(mocks.MockLinkRepository)
 	mockClickRepo := new(mocks.MockClickRepository)
 
-	service := NewCampaignService(mockCampaignRepo, mockLinkRepo, mockClickRepo)
+	service := NewCampaignService(mockCampaignRepo, mockLinkRepo, mockClickRepo, new(mocks.MockLinkService), eventbus.New(), time.UTC)
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -59,7 +62,7 @@ func TestGetCampaignByQuery(t *testing.T) {
 	mockLinkRepo := new(mocks.MockLinkRepository)
 	mockClickRepo := new(mocks.MockClickRepository)
 
-	service := NewCampaignService(mockCampaignRepo, mockLinkRepo, mockClickRepo)
+	service := NewCampaignService(mockCampaignRepo, mockLinkRepo, mockClickRepo, new(mocks.MockLinkService), eventbus.New(), time.UTC)
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -76,7 +79,8 @@ func TestGetCampaignByQuery(t *testing.T) {
 		},
 	}
 
-	mockCampaignRepo.On("GetCampaignByQuery", ctx, userId, query).Return(expectedCampaigns, nil)
+	meta := dto.PageMeta{Page: 1, Limit: 20, Total: 1, TotalPages: 1}
+	mockCampaignRepo.On("GetCampaignByQuery", ctx, userId, query).Return(expectedCampaigns, meta, nil)
 
 	result, err := service.GetCampaignByQuery(ctx, userId, query)
 
@@ -84,6 +88,7 @@ func TestGetCampaignByQuery(t *testing.T) {
 	assert.True(t, result.Success)
 	assert.Equal(t, 0, result.Code)
 	assert.Equal(t, expectedCampaigns, result.Data)
+	assert.Equal(t, &meta, result.Meta)
 	mockCampaignRepo.AssertExpectations(t)
 }
 
@@ -92,12 +97,11 @@ func TestDeleteCampaignById_Success(t *testing.T) {
 	mockLinkRepo := new(mocks.MockLinkRepository)
 	mockClickRepo := new(mocks.MockClickRepository)
 
-	service := NewCampaignService(mockCampaignRepo, mockLinkRepo, mockClickRepo)
+	service := NewCampaignService(mockCampaignRepo, mockLinkRepo, mockClickRepo, new(mocks.MockLinkService), eventbus.New(), time.UTC)
 
 	ctx := context.Background()
 	userId := int64(1)
 	campaignId := uuid.Must(uuid.NewV4())
-	linkId := uuid.Must(uuid.NewV4())
 
 	campaign := domains.Campaign{
 		Id:     campaignId,
@@ -105,17 +109,7 @@ func TestDeleteCampaignById_Success(t *testing.T) {
 		Name:   "Test Campaign",
 	}
 
-	links := []domains.Link{
-		{
-			Id:         linkId,
-			CampaignId: campaignId,
-		},
-	}
-
 	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(campaign, nil)
-	mockLinkRepo.On("GetLinksByCampaignId", ctx, campaignId.String()).Return(links, nil)
-	mockClickRepo.On("DeleteClicksByLinkId", ctx, linkId.String()).Return(nil)
-	mockLinkRepo.On("DeleteLink", ctx, linkId.String()).Return(nil)
 	mockCampaignRepo.On("DeleteCampaign", ctx, campaignId.String()).Return(nil)
 
 	result, err := service.DeleteCampaignById(ctx, userId, campaignId.String())
@@ -124,8 +118,8 @@ func TestDeleteCampaignById_Success(t *testing.T) {
 	assert.True(t, result.Success)
 	assert.Equal(t, 0, result.Code)
 	mockCampaignRepo.AssertExpectations(t)
-	mockLinkRepo.AssertExpectations(t)
-	mockClickRepo.AssertExpectations(t)
+	// Links and clicks are kept for restore and historical analytics.
+	mockLinkRepo.AssertNotCalled(t, "DeleteLink", mock.Anything, mock.Anything)
 }
 
 func TestDeleteCampaignById_Forbidden(t *testing.T) {
@@ -133,7 +127,7 @@ func TestDeleteCampaignById_Forbidden(t *testing.T) {
 	mockLinkRepo := new(mocks.MockLinkRepository)
 	mockClickRepo := new(mocks.MockClickRepository)
 
-	service := NewCampaignService(mockCampaignRepo, mockLinkRepo, mockClickRepo)
+	service := NewCampaignService(mockCampaignRepo, mockLinkRepo, mockClickRepo, new(mocks.MockLinkService), eventbus.New(), time.UTC)
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -161,10 +155,10 @@ func TestGetPublicCampaigns(t *testing.T) {
 	mockLinkRepo := new(mocks.MockLinkRepository)
 	mockClickRepo := new(mocks.MockClickRepository)
 
-	service := NewCampaignService(mockCampaignRepo, mockLinkRepo, mockClickRepo)
+	service := NewCampaignService(mockCampaignRepo, mockLinkRepo, mockClickRepo, new(mocks.MockLinkService), eventbus.New(), time.UTC)
 
 	ctx := context.Background()
-	query := dto.GetCampaignByQueryRequest{}
+	query := dto.GetCampaignByQueryRequest{Sort: "name", Limit: 10}
 
 	expectedCampaigns := []domains.Campaign{
 		{
@@ -173,7 +167,11 @@ func TestGetPublicCampaigns(t *testing.T) {
 		},
 	}
 
-	mockCampaignRepo.On("GetAvailableCampaign", ctx).Return(expectedCampaigns, nil)
+	mockCampaignRepo.On("GetCampaignByQuery", ctx, int64(0), dto.GetCampaignByQueryRequest{
+		Sort:  "name",
+		Limit: 10,
+		State: domains.CampaignActive,
+	}).Return(expectedCampaigns, dto.PageMeta{Page: 1, Limit: 10, Total: 1, TotalPages: 1}, nil)
 
 	result, err := service.GetPublicCampaigns(ctx, query)
 
@@ -183,3 +181,308 @@ func TestGetPublicCampaigns(t *testing.T) {
 	assert.Equal(t, expectedCampaigns, result.Data)
 	mockCampaignRepo.AssertExpectations(t)
 }
+
+func TestUpdateCampaign_RegeneratesLinks(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	mockClickRepo := new(mocks.MockClickRepository)
+	mockLinkService := new(mocks.MockLinkService)
+
+	service := NewCampaignService(mockCampaignRepo, mockLinkRepo, mockClickRepo, mockLinkService, eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	userId := int64(1)
+	now := time.Now()
+	campaignId := uuid.Must(uuid.NewV4())
+	campaign := domains.Campaign{
+		Id:          campaignId,
+		Name:        "Summer Sale",
+		UtmCampaign: "summer",
+		StartAt:     now,
+		EndAt:       now.Add(24 * time.Hour),
+		UserId:      userId,
+	}
+	okLink := domains.Link{Id: uuid.Must(uuid.NewV4()), ProductId: uuid.Must(uuid.NewV4()), CampaignId: campaignId}
+	failedLink := domains.Link{Id: uuid.Must(uuid.NewV4()), ProductId: uuid.Must(uuid.NewV4()), CampaignId: campaignId}
+
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(campaign, nil)
+	mockCampaignRepo.On("SaveCampaign", ctx, mock.MatchedBy(func(c domains.Campaign) bool {
+		return c.UtmCampaign == "summer_2026" && c.Name == "Summer Sale"
+	})).Return(domains.Campaign{Id: campaignId, Name: "Summer Sale", UtmCampaign: "summer_2026", UserId: userId}, nil)
+	mockLinkRepo.On("GetLinksByCampaignId", ctx, campaignId.String()).Return([]domains.Link{okLink, failedLink}, nil)
+	mockLinkService.On("RegenerateLink", ctx, userId, okLink.Id.String()).Return(dto.Response[domains.Link]{Success: true, Data: okLink}, nil)
+	mockLinkService.On("RegenerateLink", ctx, userId, failedLink.Id.String()).Return(dto.Response[domains.Link]{
+		Success: false,
+		Code:    4009,
+		Message: "Marketplace credentials not found",
+	}, assert.AnError)
+
+	utm := "summer_2026"
+	result, err := service.UpdateCampaign(ctx, userId, campaignId.String(), dto.UpdateCampaignRequest{
+		UtmCampaign:     &utm,
+		RegenerateLinks: true,
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "summer_2026", result.Data.Campaign.UtmCampaign)
+	assert.Equal(t, []domains.Link{okLink}, result.Data.Links)
+	assert.Equal(t, 1, len(result.Data.Failures))
+	assert.Equal(t, failedLink.ProductId, result.Data.Failures[0].ProductId)
+	assert.Equal(t, 4009, result.Data.Failures[0].Code)
+	mockCampaignRepo.AssertExpectations(t)
+	mockLinkService.AssertExpectations(t)
+}
+
+func TestUpdateCampaign_Validation(t *testing.T) {
+	now := time.Now()
+	campaignId := uuid.Must(uuid.NewV4())
+	campaign := domains.Campaign{
+		Id:          campaignId,
+		Name:        "Summer Sale",
+		UtmCampaign: "summer",
+		StartAt:     now,
+		EndAt:       now.Add(24 * time.Hour),
+		UserId:      1,
+	}
+	earlier := now.Add(-time.Hour)
+	badUtm := "summer sale?"
+
+	tests := []struct {
+		name    string
+		request dto.UpdateCampaignRequest
+		code    int
+	}{
+		{"end before start", dto.UpdateCampaignRequest{EndAt: &earlier}, 3009},
+		{"utm with spaces", dto.UpdateCampaignRequest{UtmCampaign: &badUtm}, 3010},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCampaignRepo := new(mocks.MockCampaignRepository)
+			service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository), new(mocks.MockLinkService), eventbus.New(), time.UTC)
+			ctx := context.Background()
+			mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(campaign, nil)
+
+			result, err := service.UpdateCampaign(ctx, 1, campaignId.String(), tt.request)
+
+			assert.Error(t, err)
+			assert.False(t, result.Success)
+			assert.Equal(t, tt.code, result.Code)
+			mockCampaignRepo.AssertNotCalled(t, "SaveCampaign", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUpdateCampaign_Forbidden(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository), new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 2}, nil)
+
+	name := "Renamed"
+	result, err := service.UpdateCampaign(ctx, 1, campaignId.String(), dto.UpdateCampaignRequest{Name: &name})
+
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 3005, result.Code)
+	mockCampaignRepo.AssertNotCalled(t, "SaveCampaign", mock.Anything, mock.Anything)
+}
+
+func TestChangeCampaignState_ResumesToScheduledState(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	bus := eventbus.New()
+	events := []domains.CampaignStateChanged{}
+	bus.Subscribe(domains.TopicCampaignStateChanged, func(ctx context.Context, payload any) {
+		events = append(events, payload.(domains.CampaignStateChanged))
+	})
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository), new(mocks.MockLinkService), bus, time.UTC)
+
+	ctx := context.Background()
+	now := time.Now()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(domains.Campaign{
+		Id:      campaignId,
+		UserId:  1,
+		State:   domains.CampaignPaused,
+		StartAt: now.AddDate(0, 0, -2),
+		EndAt:   now.AddDate(0, 0, 2),
+	}, nil)
+	mockCampaignRepo.On("UpdateCampaignState", ctx, campaignId.String(), domains.CampaignPaused, domains.CampaignActive).Return(true, nil)
+
+	result, err := service.ChangeCampaignState(ctx, 1, campaignId.String(), dto.CampaignStateRequest{State: domains.CampaignScheduled})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, domains.CampaignActive, result.Data.State)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, domains.CampaignPaused, events[0].From)
+	assert.Equal(t, domains.CampaignActive, events[0].To)
+	mockCampaignRepo.AssertExpectations(t)
+}
+
+func TestChangeCampaignState_InvalidTransition(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository), new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 1, State: domains.CampaignArchived}, nil)
+
+	result, err := service.ChangeCampaignState(ctx, 1, campaignId.String(), dto.CampaignStateRequest{State: domains.CampaignActive})
+
+	assert.Error(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 3012, result.Code)
+	mockCampaignRepo.AssertNotCalled(t, "UpdateCampaignState", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetCampaignByQuery_InvalidCursor(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository), new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	query := dto.GetCampaignByQueryRequest{Cursor: "not-a-cursor"}
+	mockCampaignRepo.On("GetCampaignByQuery", ctx, int64(1), query).Return([]domains.Campaign(nil), dto.PageMeta{}, ports.ErrInvalidCursor)
+
+	result, err := service.GetCampaignByQuery(ctx, 1, query)
+
+	assert.ErrorIs(t, err, ports.ErrInvalidCursor)
+	assert.Equal(t, 400, result.HttpCode)
+	assert.Equal(t, 3019, result.Code)
+	assert.Nil(t, result.Meta)
+}
+
+func TestGetPublicCampaigns_SortByClicks(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository), new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	result, err := service.GetPublicCampaigns(context.Background(), dto.GetCampaignByQueryRequest{Sort: "clicks"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 400, result.HttpCode)
+	assert.Equal(t, 3025, result.Code)
+	mockCampaignRepo.AssertNotCalled(t, "GetCampaignByQuery", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCloneCampaign_CreatesLinksForEachProduct(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	mockLinkService := new(mocks.MockLinkService)
+
+	service := NewCampaignService(mockCampaignRepo, mockLinkRepo, new(mocks.MockClickRepository), mockLinkService, eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	userId := int64(1)
+	start := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	customtime.Now = func() time.Time { return start.AddDate(0, 0, -5) }
+	defer func() { customtime.Now = time.Now }()
+
+	sourceId := uuid.Must(uuid.NewV4())
+	cloneId := uuid.Must(uuid.NewV4())
+	okProduct := uuid.Must(uuid.NewV4())
+	failedProduct := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignById", ctx, sourceId.String()).Return(domains.Campaign{Id: sourceId, Name: "9.9 Sale", UtmCampaign: "sale_0909", UserId: userId}, nil)
+	mockLinkRepo.On("GetLinksByCampaignId", ctx, sourceId.String()).Return([]domains.Link{
+		{ProductId: okProduct, CampaignId: sourceId},
+		{ProductId: okProduct, CampaignId: sourceId},
+		{ProductId: failedProduct, CampaignId: sourceId},
+	}, nil)
+	mockCampaignRepo.On("SaveCampaign", ctx, mock.MatchedBy(func(c domains.Campaign) bool {
+		return c.Name == "9.9 Sale" && c.UtmCampaign == "sale_1010" && c.State == domains.CampaignScheduled && c.Id == uuid.Nil
+	})).Return(domains.Campaign{Id: cloneId, Name: "9.9 Sale", UtmCampaign: "sale_1010", UserId: userId, State: domains.CampaignScheduled}, nil)
+	okLink := domains.Link{Id: uuid.Must(uuid.NewV4()), ProductId: okProduct, CampaignId: cloneId}
+	mockLinkService.On("CreateLink", ctx, userId, dto.CreateLinkRequest{ProductId: okProduct, CampaignId: cloneId}).Return(dto.Response[domains.Link]{Success: true, Data: okLink}, nil).Once()
+	mockLinkService.On("CreateLink", ctx, userId, dto.CreateLinkRequest{ProductId: failedProduct, CampaignId: cloneId}).Return(dto.Response[domains.Link]{
+		Success: false,
+		Code:    4005,
+		Message: "Failed to create affiliate link",
+	}, assert.AnError)
+
+	result, err := service.CloneCampaign(ctx, userId, sourceId.String(), dto.CloneCampaignRequest{
+		UtmCampaign: "sale_1010",
+		StartAt:     start,
+		EndAt:       start.AddDate(0, 0, 1),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, cloneId, result.Data.Campaign.Id)
+	assert.Equal(t, []domains.Link{okLink}, result.Data.Links)
+	assert.Equal(t, []dto.LinkFailure{{ProductId: failedProduct, Code: 4005, Message: "Failed to create affiliate link"}}, result.Data.Failures)
+	mockCampaignRepo.AssertExpectations(t)
+	mockLinkService.AssertExpectations(t)
+}
+
+func TestCloneCampaign_Forbidden(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository), new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 2}, nil)
+
+	start := time.Now()
+	result, err := service.CloneCampaign(ctx, 1, campaignId.String(), dto.CloneCampaignRequest{
+		UtmCampaign: "sale_1010",
+		StartAt:     start,
+		EndAt:       start.Add(time.Hour),
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 403, result.HttpCode)
+	mockCampaignRepo.AssertNotCalled(t, "SaveCampaign", mock.Anything, mock.Anything)
+}
+
+func TestRestoreCampaign(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository), new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	deletedAt := time.Now()
+	deleted := domains.Campaign{Id: campaignId, UserId: 1, DeletedAt: &deletedAt}
+	mockCampaignRepo.On("GetCampaignByIdWithDeleted", ctx, campaignId.String()).Return(deleted, nil)
+	mockCampaignRepo.On("RestoreCampaign", ctx, campaignId.String()).Return(nil)
+
+	result, err := service.RestoreCampaign(ctx, 1, campaignId.String())
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Nil(t, result.Data.DeletedAt)
+	mockCampaignRepo.AssertExpectations(t)
+}
+
+func TestRestoreCampaign_NotDeleted(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository), new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignByIdWithDeleted", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 1}, nil)
+
+	result, err := service.RestoreCampaign(ctx, 1, campaignId.String())
+
+	assert.Error(t, err)
+	assert.Equal(t, 400, result.HttpCode)
+	assert.Equal(t, 3020, result.Code)
+	mockCampaignRepo.AssertNotCalled(t, "RestoreCampaign", mock.Anything, mock.Anything)
+}
+
+func TestRestoreCampaign_NotFound(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	service := NewCampaignService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository), new(mocks.MockLinkService), eventbus.New(), time.UTC)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignByIdWithDeleted", ctx, campaignId.String()).Return(domains.Campaign{}, ports.ErrCampaignNotFound)
+
+	result, err := service.RestoreCampaign(ctx, 1, campaignId.String())
+
+	assert.ErrorIs(t, err, ports.ErrCampaignNotFound)
+	assert.Equal(t, 404, result.HttpCode)
+	assert.Equal(t, 3026, result.Code)
+	mockCampaignRepo.AssertNotCalled(t, "RestoreCampaign", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/market-place-affiliate/api/pkg/customtime"
+)
+
+// rollupChunk bounds how much click history one RollupClicks call recounts, so a backfill runs
+// as many short transactions instead of one long one.
+const rollupChunk = 24 * time.Hour
+
+// rollupLag is how far before the previous run each run starts recounting, so clicks stamped
+// just before that run but committed after it are still counted.
+const rollupLag = time.Minute
+
+// ClickRollup keeps the click rollups read by the dashboard and reports up to date.
+type ClickRollup struct {
+	rollupRepo ports.ClickRollupRepository
+	location   *time.Location
+	interval   time.Duration
+	rolledUpTo time.Time
+}
+
+// NewClickRollup records visitors per day in location, which should be the timezone reports
+// are read in.
+func NewClickRollup(rollupRepo ports.ClickRollupRepository, location *time.Location, interval time.Duration) *ClickRollup {
+	return &ClickRollup{rollupRepo: rollupRepo, location: location, interval: interval}
+}
+
+// Run rolls up immediately and then every interval until ctx is done.
+func (r *ClickRollup) Run(ctx context.Context) {
+	runEvery(ctx, r.interval, "click rollup", r.Tick)
+}
+
+// Tick recounts from the hour of the previous tick until now. The first tick resumes from the
+// latest rolled up hour, or from the first click when nothing has been rolled up yet.
+func (r *ClickRollup) Tick(ctx context.Context, now time.Time) error {
+	from := r.rolledUpTo.Add(-rollupLag)
+	if r.rolledUpTo.IsZero() {
+		latest, err := r.rollupRepo.GetLatestRollupBucket(ctx)
+		if err != nil {
+			return err
+		}
+		from = latest
+		if from.IsZero() {
+			from, err = r.firstClickAt(ctx, now)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if err := r.Rollup(ctx, from, now); err != nil {
+		return err
+	}
+	r.rolledUpTo = now
+	return nil
+}
+
+// Backfill rebuilds the rollups in [from, to) from raw clicks. A zero from starts at the first
+// click and a zero to ends now.
+func (r *ClickRollup) Backfill(ctx context.Context, from, to time.Time) error {
+	if to.IsZero() {
+		to = customtime.Now()
+	}
+	if from.IsZero() {
+		var err error
+		from, err = r.firstClickAt(ctx, to)
+		if err != nil {
+			return err
+		}
+	}
+	return r.Rollup(ctx, from, to)
+}
+
+// Rollup recounts [from, to) a chunk at a time, starting at the hour from falls in.
+func (r *ClickRollup) Rollup(ctx context.Context, from, to time.Time) error {
+	for start := from.Truncate(time.Hour); start.Before(to); start = start.Add(rollupChunk) {
+		end := minTime(start.Add(rollupChunk), to)
+		if err := r.rollupRepo.RollupClicks(ctx, start, end, r.location.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// firstClickAt is when the first click was recorded, or fallback when there are none.
+func (r *ClickRollup) firstClickAt(ctx context.Context, fallback time.Time) (time.Time, error) {
+	earliest, err := r.rollupRepo.GetEarliestClickAt(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if earliest.IsZero() {
+		return fallback, nil
+	}
+	return earliest, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestClickRollupTick_ResumesFromLatestBucket(t *testing.T) {
+	mockRollupRepo := new(mocks.MockClickRollupRepository)
+	rollup := NewClickRollup(mockRollupRepo, time.UTC, time.Minute)
+
+	ctx := context.Background()
+	latest := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 5, 10, 20, 0, 0, time.UTC)
+	mockRollupRepo.On("GetLatestRollupBucket", ctx).Return(latest, nil).Once()
+	mockRollupRepo.On("RollupClicks", ctx, latest, now, "UTC").Return(nil).Once()
+
+	assert.NoError(t, rollup.Tick(ctx, now))
+
+	// The next tick recounts the hour of the previous one without asking for the latest bucket.
+	next := now.Add(time.Minute)
+	mockRollupRepo.On("RollupClicks", ctx, time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC), next, "UTC").Return(nil).Once()
+
+	assert.NoError(t, rollup.Tick(ctx, next))
+	mockRollupRepo.AssertExpectations(t)
+}
+
+func TestClickRollupTick_RecountsPreviousHourAcrossBoundary(t *testing.T) {
+	mockRollupRepo := new(mocks.MockClickRollupRepository)
+	rollup := NewClickRollup(mockRollupRepo, time.UTC, time.Minute)
+
+	ctx := context.Background()
+	now := time.Date(2026, 3, 5, 11, 0, 30, 0, time.UTC)
+	mockRollupRepo.On("GetLatestRollupBucket", ctx).Return(time.Date(2026, 3, 5, 11, 0, 0, 0, time.UTC), nil)
+	mockRollupRepo.On("RollupClicks", ctx, mock.Anything, mock.Anything, "UTC").Return(nil)
+	assert.NoError(t, rollup.Tick(ctx, now))
+
+	// Clicks stamped just before 11:00:30 may commit after it, so the 10:00 hour is recounted.
+	next := now.Add(time.Minute)
+	assert.NoError(t, rollup.Tick(ctx, next))
+	mockRollupRepo.AssertCalled(t, "RollupClicks", ctx, time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC), next, "UTC")
+}
+
+func TestClickRollupTick_BackfillsFromFirstClickInChunks(t *testing.T) {
+	mockRollupRepo := new(mocks.MockClickRollupRepository)
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	rollup := NewClickRollup(mockRollupRepo, bangkok, time.Minute)
+
+	ctx := context.Background()
+	now := time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)
+	mockRollupRepo.On("GetLatestRollupBucket", ctx).Return(time.Time{}, nil)
+	mockRollupRepo.On("GetEarliestClickAt", ctx).Return(time.Date(2026, 3, 1, 8, 45, 0, 0, time.UTC), nil)
+	mockRollupRepo.On("RollupClicks", ctx, time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC), time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC), "Asia/Bangkok").Return(nil).Once()
+	mockRollupRepo.On("RollupClicks", ctx, time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC), time.Date(2026, 3, 3, 8, 0, 0, 0, time.UTC), "Asia/Bangkok").Return(nil).Once()
+	mockRollupRepo.On("RollupClicks", ctx, time.Date(2026, 3, 3, 8, 0, 0, 0, time.UTC), now, "Asia/Bangkok").Return(nil).Once()
+
+	assert.NoError(t, rollup.Tick(ctx, now))
+	mockRollupRepo.AssertExpectations(t)
+}
+
+func TestClickRollupTick_RetriesAfterError(t *testing.T) {
+	mockRollupRepo := new(mocks.MockClickRollupRepository)
+	rollup := NewClickRollup(mockRollupRepo, time.UTC, time.Minute)
+
+	ctx := context.Background()
+	latest := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 5, 9, 30, 0, 0, time.UTC)
+	mockRollupRepo.On("GetLatestRollupBucket", ctx).Return(latest, nil)
+	mockRollupRepo.On("RollupClicks", ctx, latest, now, "UTC").Return(assert.AnError).Once()
+
+	assert.Error(t, rollup.Tick(ctx, now))
+
+	// The failed range is not skipped: the next tick starts from the latest bucket again.
+	next := now.Add(time.Minute)
+	mockRollupRepo.On("RollupClicks", ctx, latest, next, "UTC").Return(nil).Once()
+	assert.NoError(t, rollup.Tick(ctx, next))
+	mockRollupRepo.AssertNumberOfCalls(t, "GetLatestRollupBucket", 2)
+}
+
+func TestClickRollupBackfill_Range(t *testing.T) {
+	mockRollupRepo := new(mocks.MockClickRollupRepository)
+	rollup := NewClickRollup(mockRollupRepo, time.UTC, 0)
+
+	ctx := context.Background()
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	mockRollupRepo.On("RollupClicks", ctx, from, to, "UTC").Return(nil).Once()
+
+	assert.NoError(t, rollup.Backfill(ctx, from, to))
+	mockRollupRepo.AssertExpectations(t)
+	mockRollupRepo.AssertNotCalled(t, "GetEarliestClickAt", mock.Anything)
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+// clickStreamBuffer is how many clicks a subscriber may fall behind before further clicks are
+// dropped for it. Redirects never wait for a slow subscriber.
+const clickStreamBuffer = 64
+
+type clickStreamService struct {
+	campaignRepo ports.CampaignRepository
+	linkRepo     ports.LinkRepository
+
+	mu          sync.Mutex
+	nextId      int
+	subscribers map[int]clickSubscriber
+	// owners remembers the user of each campaign a click was delivered for, since clicks do
+	// not carry it and campaigns never change hands.
+	owners map[uuid.UUID]int64
+}
+
+type clickSubscriber struct {
+	userId     int64
+	campaignId uuid.UUID
+	linkId     uuid.UUID
+	clicks     chan domains.ClickRecorded
+}
+
+// NewClickStreamService follows the domains.TopicClickRecorded events of events, which include
+// those relayed from other instances when running more than one.
+func NewClickStreamService(campaignRepo ports.CampaignRepository, linkRepo ports.LinkRepository, events ports.EventSubscriber) ports.ClickStreamService {
+	s := &clickStreamService{campaignRepo: campaignRepo, linkRepo: linkRepo, subscribers: map[int]clickSubscriber{}, owners: map[uuid.UUID]int64{}}
+	events.Subscribe(domains.TopicClickRecorded, s.deliver)
+	return s
+}
+
+// Subscribe returns the clicks recorded on the user's links from now on, only those of
+// query's campaign or link when it names one. The channel is closed once ctx is done.
+func (s *clickStreamService) Subscribe(ctx context.Context, userId int64, query dto.ClickStreamRequest) (dto.Response[<-chan domains.ClickRecorded], error) {
+	subscriber := clickSubscriber{userId: userId, clicks: make(chan domains.ClickRecorded, clickStreamBuffer)}
+	if query.LinkId != "" {
+		link, err := s.linkRepo.GetLinkById(ctx, query.LinkId)
+		if err != nil {
+			return dto.Response[<-chan domains.ClickRecorded]{
+				HttpCode: http.StatusNotFound,
+				Success:  false,
+				Code:     11001,
+				Message:  "Link not found",
+			}, err
+		}
+		subscriber.linkId = link.Id
+		query.CampaignId = link.CampaignId.String()
+	}
+	if query.CampaignId != "" {
+		campaignId := uuid.FromStringOrNil(query.CampaignId)
+		owner, err := s.owner(ctx, campaignId)
+		if err != nil {
+			return dto.Response[<-chan domains.ClickRecorded]{
+				HttpCode: http.StatusNotFound,
+				Success:  false,
+				Code:     11002,
+				Message:  "Campaign not found",
+			}, err
+		}
+		if owner != userId {
+			return dto.Response[<-chan domains.ClickRecorded]{
+				HttpCode: http.StatusForbidden,
+				Success:  false,
+				Code:     11003,
+				Message:  "You do not have permission to follow this campaign's clicks",
+			}, errors.New("campaign of another user")
+		}
+		subscriber.campaignId = campaignId
+	}
+
+	s.mu.Lock()
+	id := s.nextId
+	s.nextId++
+	s.subscribers[id] = subscriber
+	s.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers, id)
+		close(subscriber.clicks)
+	}()
+
+	return dto.Response[<-chan domains.ClickRecorded]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Data:     subscriber.clicks,
+	}, nil
+}
+
+// deliver hands a recorded click to the subscribers of its owner. Nothing is looked up while
+// nobody is subscribed.
+func (s *clickStreamService) deliver(ctx context.Context, payload any) {
+	click, ok := payload.(domains.ClickRecorded)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	idle := len(s.subscribers) == 0
+	s.mu.Unlock()
+	if idle {
+		return
+	}
+	userId, err := s.owner(ctx, click.CampaignId)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, subscriber := range s.subscribers {
+		if subscriber.userId != userId ||
+			(!subscriber.campaignId.IsNil() && subscriber.campaignId != click.CampaignId) ||
+			(!subscriber.linkId.IsNil() && subscriber.linkId != click.LinkId) {
+			continue
+		}
+		select {
+		case subscriber.clicks <- click:
+		default:
+		}
+	}
+}
+
+func (s *clickStreamService) owner(ctx context.Context, campaignId uuid.UUID) (int64, error) {
+	s.mu.Lock()
+	userId, ok := s.owners[campaignId]
+	s.mu.Unlock()
+	if ok {
+		return userId, nil
+	}
+	campaign, err := s.campaignRepo.GetCampaignById(ctx, campaignId.String())
+	if err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	s.owners[campaignId] = campaign.UserId
+	s.mu.Unlock()
+	return campaign.UserId, nil
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/market-place-affiliate/api/pkg/eventbus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClickStream_DeliversOwnClicks(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	bus := eventbus.New()
+	service := NewClickStreamService(mockCampaignRepo, new(mocks.MockLinkRepository), bus)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	own := uuid.Must(uuid.NewV4())
+	other := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignById", ctx, own.String()).Return(domains.Campaign{Id: own, UserId: 7}, nil)
+	mockCampaignRepo.On("GetCampaignById", ctx, other.String()).Return(domains.Campaign{Id: other, UserId: 8}, nil)
+
+	// Nothing is looked up until someone follows the stream.
+	bus.Publish(ctx, domains.TopicClickRecorded, domains.ClickRecorded{ClickId: "0", CampaignId: own})
+	mockCampaignRepo.AssertNotCalled(t, "GetCampaignById", ctx, own.String())
+
+	all, err := service.Subscribe(ctx, 7, dto.ClickStreamRequest{})
+	assert.NoError(t, err)
+	filtered, err := service.Subscribe(ctx, 7, dto.ClickStreamRequest{CampaignId: other.String()})
+	assert.Error(t, err)
+	assert.Equal(t, 403, filtered.HttpCode)
+	assert.Equal(t, 11003, filtered.Code)
+
+	bus.Publish(ctx, domains.TopicClickRecorded, domains.ClickRecorded{ClickId: "1", CampaignId: own})
+	bus.Publish(ctx, domains.TopicClickRecorded, domains.ClickRecorded{ClickId: "2", CampaignId: other})
+	bus.Publish(ctx, domains.TopicClickRecorded, domains.ClickRecorded{ClickId: "3", CampaignId: own})
+	cancel()
+
+	var received []string
+	for click := range all.Data {
+		received = append(received, click.ClickId)
+	}
+	assert.Equal(t, []string{"1", "3"}, received)
+	// Campaign owners are only looked up once.
+	mockCampaignRepo.AssertNumberOfCalls(t, "GetCampaignById", 2)
+}
+
+func TestClickStream_LinkFilter(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	bus := eventbus.New()
+	service := NewClickStreamService(mockCampaignRepo, mockLinkRepo, bus)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	campaignId := uuid.Must(uuid.NewV4())
+	link := domains.Link{Id: uuid.Must(uuid.NewV4()), CampaignId: campaignId}
+	mockLinkRepo.On("GetLinkById", ctx, link.Id.String()).Return(link, nil)
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 7}, nil)
+
+	result, err := service.Subscribe(ctx, 7, dto.ClickStreamRequest{LinkId: link.Id.String()})
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+
+	bus.Publish(ctx, domains.TopicClickRecorded, domains.ClickRecorded{ClickId: "1", CampaignId: campaignId, LinkId: uuid.Must(uuid.NewV4())})
+	bus.Publish(ctx, domains.TopicClickRecorded, domains.ClickRecorded{ClickId: "2", CampaignId: campaignId, LinkId: link.Id})
+	cancel()
+
+	var received []string
+	for click := range result.Data {
+		received = append(received, click.ClickId)
+	}
+	assert.Equal(t, []string{"2"}, received)
+}
+
+func TestClickStream_UnknownLink(t *testing.T) {
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	service := NewClickStreamService(new(mocks.MockCampaignRepository), mockLinkRepo, eventbus.New())
+
+	ctx := context.Background()
+	linkId := uuid.Must(uuid.NewV4())
+	mockLinkRepo.On("GetLinkById", ctx, linkId.String()).Return(domains.Link{}, errors.New("record not found"))
+
+	result, err := service.Subscribe(ctx, 7, dto.ClickStreamRequest{LinkId: linkId.String()})
+
+	assert.Error(t, err)
+	assert.Equal(t, 404, result.HttpCode)
+	assert.Equal(t, 11001, result.Code)
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+type collectionService struct {
+	collectionRepo ports.CollectionRepository
+	productRepo    ports.ProductRepository
+	linkService    ports.LinkService
+}
+
+func NewCollectionService(collectionRepo ports.CollectionRepository, productRepo ports.ProductRepository, linkService ports.LinkService) ports.CollectionService {
+	return &collectionService{collectionRepo: collectionRepo, productRepo: productRepo, linkService: linkService}
+}
+
+func (s *collectionService) CreateCollection(ctx context.Context, userId int64, collection dto.CollectionRequest) (dto.Response[domains.Collection], error) {
+	res, err := s.checkProducts(ctx, userId, collection.ProductIds)
+	if err != nil || !res.Success {
+		return dto.Response[domains.Collection]{
+			HttpCode: res.HttpCode,
+			Success:  false,
+			Code:     res.Code,
+			Message:  res.Message,
+		}, err
+	}
+	newCollection, err := s.collectionRepo.SaveCollection(ctx, domains.Collection{
+		Name:        collection.Name,
+		Description: collection.Description,
+		UserId:      userId,
+	})
+	if err != nil {
+		return dto.Response[domains.Collection]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     8001,
+			Message:  "Failed to create collection",
+		}, err
+	}
+	if len(collection.ProductIds) > 0 {
+		err = s.collectionRepo.ReplaceCollectionItems(ctx, newCollection.Id.String(), collection.ProductIds)
+		if err != nil {
+			return dto.Response[domains.Collection]{
+				HttpCode: http.StatusInternalServerError,
+				Success:  false,
+				Code:     8002,
+				Message:  "Failed to save collection products",
+			}, err
+		}
+	}
+	return s.GetCollectionById(ctx, userId, newCollection.Id.String())
+}
+
+func (s *collectionService) GetCollections(ctx context.Context, userId int64) (dto.Response[[]domains.Collection], error) {
+	collections, err := s.collectionRepo.GetCollectionsByUserId(ctx, userId)
+	if err != nil {
+		return dto.Response[[]domains.Collection]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     8003,
+			Message:  "Failed to fetch collections",
+		}, err
+	}
+	return dto.Response[[]domains.Collection]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Collections fetched successfully",
+		Data:     collections,
+	}, nil
+}
+
+func (s *collectionService) GetCollectionById(ctx context.Context, userId int64, collectionId string) (dto.Response[domains.Collection], error) {
+	collection, res, err := s.getOwnedCollection(ctx, userId, collectionId)
+	if err != nil || !res.Success {
+		return dto.Response[domains.Collection]{
+			HttpCode: res.HttpCode,
+			Success:  false,
+			Code:     res.Code,
+			Message:  res.Message,
+		}, err
+	}
+	return dto.Response[domains.Collection]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Collection fetched successfully",
+		Data:     collection,
+	}, nil
+}
+
+func (s *collectionService) UpdateCollection(ctx context.Context, userId int64, collectionId string, collection dto.CollectionRequest) (dto.Response[domains.Collection], error) {
+	existing, res, err := s.getOwnedCollection(ctx, userId, collectionId)
+	if err == nil && res.Success {
+		res, err = s.checkProducts(ctx, userId, collection.ProductIds)
+	}
+	if err != nil || !res.Success {
+		return dto.Response[domains.Collection]{
+			HttpCode: res.HttpCode,
+			Success:  false,
+			Code:     res.Code,
+			Message:  res.Message,
+		}, err
+	}
+	existing.Name = collection.Name
+	existing.Description = collection.Description
+	existing.Items = nil
+	_, err = s.collectionRepo.SaveCollection(ctx, existing)
+	if err != nil {
+		return dto.Response[domains.Collection]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     8006,
+			Message:  "Failed to update collection",
+		}, err
+	}
+	if collection.ProductIds != nil {
+		err = s.collectionRepo.ReplaceCollectionItems(ctx, collectionId, collection.ProductIds)
+		if err != nil {
+			return dto.Response[domains.Collection]{
+				HttpCode: http.StatusInternalServerError,
+				Success:  false,
+				Code:     8002,
+				Message:  "Failed to save collection products",
+			}, err
+		}
+	}
+	return s.GetCollectionById(ctx, userId, collectionId)
+}
+
+func (s *collectionService) DeleteCollection(ctx context.Context, userId int64, collectionId string) (dto.Response[any], error) {
+	_, res, err := s.getOwnedCollection(ctx, userId, collectionId)
+	if err != nil || !res.Success {
+		return res, err
+	}
+	err = s.collectionRepo.DeleteCollection(ctx, collectionId)
+	if err != nil {
+		return dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     8007,
+			Message:  "Failed to delete collection",
+		}, err
+	}
+	return dto.Response[any]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Collection deleted successfully",
+	}, nil
+}
+
+func (s *collectionService) AddCollectionProduct(ctx context.Context, userId int64, collectionId, productId string) (dto.Response[any], error) {
+	_, res, err := s.getOwnedCollection(ctx, userId, collectionId)
+	if err == nil && res.Success {
+		res, err = s.checkProducts(ctx, userId, []uuid.UUID{uuid.FromStringOrNil(productId)})
+	}
+	if err != nil || !res.Success {
+		return res, err
+	}
+	err = s.collectionRepo.AddCollectionItem(ctx, collectionId, productId)
+	if err != nil {
+		return dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     8002,
+			Message:  "Failed to save collection products",
+		}, err
+	}
+	return dto.Response[any]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Product added to collection successfully",
+	}, nil
+}
+
+func (s *collectionService) RemoveCollectionProduct(ctx context.Context, userId int64, collectionId, productId string) (dto.Response[any], error) {
+	_, res, err := s.getOwnedCollection(ctx, userId, collectionId)
+	if err != nil || !res.Success {
+		return res, err
+	}
+	err = s.collectionRepo.RemoveCollectionItem(ctx, collectionId, productId)
+	if err != nil {
+		return dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     8008,
+			Message:  "Failed to remove product from collection",
+		}, err
+	}
+	return dto.Response[any]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Product removed from collection successfully",
+	}, nil
+}
+
+func (s *collectionService) CreateCollectionLinks(ctx context.Context, userId int64, collectionId string, req dto.CreateCollectionLinksRequest) (dto.Response[dto.BulkLinkResponse], error) {
+	collection, res, err := s.getOwnedCollection(ctx, userId, collectionId)
+	if err != nil || !res.Success {
+		return dto.Response[dto.BulkLinkResponse]{
+			HttpCode: res.HttpCode,
+			Success:  false,
+			Code:     res.Code,
+			Message:  res.Message,
+		}, err
+	}
+	result := dto.BulkLinkResponse{
+		Links:    []domains.Link{},
+		Failures: []dto.LinkFailure{},
+	}
+	for _, item := range collection.Items {
+		linkRes, err := s.linkService.CreateLink(ctx, userId, dto.CreateLinkRequest{
+			ProductId:  item.ProductId,
+			CampaignId: req.CampaignId,
+		})
+		if err != nil || !linkRes.Success {
+			result.Failures = append(result.Failures, dto.LinkFailure{
+				ProductId: item.ProductId,
+				Code:      linkRes.Code,
+				Message:   linkRes.Message,
+			})
+			continue
+		}
+		result.Links = append(result.Links, linkRes.Data)
+	}
+	message := "Links created successfully"
+	if len(result.Failures) > 0 {
+		message = "Some links could not be created"
+	}
+	return dto.Response[dto.BulkLinkResponse]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  message,
+		Data:     result,
+	}, nil
+}
+
+func (s *collectionService) getOwnedCollection(ctx context.Context, userId int64, collectionId string) (domains.Collection, dto.Response[any], error) {
+	collection, err := s.collectionRepo.GetCollectionById(ctx, collectionId)
+	if err != nil {
+		return domains.Collection{}, dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     8004,
+			Message:  "Failed to fetch collection",
+		}, err
+	}
+	if collection.UserId != userId {
+		return domains.Collection{}, dto.Response[any]{
+			HttpCode: http.StatusForbidden,
+			Success:  false,
+			Code:     8005,
+			Message:  "You do not have access to this collection",
+		}, nil
+	}
+	return collection, dto.Response[any]{Success: true}, nil
+}
+
+// checkProducts makes sure every product exists and belongs to the user before it is put in a collection.
+func (s *collectionService) checkProducts(ctx context.Context, userId int64, productIds []uuid.UUID) (dto.Response[any], error) {
+	for _, productId := range productIds {
+		product, err := s.productRepo.GetProductById(ctx, productId.String())
+		if err != nil {
+			return dto.Response[any]{
+				HttpCode: http.StatusInternalServerError,
+				Success:  false,
+				Code:     8009,
+				Message:  "Failed to fetch product " + productId.String(),
+			}, err
+		}
+		if product.UserId != userId {
+			return dto.Response[any]{
+				HttpCode: http.StatusForbidden,
+				Success:  false,
+				Code:     8010,
+				Message:  "You do not have access to product " + productId.String(),
+			}, nil
+		}
+	}
+	return dto.Response[any]{Success: true}, nil
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateCollection_Success(t *testing.T) {
+	mockCollectionRepo := new(mocks.MockCollectionRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockLinkService := new(mocks.MockLinkService)
+
+	service := NewCollectionService(mockCollectionRepo, mockProductRepo, mockLinkService)
+
+	ctx := context.Background()
+	userId := int64(1)
+	collectionId := uuid.Must(uuid.NewV4())
+	productIds := []uuid.UUID{uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())}
+
+	collection := domains.Collection{
+		Id:     collectionId,
+		Name:   "11.11 picks",
+		UserId: userId,
+		Items: []domains.CollectionItem{
+			{CollectionId: collectionId, ProductId: productIds[0], Position: 0},
+			{CollectionId: collectionId, ProductId: productIds[1], Position: 1},
+		},
+	}
+
+	for _, productId := range productIds {
+		mockProductRepo.On("GetProductById", ctx, productId.String()).Return(domains.Product{Id: productId, UserId: userId}, nil)
+	}
+	mockCollectionRepo.On("SaveCollection", ctx, mock.MatchedBy(func(c domains.Collection) bool {
+		return c.Name == "11.11 picks" && c.UserId == userId
+	})).Return(domains.Collection{Id: collectionId, Name: "11.11 picks", UserId: userId}, nil)
+	mockCollectionRepo.On("ReplaceCollectionItems", ctx, collectionId.String(), productIds).Return(nil)
+	mockCollectionRepo.On("GetCollectionById", ctx, collectionId.String()).Return(collection, nil)
+
+	result, err := service.CreateCollection(ctx, userId, dto.CollectionRequest{Name: "11.11 picks", ProductIds: productIds})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, collection, result.Data)
+	mockProductRepo.AssertExpectations(t)
+	mockCollectionRepo.AssertExpectations(t)
+}
+
+func TestCreateCollection_ForeignProduct(t *testing.T) {
+	mockCollectionRepo := new(mocks.MockCollectionRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockLinkService := new(mocks.MockLinkService)
+
+	service := NewCollectionService(mockCollectionRepo, mockProductRepo, mockLinkService)
+
+	ctx := context.Background()
+	productId := uuid.Must(uuid.NewV4())
+
+	mockProductRepo.On("GetProductById", ctx, productId.String()).Return(domains.Product{Id: productId, UserId: int64(2)}, nil)
+
+	result, err := service.CreateCollection(ctx, int64(1), dto.CollectionRequest{Name: "mine", ProductIds: []uuid.UUID{productId}})
+
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 8010, result.Code)
+	mockCollectionRepo.AssertNotCalled(t, "SaveCollection", mock.Anything, mock.Anything)
+}
+
+func TestCreateCollectionLinks_ReportsFailures(t *testing.T) {
+	mockCollectionRepo := new(mocks.MockCollectionRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockLinkService := new(mocks.MockLinkService)
+
+	service := NewCollectionService(mockCollectionRepo, mockProductRepo, mockLinkService)
+
+	ctx := context.Background()
+	userId := int64(1)
+	collectionId := uuid.Must(uuid.NewV4())
+	campaignId := uuid.Must(uuid.NewV4())
+	okProductId := uuid.Must(uuid.NewV4())
+	failProductId := uuid.Must(uuid.NewV4())
+
+	collection := domains.Collection{
+		Id:     collectionId,
+		UserId: userId,
+		Items: []domains.CollectionItem{
+			{CollectionId: collectionId, ProductId: okProductId, Position: 0},
+			{CollectionId: collectionId, ProductId: failProductId, Position: 1},
+		},
+	}
+	link := domains.Link{Id: uuid.Must(uuid.NewV4()), ProductId: okProductId, CampaignId: campaignId, ShortCode: "abcd"}
+
+	mockCollectionRepo.On("GetCollectionById", ctx, collectionId.String()).Return(collection, nil)
+	mockLinkService.On("CreateLink", ctx, userId, dto.CreateLinkRequest{ProductId: okProductId, CampaignId: campaignId}).
+		Return(dto.Response[domains.Link]{Success: true, Data: link}, nil)
+	mockLinkService.On("CreateLink", ctx, userId, dto.CreateLinkRequest{ProductId: failProductId, CampaignId: campaignId}).
+		Return(dto.Response[domains.Link]{Success: false, Code: 4007, Message: "Failed to generate lazada affiliate link"}, assert.AnError)
+
+	result, err := service.CreateCollectionLinks(ctx, userId, collectionId.String(), dto.CreateCollectionLinksRequest{CampaignId: campaignId})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, []domains.Link{link}, result.Data.Links)
+	assert.Equal(t, 1, len(result.Data.Failures))
+	assert.Equal(t, failProductId, result.Data.Failures[0].ProductId)
+	assert.Equal(t, 4007, result.Data.Failures[0].Code)
+	mockLinkService.AssertExpectations(t)
+}
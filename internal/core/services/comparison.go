@@ -0,0 +1,37 @@
+package services
+
+import (
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/core/dto"
+)
+
+// comparisonRange is the period [start, end) is compared with: the period of the same length
+// just before it, or the same dates a month or a year earlier.
+func comparisonRange(compare string, start, end time.Time) (time.Time, time.Time) {
+	switch compare {
+	case dto.ComparePreviousMonth:
+		return addMonths(start, -1), addMonths(end, -1)
+	case dto.ComparePreviousYear:
+		return addMonths(start, -12), addMonths(end, -12)
+	default:
+		return start.Add(-end.Sub(start)), start
+	}
+}
+
+// addMonths moves t by months but keeps it in the target month, so a month before 31 March is
+// 28 February rather than 3 March.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return time.Date(first.Year(), first.Month(), min(t.Day(), lastDay), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+func compareMetric(current, previous int64) dto.MetricDelta {
+	delta := dto.MetricDelta{Current: current, Previous: previous, Delta: current - previous}
+	if previous > 0 {
+		ratio := float64(delta.Delta) / float64(previous)
+		delta.DeltaRatio = &ratio
+	}
+	return delta
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComparisonRange(t *testing.T) {
+	start := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 4, 7, 0, 0, 0, 0, time.UTC)
+
+	previousStart, previousEnd := comparisonRange(dto.ComparePreviousPeriod, start, end)
+	assert.Equal(t, time.Date(2026, 3, 24, 0, 0, 0, 0, time.UTC), previousStart)
+	assert.Equal(t, start, previousEnd)
+
+	// A month before 31 March is the end of February, not the beginning of March.
+	previousStart, previousEnd = comparisonRange(dto.ComparePreviousMonth, start, end)
+	assert.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), previousStart)
+	assert.Equal(t, time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC), previousEnd)
+
+	previousStart, previousEnd = comparisonRange(dto.ComparePreviousYear, time.Date(2028, 2, 29, 10, 0, 0, 0, time.UTC), end)
+	assert.Equal(t, time.Date(2027, 2, 28, 10, 0, 0, 0, time.UTC), previousStart)
+	assert.Equal(t, time.Date(2025, 4, 7, 0, 0, 0, 0, time.UTC), previousEnd)
+}
+
+func TestCompareMetric(t *testing.T) {
+	ratio := 0.25
+	assert.Equal(t, dto.MetricDelta{Current: 5, Previous: 4, Delta: 1, DeltaRatio: &ratio}, compareMetric(5, 4))
+	assert.Equal(t, dto.MetricDelta{Current: 3, Delta: 3}, compareMetric(3, 0))
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+// ConversionImporter pulls the conversion report of every marketplace credential and saves the
+// order items, attributed to the user's link whose short code is in their sub ids.
+type ConversionImporter struct {
+	credRepo       ports.MarketplaceRepository
+	conversionRepo ports.ConversionRepository
+	linkRepo       ports.LinkRepository
+	campaignRepo   ports.CampaignRepository
+	marketplaces   ports.MarketplaceRegistry
+	lookback       time.Duration
+	interval       time.Duration
+}
+
+// NewConversionImporter imports the orders of the last lookback on every run, since marketplaces
+// keep updating an order's status until its commission is settled.
+func NewConversionImporter(credRepo ports.MarketplaceRepository, conversionRepo ports.ConversionRepository, linkRepo ports.LinkRepository, campaignRepo ports.CampaignRepository, marketplaces ports.MarketplaceRegistry, lookback, interval time.Duration) *ConversionImporter {
+	return &ConversionImporter{credRepo: credRepo, conversionRepo: conversionRepo, linkRepo: linkRepo, campaignRepo: campaignRepo, marketplaces: marketplaces, lookback: lookback, interval: interval}
+}
+
+// Run imports immediately and then every interval until ctx is done.
+func (i *ConversionImporter) Run(ctx context.Context) {
+	runEvery(ctx, i.interval, "conversion importer", i.Tick)
+}
+
+// Tick imports the orders placed in the lookback before now for every credential. A credential
+// whose report fails does not stop the others; the failures are returned together.
+func (i *ConversionImporter) Tick(ctx context.Context, now time.Time) error {
+	credentials, err := i.credRepo.ListCredentials(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	imported := 0
+	for _, cred := range credentials {
+		count, err := i.importConversions(ctx, cred, now.Add(-i.lookback), now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s conversions of user %d: %w", cred.Marketplace, cred.UserId, err))
+			continue
+		}
+		imported += count
+	}
+	if imported > 0 {
+		log.Printf("Imported %d conversions from %d credentials\n", imported, len(credentials))
+	}
+	return errors.Join(errs...)
+}
+
+func (i *ConversionImporter) importConversions(ctx context.Context, cred domains.MarketplaceCredential, start, end time.Time) (int, error) {
+	provider, err := i.marketplaces.Get(cred.Marketplace)
+	if err != nil {
+		return 0, err
+	}
+	conversions, err := provider.FetchConversions(ctx, cred, start, end)
+	if err != nil {
+		return 0, err
+	}
+	links := map[string]*domains.Link{}
+	for n := range conversions {
+		conversions[n].UserId = cred.UserId
+		link := i.attributedLink(ctx, cred.UserId, conversions[n].SubIds, links)
+		if link != nil {
+			conversions[n].LinkId = uuid.NullUUID{UUID: link.Id, Valid: true}
+			conversions[n].CampaignId = uuid.NullUUID{UUID: link.CampaignId, Valid: true}
+			conversions[n].ProductId = uuid.NullUUID{UUID: link.ProductId, Valid: true}
+		}
+	}
+	err = i.conversionRepo.SaveConversions(ctx, conversions)
+	if err != nil {
+		return 0, err
+	}
+	return len(conversions), nil
+}
+
+// attributedLink returns the link of userId whose short code is the sub id at shortCodeSubId,
+// where affiliate urls made here carry it, or nil. Links and campaigns deleted since the order
+// still get it. Lookups are remembered in links, including misses, for the rest of the import.
+func (i *ConversionImporter) attributedLink(ctx context.Context, userId int64, subIds []string, links map[string]*domains.Link) *domains.Link {
+	if len(subIds) <= shortCodeSubId || subIds[shortCodeSubId] == "" {
+		return nil
+	}
+	shortCode := subIds[shortCodeSubId]
+	link, seen := links[shortCode]
+	if !seen {
+		link = i.ownedLink(ctx, userId, shortCode)
+		links[shortCode] = link
+	}
+	return link
+}
+
+// ownedLink looks shortCode up among the links of userId.
+func (i *ConversionImporter) ownedLink(ctx context.Context, userId int64, shortCode string) *domains.Link {
+	link, err := i.linkRepo.GetLinkByShortCodeWithDeleted(ctx, shortCode)
+	if err != nil {
+		return nil
+	}
+	campaign, err := i.campaignRepo.GetCampaignByIdWithDeleted(ctx, link.CampaignId.String())
+	if err != nil || campaign.UserId != userId {
+		return nil
+	}
+	return &link
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/fakemarket"
+	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestConversionImporterTick_FakeMarket(t *testing.T) {
+	fake := fakemarket.Start(fakemarket.DefaultFixtures())
+	defer fake.Close()
+
+	mockCredRepo := new(mocks.MockMarketplaceRepository)
+	mockConversionRepo := new(mocks.MockConversionRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	importer := NewConversionImporter(mockCredRepo, mockConversionRepo, mockLinkRepo, mockCampaignRepo, fakeMarketplaces(fake), 30*24*time.Hour, time.Hour)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	lazadaLink := domains.Link{Id: uuid.Must(uuid.NewV4()), CampaignId: campaignId, ProductId: uuid.Must(uuid.NewV4()), ShortCode: "lzd1001"}
+	shopeeLink := domains.Link{Id: uuid.Must(uuid.NewV4()), CampaignId: campaignId, ProductId: uuid.Must(uuid.NewV4()), ShortCode: "shp3001"}
+	mockCredRepo.On("ListCredentials", ctx).Return([]domains.MarketplaceCredential{
+		{UserId: 7, Marketplace: "lazada", AppKey: "key", AppSecret: "secret", UserToken: "token"},
+		{UserId: 7, Marketplace: "shopee", AppId: "app", AppSecret: "secret"},
+	}, nil)
+	mockLinkRepo.On("GetLinkByShortCodeWithDeleted", ctx, "lzd1001").Return(lazadaLink, nil)
+	mockLinkRepo.On("GetLinkByShortCodeWithDeleted", ctx, "shp3001").Return(shopeeLink, nil)
+	mockCampaignRepo.On("GetCampaignByIdWithDeleted", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 7}, nil)
+	var saved []domains.Conversion
+	mockConversionRepo.On("SaveConversions", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(1).([]domains.Conversion)...)
+	}).Return(nil)
+
+	err := importer.Tick(ctx, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Len(t, saved, 3)
+	assert.Equal(t, domains.Conversion{
+		UserId:      7,
+		Marketplace: "lazada",
+		OrderId:     "LZ-9001",
+		ItemId:      "LZ-9001-1",
+		Status:      domains.ConversionApproved,
+		SubIds:      []string{"summer_sale", "lzd1001", "", "", "", ""},
+		LinkId:      uuid.NullUUID{UUID: lazadaLink.Id, Valid: true},
+		CampaignId:  uuid.NullUUID{UUID: campaignId, Valid: true},
+		ProductId:   uuid.NullUUID{UUID: lazadaLink.ProductId, Valid: true},
+		Amount:      599,
+		Commission:  71.88,
+		Currency:    "THB",
+		OrderedAt:   time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC),
+	}, withUTCOrderTime(saved[0]))
+	assert.Equal(t, domains.ConversionCancelled, saved[1].Status)
+	assert.Equal(t, "shopee", saved[2].Marketplace)
+	assert.Equal(t, "3001-1", saved[2].ItemId)
+	assert.Equal(t, domains.ConversionPending, saved[2].Status)
+	assert.Equal(t, 51.8, saved[2].Commission)
+	assert.Equal(t, shopeeLink.Id, saved[2].LinkId.UUID)
+	// Short codes are only looked up once per import.
+	mockLinkRepo.AssertNumberOfCalls(t, "GetLinkByShortCodeWithDeleted", 2)
+}
+
+func TestConversionImporterTick_SkipsOtherUsersLinksAndFailedCredentials(t *testing.T) {
+	fake := fakemarket.Start(fakemarket.DefaultFixtures())
+	defer fake.Close()
+	fake.Fail(fakemarket.EndpointLazadaConversionReport, fakemarket.Failure{Code: "ApiCallLimit"})
+
+	mockCredRepo := new(mocks.MockMarketplaceRepository)
+	mockConversionRepo := new(mocks.MockConversionRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	importer := NewConversionImporter(mockCredRepo, mockConversionRepo, mockLinkRepo, mockCampaignRepo, fakeMarketplaces(fake), 30*24*time.Hour, time.Hour)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCredRepo.On("ListCredentials", ctx).Return([]domains.MarketplaceCredential{
+		{UserId: 7, Marketplace: "lazada", AppKey: "key", AppSecret: "secret", UserToken: "token"},
+		{UserId: 7, Marketplace: "shopee", AppId: "app", AppSecret: "secret"},
+	}, nil)
+	mockLinkRepo.On("GetLinkByShortCodeWithDeleted", ctx, "shp3001").Return(domains.Link{Id: uuid.Must(uuid.NewV4()), CampaignId: campaignId}, nil)
+	mockCampaignRepo.On("GetCampaignByIdWithDeleted", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 8}, nil)
+	mockConversionRepo.On("SaveConversions", ctx, mock.MatchedBy(func(conversions []domains.Conversion) bool {
+		return len(conversions) == 1 && conversions[0].OrderId == "SH-8001" && !conversions[0].LinkId.Valid
+	})).Return(nil)
+
+	err := importer.Tick(ctx, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+
+	assert.ErrorContains(t, err, "lazada conversions of user 7")
+	mockConversionRepo.AssertExpectations(t)
+}
+
+func TestConversionImporterTick_ReadsOnlyTheShortCodeSubId(t *testing.T) {
+	fixtures := fakemarket.DefaultFixtures()
+	fixtures.LazadaConversions = fixtures.LazadaConversions[:1]
+	// A short code of the user in another sub id is not this link's.
+	fixtures.LazadaConversions[0].Sub1 = "lzd1001"
+	fixtures.LazadaConversions[0].Sub2 = "other"
+	// "-" in the UtmCampaign does not shift the short code.
+	fixtures.ShopeeConversions[0].UtmContent = "summer-sale-2026-shp3001---"
+	fake := fakemarket.Start(fixtures)
+	defer fake.Close()
+
+	mockCredRepo := new(mocks.MockMarketplaceRepository)
+	mockConversionRepo := new(mocks.MockConversionRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	importer := NewConversionImporter(mockCredRepo, mockConversionRepo, mockLinkRepo, mockCampaignRepo, fakeMarketplaces(fake), 30*24*time.Hour, time.Hour)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	shopeeLink := domains.Link{Id: uuid.Must(uuid.NewV4()), CampaignId: campaignId, ProductId: uuid.Must(uuid.NewV4()), ShortCode: "shp3001"}
+	mockCredRepo.On("ListCredentials", ctx).Return([]domains.MarketplaceCredential{
+		{UserId: 7, Marketplace: "lazada", AppKey: "key", AppSecret: "secret", UserToken: "token"},
+		{UserId: 7, Marketplace: "shopee", AppId: "app", AppSecret: "secret"},
+	}, nil)
+	mockLinkRepo.On("GetLinkByShortCodeWithDeleted", ctx, "other").Return(domains.Link{}, gorm.ErrRecordNotFound)
+	mockLinkRepo.On("GetLinkByShortCodeWithDeleted", ctx, "shp3001").Return(shopeeLink, nil)
+	mockCampaignRepo.On("GetCampaignByIdWithDeleted", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 7}, nil)
+	var saved []domains.Conversion
+	mockConversionRepo.On("SaveConversions", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(1).([]domains.Conversion)...)
+	}).Return(nil)
+
+	err := importer.Tick(ctx, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Len(t, saved, 2)
+	assert.False(t, saved[0].LinkId.Valid)
+	assert.Equal(t, []string{"summer-sale-2026", "shp3001", "", "", ""}, saved[1].SubIds)
+	assert.Equal(t, shopeeLink.Id, saved[1].LinkId.UUID)
+	mockLinkRepo.AssertNotCalled(t, "GetLinkByShortCodeWithDeleted", ctx, "lzd1001")
+}
+
+func withUTCOrderTime(conversion domains.Conversion) domains.Conversion {
+	conversion.OrderedAt = conversion.OrderedAt.UTC()
+	return conversion
+}
@@ -2,33 +2,103 @@ package services
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/market-place-affiliate/api/internal/core/dto"
 	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/market-place-affiliate/api/pkg/customtime"
 )
 
+// defaultLeaderboardLimit is how many entries a leaderboard has unless the request asks for
+// another number.
+const defaultLeaderboardLimit = 10
+
+// defaultBreakdownLimit is how many values a breakdown lists unless the request asks for
+// another number. Languages and referrers can have a long tail.
+const defaultBreakdownLimit = 20
+
+// maxDashboardBuckets bounds the time series so an hourly view cannot span years.
+const maxDashboardBuckets = 1000
+
+// dashboardBucketLayouts formats the start of a bucket like bucketFormats in the click
+// repository, so zero-filled buckets line up with counted ones.
+var dashboardBucketLayouts = map[string]string{
+	"hour":  "2006-01-02T15:00",
+	"day":   time.DateOnly,
+	"week":  time.DateOnly,
+	"month": time.DateOnly,
+}
+
 type dashboardService struct {
-	clickRepo   ports.ClickRepository
-	productRepo ports.ProductRepository
+	clickRepo      ports.ClickRepository
+	conversionRepo ports.ConversionRepository
+	productRepo    ports.ProductRepository
+	location       *time.Location
 }
 
-func NewDashboardService(clickRepo ports.ClickRepository, productRepo ports.ProductRepository) ports.DashboardService {
-	return &dashboardService{clickRepo: clickRepo, productRepo: productRepo}
+// NewDashboardService buckets clicks and conversions in location unless a request asks for
+// another timezone.
+func NewDashboardService(clickRepo ports.ClickRepository, conversionRepo ports.ConversionRepository, productRepo ports.ProductRepository, location *time.Location) ports.DashboardService {
+	return &dashboardService{clickRepo: clickRepo, conversionRepo: conversionRepo, productRepo: productRepo, location: location}
 }
 
-func (s *dashboardService) GetDashboardMetrics(ctx context.Context, userId int64, startDate, endDate time.Time) (dto.Response[dto.DashboardMetricsResponse], error) {
-	metricts, err := s.clickRepo.CountClicksByDateRange(ctx, userId, startDate, endDate)
+func (s *dashboardService) GetDashboardMetrics(ctx context.Context, userId int64, query dto.DashboardMetricsRequest) (dto.Response[dto.DashboardMetricsResponse], error) {
+	granularity := query.Granularity
+	if granularity == "" {
+		granularity = "day"
+	}
+	startDate, endDate, location, res, err := s.dashboardRange(query.StartAt, query.EndAt, query.Timezone)
 	if err != nil {
 		return dto.Response[dto.DashboardMetricsResponse]{
-			HttpCode: http.StatusInternalServerError,
+			HttpCode: res.HttpCode,
 			Success:  false,
-			Code:     4001,
-			Message:  "Failed to count clicks by date range",
+			Code:     res.Code,
+			Message:  res.Message,
 		}, err
 	}
-	productId, clickCount, err := s.clickRepo.CountTopProductClickByDateRange(ctx, userId, startDate, endDate)
+	buckets := dashboardBuckets(startDate, endDate, granularity, location)
+	if buckets == nil {
+		return tooManyBuckets()
+	}
+
+	metricts, err := s.clickRepo.CountClicksByDateRange(ctx, userId, startDate, endDate, granularity, location.String())
+	if err != nil {
+		return failedClickCount(err)
+	}
+	conversions, err := s.conversionRepo.CountConversionsByDateRange(ctx, userId, startDate, endDate, granularity, location.String())
+	if err != nil {
+		return failedConversionCount(err)
+	}
+	series := metricSeriesOf(metricts, conversions)
+	var comparison *dto.DashboardComparison
+	if query.Compare != "" {
+		previousStart, previousEnd := comparisonRange(query.Compare, startDate, endDate)
+		previousBuckets := dashboardBuckets(previousStart, previousEnd, granularity, location)
+		if previousBuckets == nil {
+			return tooManyBuckets()
+		}
+		previous, err := s.clickRepo.CountClicksByDateRange(ctx, userId, previousStart, previousEnd, granularity, location.String())
+		if err != nil {
+			return failedClickCount(err)
+		}
+		previousConversions, err := s.conversionRepo.CountConversionsByDateRange(ctx, userId, previousStart, previousEnd, granularity, location.String())
+		if err != nil {
+			return failedConversionCount(err)
+		}
+		series = metricSeriesOf(metricts, conversions, previous, previousConversions)
+		comparison = &dto.DashboardComparison{
+			Compare: query.Compare,
+			StartAt: previousStart,
+			EndAt:   previousEnd,
+			Metrics: fillMetricBuckets(previousBuckets, series, previous, previousConversions),
+			Series:  compareSeries(metricts, previous, series),
+			Clicks:  compareMetric(totalClicks(metricts), totalClicks(previous)),
+		}
+	}
+	topProducts, err := s.clickRepo.GetClickLeaderboard(ctx, userId, dto.LeaderboardProducts, startDate, startDate, startDate, endDate, s.location.String(), "clicks", 1)
 	if err != nil {
 		return dto.Response[dto.DashboardMetricsResponse]{
 			HttpCode: http.StatusInternalServerError,
@@ -37,26 +107,428 @@ func (s *dashboardService) GetDashboardMetrics(ctx context.Context, userId int64
 			Message:  "Failed to get top product clicks by date range",
 		}, err
 	}
-	product, err := s.productRepo.GetProductById(ctx, productId.String())
+	var topProduct *dto.TopProduct
+	if len(topProducts) > 0 {
+		// The top product may have been deleted since; its clicks still count.
+		product, err := s.productRepo.GetProductByIdWithDeleted(ctx, topProducts[0].Key)
+		if err != nil {
+			return dto.Response[dto.DashboardMetricsResponse]{
+				HttpCode: http.StatusInternalServerError,
+				Success:  false,
+				Code:     4003,
+				Message:  "Failed to get product by id",
+			}, err
+		}
+		topProduct = &dto.TopProduct{
+			Product: product,
+			Clicks:  topProducts[0].Clicks,
+		}
+	}
+	return dto.Response[dto.DashboardMetricsResponse]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Data: dto.DashboardMetricsResponse{
+			Metrics:     fillMetricBuckets(buckets, series, metricts, conversions),
+			Performance: seriesPerformance(series, metricts, conversions),
+			TopProduct:  topProduct,
+			Granularity: granularity,
+			Timezone:    location.String(),
+			Comparison:  comparison,
+		},
+	}, nil
+}
+
+func tooManyBuckets() (dto.Response[dto.DashboardMetricsResponse], error) {
+	return dto.Response[dto.DashboardMetricsResponse]{
+		HttpCode: http.StatusBadRequest,
+		Success:  false,
+		Code:     4014,
+		Message:  "The range may hold at most 1000 buckets",
+	}, errors.New("too many dashboard buckets")
+}
+
+func failedClickCount(err error) (dto.Response[dto.DashboardMetricsResponse], error) {
+	return dto.Response[dto.DashboardMetricsResponse]{
+		HttpCode: http.StatusInternalServerError,
+		Success:  false,
+		Code:     4001,
+		Message:  "Failed to count clicks by date range",
+	}, err
+}
+
+func failedConversionCount(err error) (dto.Response[dto.DashboardMetricsResponse], error) {
+	return dto.Response[dto.DashboardMetricsResponse]{
+		HttpCode: http.StatusInternalServerError,
+		Success:  false,
+		Code:     4016,
+		Message:  "Failed to count conversions by date range",
+	}, err
+}
+
+// GetDashboardLeaderboards ranks the user's products, links, campaigns and marketplaces and
+// compares each with the comparison period.
+func (s *dashboardService) GetDashboardLeaderboards(ctx context.Context, userId int64, query dto.DashboardLeaderboardRequest) (dto.Response[dto.DashboardLeaderboardsResponse], error) {
+	startDate, endDate, location, res, err := s.dashboardRange(query.StartAt, query.EndAt, query.Timezone)
 	if err != nil {
-		return dto.Response[dto.DashboardMetricsResponse]{
-			HttpCode: http.StatusInternalServerError,
+		return dto.Response[dto.DashboardLeaderboardsResponse]{
+			HttpCode: res.HttpCode,
 			Success:  false,
-			Code:     4003,
-			Message:  "Failed to get product by id",
+			Code:     res.Code,
+			Message:  res.Message,
 		}, err
 	}
-	topProduct := dto.TopProduct{
-		Product: product,
-		Clicks:  clickCount,
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultLeaderboardLimit
 	}
-	return dto.Response[dto.DashboardMetricsResponse]{
+	sort := query.Sort
+	if sort == "" {
+		sort = "clicks"
+	}
+	compare := query.Compare
+	if compare == "" {
+		compare = dto.ComparePreviousPeriod
+	}
+	previousStart, previousEnd := comparisonRange(compare, startDate, endDate)
+
+	leaderboards := dto.DashboardLeaderboardsResponse{
+		StartAt:         startDate,
+		EndAt:           endDate,
+		Compare:         compare,
+		PreviousStartAt: previousStart,
+		PreviousEndAt:   previousEnd,
+		Timezone:        location.String(),
+		Sort:            sort,
+	}
+	boards := []struct {
+		dimension string
+		entries   *[]dto.LeaderboardEntry
+	}{
+		{dto.LeaderboardProducts, &leaderboards.Products},
+		{dto.LeaderboardLinks, &leaderboards.Links},
+		{dto.LeaderboardCampaigns, &leaderboards.Campaigns},
+		{dto.LeaderboardMarketplaces, &leaderboards.Marketplaces},
+	}
+	for _, board := range boards {
+		entries, err := s.clickRepo.GetClickLeaderboard(ctx, userId, board.dimension, previousStart, previousEnd, startDate, endDate, s.location.String(), sort, limit)
+		if err != nil {
+			return dto.Response[dto.DashboardLeaderboardsResponse]{
+				HttpCode: http.StatusInternalServerError,
+				Success:  false,
+				Code:     4015,
+				Message:  "Failed to get leaderboards",
+			}, err
+		}
+		*board.entries = rankLeaderboard(entries, sort)
+	}
+	return dto.Response[dto.DashboardLeaderboardsResponse]{
 		HttpCode: http.StatusOK,
 		Success:  true,
 		Code:     0,
-		Data: dto.DashboardMetricsResponse{
-			Metrics:    metricts,
-			TopProduct: topProduct,
+		Message:  "Leaderboards fetched successfully",
+		Data:     leaderboards,
+	}, nil
+}
+
+// GetDashboardBreakdown ranks the values of one dimension of the user's clicks, such as their
+// traffic source or device, and compares each with the comparison period.
+func (s *dashboardService) GetDashboardBreakdown(ctx context.Context, userId int64, query dto.DashboardBreakdownRequest) (dto.Response[dto.DashboardBreakdownResponse], error) {
+	startDate, endDate, location, res, err := s.dashboardRange(query.StartAt, query.EndAt, query.Timezone)
+	if err != nil {
+		return dto.Response[dto.DashboardBreakdownResponse]{
+			HttpCode: res.HttpCode,
+			Success:  false,
+			Code:     res.Code,
+			Message:  res.Message,
+		}, err
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultBreakdownLimit
+	}
+	sort := query.Sort
+	if sort == "" {
+		sort = "clicks"
+	}
+	compare := query.Compare
+	if compare == "" {
+		compare = dto.ComparePreviousPeriod
+	}
+	previousStart, previousEnd := comparisonRange(compare, startDate, endDate)
+
+	entries, err := s.clickRepo.GetClickBreakdown(ctx, userId, query.Dimension, previousStart, previousEnd, startDate, endDate, query.CampaignId, query.LinkId, sort, limit)
+	if err != nil {
+		return dto.Response[dto.DashboardBreakdownResponse]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     4019,
+			Message:  "Failed to get breakdown",
+		}, err
+	}
+	return dto.Response[dto.DashboardBreakdownResponse]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Breakdown fetched successfully",
+		Data: dto.DashboardBreakdownResponse{
+			Dimension:       query.Dimension,
+			StartAt:         startDate,
+			EndAt:           endDate,
+			Compare:         compare,
+			PreviousStartAt: previousStart,
+			PreviousEndAt:   previousEnd,
+			Timezone:        location.String(),
+			Sort:            sort,
+			Entries:         rankLeaderboard(entries, sort),
 		},
 	}, nil
 }
+
+// rankLeaderboard numbers the entries from 1 and works out the change of the sort metric since
+// the previous period.
+func rankLeaderboard(entries []dto.LeaderboardEntry, sort string) []dto.LeaderboardEntry {
+	ranked := []dto.LeaderboardEntry{}
+	for i, entry := range entries {
+		current, previous := entry.Clicks, entry.PreviousClicks
+		if sort == "unique_visitors" {
+			current, previous = entry.UniqueVisitors, entry.PreviousUniqueVisitors
+		}
+		delta := compareMetric(current, previous)
+		entry.Rank = i + 1
+		entry.Delta = delta.Delta
+		entry.DeltaRatio = delta.DeltaRatio
+		ranked = append(ranked, entry)
+	}
+	return ranked
+}
+
+// dashboardRange resolves the timezone and the [start, end) range of a dashboard query. The
+// range defaults to the last 7 days and today.
+func (s *dashboardService) dashboardRange(startAt, endAt, timezone string) (time.Time, time.Time, *time.Location, dto.Response[any], error) {
+	location := s.location
+	if timezone != "" {
+		// Local names the server's zone, which the database does not know.
+		loc, err := time.LoadLocation(timezone)
+		if err == nil && timezone == "Local" {
+			err = errors.New("unknown time zone Local")
+		}
+		if err != nil {
+			return time.Time{}, time.Time{}, nil, dto.Response[any]{
+				HttpCode: http.StatusBadRequest,
+				Success:  false,
+				Code:     4013,
+				Message:  "tz must be an IANA time zone such as Asia/Bangkok",
+			}, err
+		}
+		location = loc
+	}
+	invalidRange := dto.Response[any]{
+		HttpCode: http.StatusBadRequest,
+		Success:  false,
+		Code:     4014,
+		Message:  "start_at and end_at must be YYYY-MM-DD or RFC 3339, and end_at after start_at",
+	}
+
+	today := startOfDay(customtime.Now(), location)
+	startDate := today.AddDate(0, 0, -7)
+	endDate := today.AddDate(0, 0, 1)
+	var err error
+	if startAt != "" {
+		if startDate, err = parseDashboardTime(startAt, location); err != nil {
+			return time.Time{}, time.Time{}, nil, invalidRange, err
+		}
+	}
+	if endAt != "" {
+		if endDate, err = parseDashboardTime(endAt, location); err != nil {
+			return time.Time{}, time.Time{}, nil, invalidRange, err
+		}
+	}
+	if !endDate.After(startDate) {
+		return time.Time{}, time.Time{}, nil, invalidRange, errors.New("dashboard end_at not after start_at")
+	}
+	return startDate, endDate, location, dto.Response[any]{Success: true}, nil
+}
+
+// parseDashboardTime reads a date as midnight in loc, or an RFC 3339 time.
+func parseDashboardTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, value, loc); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// dashboardBuckets lists the labels of the buckets overlapping [start, end), or nil when there
+// are more than maxDashboardBuckets.
+func dashboardBuckets(start, end time.Time, granularity string, loc *time.Location) []string {
+	buckets := []string{}
+	for bucket := bucketStart(start, granularity, loc); bucket.Before(end); bucket = nextBucket(bucket, granularity) {
+		if len(buckets) == maxDashboardBuckets {
+			return nil
+		}
+		buckets = append(buckets, bucket.Format(dashboardBucketLayouts[granularity]))
+	}
+	return buckets
+}
+
+// bucketStart truncates t like Postgres date_trunc in loc; weeks start on Monday.
+func bucketStart(t time.Time, granularity string, loc *time.Location) time.Time {
+	t = t.In(loc)
+	switch granularity {
+	case "hour":
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+	case "week":
+		day := startOfDay(t, loc)
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	default:
+		return startOfDay(t, loc)
+	}
+}
+
+func nextBucket(t time.Time, granularity string) time.Time {
+	switch granularity {
+	case "hour":
+		return t.Add(time.Hour)
+	case "week":
+		return t.AddDate(0, 0, 7)
+	case "month":
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// metricSeries identifies one line of the dashboard chart.
+type metricSeries struct {
+	CampaignId  uuid.UUID
+	Marketplace string
+	Currency    string
+}
+
+// metricSeriesOf lists every series with clicks or conversions in any of the metrics, in the
+// order they first appear.
+func metricSeriesOf(metrics ...[]dto.MetrictItem) []dto.MetrictItem {
+	series := []dto.MetrictItem{}
+	seen := map[metricSeries]bool{}
+	for _, items := range metrics {
+		for _, item := range items {
+			key := seriesKey(item)
+			if !seen[key] {
+				seen[key] = true
+				series = append(series, item)
+			}
+		}
+	}
+	return series
+}
+
+func seriesKey(item dto.MetrictItem) metricSeries {
+	return metricSeries{item.CampaignId, item.Marketplace, item.Currency}
+}
+
+// fillMetricBuckets returns an item for every bucket of every series, ordered by bucket, adding
+// up the clicks and conversions of all metrics. Buckets a series has nothing in are zero.
+func fillMetricBuckets(buckets []string, series []dto.MetrictItem, metrics ...[]dto.MetrictItem) []dto.MetrictItem {
+	counts := map[metricSeries]map[string]dto.MetrictItem{}
+	for _, items := range metrics {
+		for _, metric := range items {
+			key := seriesKey(metric)
+			if counts[key] == nil {
+				counts[key] = map[string]dto.MetrictItem{}
+			}
+			count := counts[key][metric.Date]
+			count.ClickCount += metric.ClickCount
+			count.Conversions += metric.Conversions
+			count.Sales += metric.Sales
+			count.Revenue += metric.Revenue
+			counts[key][metric.Date] = count
+		}
+	}
+	filled := []dto.MetrictItem{}
+	for _, bucket := range buckets {
+		for _, item := range series {
+			count := counts[seriesKey(item)][bucket]
+			item.Date = bucket
+			item.ClickCount = count.ClickCount
+			item.Conversions = count.Conversions
+			item.Sales = count.Sales
+			item.Revenue = count.Revenue
+			filled = append(filled, item)
+		}
+	}
+	return filled
+}
+
+// seriesPerformance sums the clicks and conversions of each series over the whole range and
+// works out its conversion rate and earnings per click.
+func seriesPerformance(series []dto.MetrictItem, metrics ...[]dto.MetrictItem) []dto.SeriesPerformance {
+	totals := map[metricSeries]*dto.SeriesPerformance{}
+	performance := []dto.SeriesPerformance{}
+	for _, item := range series {
+		totals[seriesKey(item)] = &dto.SeriesPerformance{
+			CampaignId:   item.CampaignId,
+			CampaignName: item.CampaignName,
+			Marketplace:  item.Marketplace,
+			Currency:     item.Currency,
+		}
+	}
+	for _, items := range metrics {
+		for _, metric := range items {
+			total, ok := totals[seriesKey(metric)]
+			if !ok {
+				continue
+			}
+			total.Clicks += int64(metric.ClickCount)
+			total.Conversions += int64(metric.Conversions)
+			total.Sales += metric.Sales
+			total.Revenue += metric.Revenue
+		}
+	}
+	for _, item := range series {
+		total := *totals[seriesKey(item)]
+		if total.Clicks > 0 {
+			conversionRate := float64(total.Conversions) / float64(total.Clicks)
+			epc := total.Revenue / float64(total.Clicks)
+			total.ConversionRate = &conversionRate
+			total.Epc = &epc
+		}
+		performance = append(performance, total)
+	}
+	return performance
+}
+
+// compareSeries compares the clicks of each series over the whole range.
+func compareSeries(metrics, previous []dto.MetrictItem, series []dto.MetrictItem) []dto.SeriesComparison {
+	current := seriesClicks(metrics)
+	before := seriesClicks(previous)
+	compared := []dto.SeriesComparison{}
+	for _, item := range series {
+		key := seriesKey(item)
+		compared = append(compared, dto.SeriesComparison{
+			CampaignId:   item.CampaignId,
+			CampaignName: item.CampaignName,
+			Marketplace:  item.Marketplace,
+			Currency:     item.Currency,
+			Clicks:       compareMetric(current[key], before[key]),
+		})
+	}
+	return compared
+}
+
+func seriesClicks(metrics []dto.MetrictItem) map[metricSeries]int64 {
+	clicks := map[metricSeries]int64{}
+	for _, metric := range metrics {
+		clicks[seriesKey(metric)] += int64(metric.ClickCount)
+	}
+	return clicks
+}
+
+func totalClicks(metrics []dto.MetrictItem) int64 {
+	var total int64
+	for _, metric := range metrics {
+		total += int64(metric.ClickCount)
+	}
+	return total
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/pkg/export"
+)
+
+// ExportDashboardMetrics writes the dashboard of query to w: a Metrics sheet with the buckets, a
+// Performance sheet with the totals of each series, and the buckets of the comparison period
+// in a Comparison sheet when query asks for one.
+func (s *dashboardService) ExportDashboardMetrics(ctx context.Context, userId int64, query dto.DashboardMetricsRequest, w export.Writer) (dto.Response[any], error) {
+	res, err := s.GetDashboardMetrics(ctx, userId, query)
+	if err != nil {
+		return dto.Response[any]{
+			HttpCode: res.HttpCode,
+			Success:  false,
+			Code:     res.Code,
+			Message:  res.Message,
+		}, err
+	}
+	dashboard := res.Data
+	err = writeMetrics(w, "Metrics", dashboard.Metrics)
+	if err != nil {
+		return failedExport(4017, err)
+	}
+	performance := dashboard.Performance
+	err = writeTable(w, "Performance", []any{"campaign_id", "campaign_name", "marketplace", "currency", "clicks", "conversions", "sales", "revenue", "conversion_rate", "epc"}, len(performance), func(n int) []any {
+		series := performance[n]
+		return []any{exportedId(series.CampaignId), series.CampaignName, series.Marketplace, series.Currency, series.Clicks, series.Conversions, series.Sales, series.Revenue, series.ConversionRate, series.Epc}
+	})
+	if err != nil {
+		return failedExport(4017, err)
+	}
+	if dashboard.Comparison != nil {
+		err = writeMetrics(w, "Comparison", dashboard.Comparison.Metrics)
+		if err != nil {
+			return failedExport(4017, err)
+		}
+	}
+	return exported()
+}
+
+func writeMetrics(w export.Writer, sheet string, metrics []dto.MetrictItem) error {
+	return writeTable(w, sheet, []any{"date", "campaign_id", "campaign_name", "marketplace", "currency", "clicks", "conversions", "sales", "revenue"}, len(metrics), func(n int) []any {
+		item := metrics[n]
+		return []any{item.Date, exportedId(item.CampaignId), item.CampaignName, item.Marketplace, item.Currency, item.ClickCount, item.Conversions, item.Sales, item.Revenue}
+	})
+}
+
+// ExportClickLog writes every click of query to w as it is read from the database, with its
+// time in the query timezone.
+func (s *dashboardService) ExportClickLog(ctx context.Context, userId int64, query dto.ClickLogRequest, w export.Writer) (dto.Response[any], error) {
+	startDate, endDate, location, res, err := s.dashboardRange(query.StartAt, query.EndAt, query.Timezone)
+	if err != nil {
+		return res, err
+	}
+	err = writeTable(w, "Clicks", []any{"click_id", "clicked_at", "link_id", "short_code", "campaign_id", "campaign_name", "product_id", "product_title", "marketplace", "visitor_id", "referrer_domain", "device", "os", "browser", "language"}, 0, nil)
+	if err != nil {
+		return failedExport(4018, err)
+	}
+	err = s.clickRepo.StreamClicks(ctx, userId, startDate, endDate, query.CampaignId, query.LinkId, func(click dto.ClickLogEntry) error {
+		return w.WriteRow(domains.Click{Id: click.ClickId}.ClickId(), click.CreatedAt.In(location), click.LinkId, click.ShortCode, click.CampaignId, click.CampaignName, click.ProductId, click.ProductTitle, click.Marketplace, click.VisitorId, click.ReferrerDomain, click.Device, click.Os, click.Browser, click.Language)
+	})
+	if err != nil {
+		return failedExport(4018, err)
+	}
+	return exported()
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/market-place-affiliate/api/pkg/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportDashboardMetrics_CSV(t *testing.T) {
+	mockClickRepo := new(mocks.MockClickRepository)
+	mockConversionRepo := new(mocks.MockConversionRepository)
+	service := NewDashboardService(mockClickRepo, mockConversionRepo, new(mocks.MockProductRepository), time.UTC)
+
+	ctx := context.Background()
+	startDate := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	campaignId := uuid.Must(uuid.FromString("0195a1b2-0000-7000-8000-000000000001"))
+	mockClickRepo.On("CountClicksByDateRange", ctx, int64(1), startDate, endDate, "day", "UTC").Return([]dto.MetrictItem{
+		{Date: "2026-03-02", ClickCount: 10, CampaignId: campaignId, CampaignName: "Summer, sale", Marketplace: "lazada", Currency: "THB"},
+	}, nil)
+	mockConversionRepo.On("CountConversionsByDateRange", ctx, int64(1), startDate, endDate, "day", "UTC").Return([]dto.MetrictItem{
+		{Date: "2026-03-02", Conversions: 2, Sales: 1198, Revenue: 143.76, CampaignId: campaignId, CampaignName: "Summer, sale", Marketplace: "lazada", Currency: "THB"},
+	}, nil)
+	mockClickRepo.On("GetClickLeaderboard", ctx, int64(1), dto.LeaderboardProducts, startDate, startDate, startDate, endDate, "UTC", "clicks", 1).Return([]dto.LeaderboardEntry{}, nil)
+
+	var out bytes.Buffer
+	w, _ := export.NewWriter(export.FormatCSV, &out)
+	result, err := service.ExportDashboardMetrics(ctx, 1, dto.DashboardMetricsRequest{StartAt: "2026-03-01", EndAt: "2026-03-03"}, w)
+	assert.NoError(t, w.Close())
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "date,campaign_id,campaign_name,marketplace,currency,clicks,conversions,sales,revenue\n"+
+		"2026-03-01,0195a1b2-0000-7000-8000-000000000001,\"Summer, sale\",lazada,THB,0,0,0,0\n"+
+		"2026-03-02,0195a1b2-0000-7000-8000-000000000001,\"Summer, sale\",lazada,THB,10,2,1198,143.76\n"+
+		"\n"+
+		"campaign_id,campaign_name,marketplace,currency,clicks,conversions,sales,revenue,conversion_rate,epc\n"+
+		"0195a1b2-0000-7000-8000-000000000001,\"Summer, sale\",lazada,THB,10,2,1198,143.76,0.2,14.376\n", out.String())
+}
+
+func TestExportDashboardMetrics_InvalidRangeWritesNothing(t *testing.T) {
+	service := NewDashboardService(new(mocks.MockClickRepository), new(mocks.MockConversionRepository), new(mocks.MockProductRepository), time.UTC)
+
+	var out bytes.Buffer
+	w, _ := export.NewWriter(export.FormatXLSX, &out)
+	result, err := service.ExportDashboardMetrics(context.Background(), 1, dto.DashboardMetricsRequest{StartAt: "2026-03-03", EndAt: "2026-03-01"}, w)
+
+	assert.Error(t, err)
+	assert.Equal(t, 4014, result.Code)
+	assert.Zero(t, out.Len())
+}
+
+func TestExportClickLog_XLSX(t *testing.T) {
+	mockClickRepo := new(mocks.MockClickRepository)
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	service := NewDashboardService(mockClickRepo, new(mocks.MockConversionRepository), new(mocks.MockProductRepository), bangkok)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	clickId := uuid.Must(uuid.FromString("0195a1b2-0000-7000-8000-00000000000c"))
+	mockClickRepo.On("StreamClicks", ctx, int64(1), time.Date(2026, 3, 1, 0, 0, 0, 0, bangkok), time.Date(2026, 3, 2, 0, 0, 0, 0, bangkok), campaignId.String(), "", mock.Anything).Return([]dto.ClickLogEntry{
+		{ClickId: clickId, CreatedAt: time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC), ShortCode: "abc123", CampaignId: campaignId, ProductTitle: "Tom & Jerry <mug>", Marketplace: "shopee", ReferrerDomain: "m.facebook.com", Device: "mobile"},
+	}, nil)
+
+	var out bytes.Buffer
+	w, _ := export.NewWriter(export.FormatXLSX, &out)
+	result, err := service.ExportClickLog(ctx, 1, dto.ClickLogRequest{StartAt: "2026-03-01", EndAt: "2026-03-02", CampaignId: campaignId.String()}, w)
+	assert.NoError(t, w.Close())
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	workbook, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	assert.NoError(t, err)
+	sheet, err := workbook.Open("xl/worksheets/sheet1.xml")
+	assert.NoError(t, err)
+	var content bytes.Buffer
+	_, _ = content.ReadFrom(sheet)
+	assert.Contains(t, content.String(), "<t xml:space=\"preserve\">0195a1b200007000800000000000000c</t>")
+	assert.Contains(t, content.String(), "2026-03-01T10:00:00+07:00")
+	assert.Contains(t, content.String(), "Tom &amp; Jerry &lt;mug&gt;")
+	assert.Contains(t, content.String(), "m.facebook.com")
+}
+
+func TestExportClickLog_StreamFailure(t *testing.T) {
+	mockClickRepo := new(mocks.MockClickRepository)
+	service := NewDashboardService(mockClickRepo, new(mocks.MockConversionRepository), new(mocks.MockProductRepository), time.UTC)
+	mockClickRepo.On("StreamClicks", mock.Anything, int64(1), mock.Anything, mock.Anything, "", "", mock.Anything).Return(nil, errors.New("connection reset"))
+
+	var out bytes.Buffer
+	w, _ := export.NewWriter(export.FormatCSV, &out)
+	result, err := service.ExportClickLog(context.Background(), 1, dto.ClickLogRequest{}, w)
+
+	assert.Error(t, err)
+	assert.Equal(t, 4018, result.Code)
+}
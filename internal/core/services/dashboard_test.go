@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -9,26 +10,31 @@ import (
 	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/market-place-affiliate/api/internal/core/dto"
 	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/market-place-affiliate/api/pkg/customtime"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetDashboardMetrics_Success(t *testing.T) {
 	mockClickRepo := new(mocks.MockClickRepository)
+	mockConversionRepo := new(mocks.MockConversionRepository)
 	mockProductRepo := new(mocks.MockProductRepository)
 
-	service := NewDashboardService(mockClickRepo, mockProductRepo)
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	service := NewDashboardService(mockClickRepo, mockConversionRepo, mockProductRepo, bangkok)
 
 	ctx := context.Background()
 	userId := int64(1)
-	startDate := time.Now().AddDate(0, 0, -7)
-	endDate := time.Now()
+	startDate := time.Date(2026, 3, 1, 0, 0, 0, 0, bangkok)
+	endDate := time.Date(2026, 3, 3, 0, 0, 0, 0, bangkok)
 	productId := uuid.Must(uuid.NewV4())
+	campaignId := uuid.Must(uuid.NewV4())
 
 	metrics := []dto.MetrictItem{
 		{
-			Date:         time.Now().Format("2006-01-02"),
+			Date:         "2026-03-02",
 			ClickCount:   10,
-			CampaignId:   uuid.Must(uuid.NewV4()),
+			CampaignId:   campaignId,
 			CampaignName: "Test Campaign",
 			Marketplace:  "lazada",
 		},
@@ -41,18 +47,267 @@ func TestGetDashboardMetrics_Success(t *testing.T) {
 		UserId:   userId,
 	}
 
-	mockClickRepo.On("CountClicksByDateRange", ctx, userId, startDate, endDate).Return(metrics, nil)
-	mockClickRepo.On("CountTopProductClickByDateRange", ctx, userId, startDate, endDate).Return(productId, int64(100), nil)
-	mockProductRepo.On("GetProductById", ctx, productId.String()).Return(product, nil)
+	mockClickRepo.On("CountClicksByDateRange", ctx, userId, startDate, endDate, "day", "Asia/Bangkok").Return(metrics, nil)
+	mockConversionRepo.On("CountConversionsByDateRange", ctx, userId, startDate, endDate, "day", "Asia/Bangkok").Return([]dto.MetrictItem{
+		{Date: "2026-03-02", Conversions: 2, Sales: 1198, Revenue: 143.76, CampaignId: campaignId, CampaignName: "Test Campaign", Marketplace: "lazada"},
+		{Date: "2026-03-01", Conversions: 1, Sales: 259, Revenue: 25.9, Marketplace: "shopee"},
+	}, nil)
+	mockClickRepo.On("GetClickLeaderboard", ctx, userId, dto.LeaderboardProducts, startDate, startDate, startDate, endDate, "Asia/Bangkok", "clicks", 1).Return([]dto.LeaderboardEntry{
+		{Key: productId.String(), Label: "Test Product", Clicks: 100},
+	}, nil)
+	mockProductRepo.On("GetProductByIdWithDeleted", ctx, productId.String()).Return(product, nil)
 
-	result, err := service.GetDashboardMetrics(ctx, userId, startDate, endDate)
+	result, err := service.GetDashboardMetrics(ctx, userId, dto.DashboardMetricsRequest{StartAt: "2026-03-01", EndAt: "2026-03-03"})
 
 	assert.NoError(t, err)
 	assert.True(t, result.Success)
 	assert.Equal(t, 0, result.Code)
-	assert.Equal(t, metrics, result.Data.Metrics)
+	// The unattributed shopee order gets a series of its own under the nil campaign.
+	assert.Equal(t, []dto.MetrictItem{
+		{Date: "2026-03-01", ClickCount: 0, CampaignId: campaignId, CampaignName: "Test Campaign", Marketplace: "lazada"},
+		{Date: "2026-03-01", Conversions: 1, Sales: 259, Revenue: 25.9, Marketplace: "shopee"},
+		{Date: "2026-03-02", ClickCount: 10, Conversions: 2, Sales: 1198, Revenue: 143.76, CampaignId: campaignId, CampaignName: "Test Campaign", Marketplace: "lazada"},
+		{Date: "2026-03-02", Marketplace: "shopee"},
+	}, result.Data.Metrics)
+	conversionRate, epc := 0.2, 14.376
+	assert.Equal(t, []dto.SeriesPerformance{
+		{CampaignId: campaignId, CampaignName: "Test Campaign", Marketplace: "lazada", Clicks: 10, Conversions: 2, Sales: 1198, Revenue: 143.76, ConversionRate: &conversionRate, Epc: &epc},
+		{Marketplace: "shopee", Conversions: 1, Sales: 259, Revenue: 25.9},
+	}, result.Data.Performance)
+	assert.Equal(t, "day", result.Data.Granularity)
+	assert.Equal(t, "Asia/Bangkok", result.Data.Timezone)
 	assert.Equal(t, product, result.Data.TopProduct.Product)
 	assert.Equal(t, int64(100), result.Data.TopProduct.Clicks)
 	mockClickRepo.AssertExpectations(t)
 	mockProductRepo.AssertExpectations(t)
 }
+
+func TestGetDashboardMetrics_HourlyInRequestedTimezone(t *testing.T) {
+	mockClickRepo := new(mocks.MockClickRepository)
+	mockConversionRepo := new(mocks.MockConversionRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	service := NewDashboardService(mockClickRepo, mockConversionRepo, mockProductRepo, time.UTC)
+
+	defer func(now func() time.Time) { customtime.Now = now }(customtime.Now)
+	customtime.Now = func() time.Time { return time.Date(2026, 11, 11, 1, 30, 0, 0, time.UTC) }
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockClickRepo.On("CountClicksByDateRange", ctx, int64(1), mock.Anything, mock.Anything, "hour", "Asia/Bangkok").Return([]dto.MetrictItem{
+		{Date: "2026-11-11T01:00", ClickCount: 4, CampaignId: campaignId, Marketplace: "shopee"},
+	}, nil)
+	mockConversionRepo.On("CountConversionsByDateRange", ctx, int64(1), mock.Anything, mock.Anything, "hour", "Asia/Bangkok").Return([]dto.MetrictItem{}, nil)
+	mockClickRepo.On("GetClickLeaderboard", ctx, int64(1), dto.LeaderboardProducts, mock.Anything, mock.Anything, mock.Anything, mock.Anything, "UTC", "clicks", 1).Return([]dto.LeaderboardEntry{}, nil)
+
+	result, err := service.GetDashboardMetrics(ctx, 1, dto.DashboardMetricsRequest{
+		StartAt:     "2026-11-11T00:00:00+07:00",
+		EndAt:       "2026-11-11T03:00:00+07:00",
+		Granularity: "hour",
+		Timezone:    "Asia/Bangkok",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2026-11-11T00:00", "2026-11-11T01:00", "2026-11-11T02:00"}, []string{
+		result.Data.Metrics[0].Date, result.Data.Metrics[1].Date, result.Data.Metrics[2].Date,
+	})
+	assert.Equal(t, []int{0, 4, 0}, []int{
+		result.Data.Metrics[0].ClickCount, result.Data.Metrics[1].ClickCount, result.Data.Metrics[2].ClickCount,
+	})
+	// Without clicks there is no top product to look up.
+	assert.Nil(t, result.Data.TopProduct)
+	mockProductRepo.AssertNotCalled(t, "GetProductByIdWithDeleted", mock.Anything, mock.Anything)
+}
+
+func TestGetDashboardMetrics_InvalidQuery(t *testing.T) {
+	mockClickRepo := new(mocks.MockClickRepository)
+	mockConversionRepo := new(mocks.MockConversionRepository)
+	service := NewDashboardService(mockClickRepo, mockConversionRepo, new(mocks.MockProductRepository), time.UTC)
+	ctx := context.Background()
+
+	result, err := service.GetDashboardMetrics(ctx, 1, dto.DashboardMetricsRequest{Timezone: "Mars/Olympus"})
+	assert.Error(t, err)
+	assert.Equal(t, 400, result.HttpCode)
+	assert.Equal(t, 4013, result.Code)
+
+	result, err = service.GetDashboardMetrics(ctx, 1, dto.DashboardMetricsRequest{StartAt: "2026-03-03", EndAt: "2026-03-01"})
+	assert.Error(t, err)
+	assert.Equal(t, 4014, result.Code)
+
+	result, err = service.GetDashboardMetrics(ctx, 1, dto.DashboardMetricsRequest{StartAt: "2025-01-01", EndAt: "2026-01-01", Granularity: "hour"})
+	assert.Error(t, err)
+	assert.Equal(t, 4014, result.Code)
+	mockClickRepo.AssertNotCalled(t, "CountClicksByDateRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDashboardBuckets_WeeksStartOnMonday(t *testing.T) {
+	start := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC) // a Wednesday
+	end := time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, []string{"2026-03-02", "2026-03-09", "2026-03-16"}, dashboardBuckets(start, end, "week", time.UTC))
+	assert.Equal(t, []string{"2026-03-01"}, dashboardBuckets(start, end, "month", time.UTC))
+}
+
+func TestGetDashboardLeaderboards(t *testing.T) {
+	mockClickRepo := new(mocks.MockClickRepository)
+	mockConversionRepo := new(mocks.MockConversionRepository)
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	service := NewDashboardService(mockClickRepo, mockConversionRepo, new(mocks.MockProductRepository), bangkok)
+
+	ctx := context.Background()
+	start := time.Date(2026, 3, 8, 0, 0, 0, 0, bangkok)
+	end := time.Date(2026, 3, 15, 0, 0, 0, 0, bangkok)
+	previousStart := time.Date(2026, 3, 1, 0, 0, 0, 0, bangkok)
+	mockClickRepo.On("GetClickLeaderboard", ctx, int64(1), dto.LeaderboardProducts, previousStart, start, start, end, "Asia/Bangkok", "unique_visitors", 3).Return([]dto.LeaderboardEntry{
+		{Key: "p1", Label: "Earbuds", Clicks: 40, UniqueVisitors: 30, PreviousClicks: 10, PreviousUniqueVisitors: 20},
+		{Key: "p2", Label: "Charger", Clicks: 12, UniqueVisitors: 9},
+	}, nil)
+	mockClickRepo.On("GetClickLeaderboard", ctx, int64(1), mock.Anything, previousStart, start, start, end, "Asia/Bangkok", "unique_visitors", 3).Return([]dto.LeaderboardEntry{}, nil)
+
+	result, err := service.GetDashboardLeaderboards(ctx, 1, dto.DashboardLeaderboardRequest{StartAt: "2026-03-08", EndAt: "2026-03-15", Limit: 3, Sort: "unique_visitors"})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, previousStart, result.Data.PreviousStartAt)
+	assert.Len(t, result.Data.Products, 2)
+	assert.Equal(t, 1, result.Data.Products[0].Rank)
+	assert.Equal(t, int64(10), result.Data.Products[0].Delta)
+	assert.Equal(t, 0.5, *result.Data.Products[0].DeltaRatio)
+	assert.Equal(t, 2, result.Data.Products[1].Rank)
+	assert.Equal(t, int64(9), result.Data.Products[1].Delta)
+	assert.Nil(t, result.Data.Products[1].DeltaRatio)
+	assert.Equal(t, []dto.LeaderboardEntry{}, result.Data.Links)
+	assert.Equal(t, []dto.LeaderboardEntry{}, result.Data.Campaigns)
+	assert.Equal(t, []dto.LeaderboardEntry{}, result.Data.Marketplaces)
+}
+
+func TestGetDashboardLeaderboards_Defaults(t *testing.T) {
+	mockClickRepo := new(mocks.MockClickRepository)
+	mockConversionRepo := new(mocks.MockConversionRepository)
+	service := NewDashboardService(mockClickRepo, mockConversionRepo, new(mocks.MockProductRepository), time.UTC)
+
+	defer func(now func() time.Time) { customtime.Now = now }(customtime.Now)
+	customtime.Now = func() time.Time { return time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC) }
+
+	ctx := context.Background()
+	mockClickRepo.On("GetClickLeaderboard", ctx, int64(1), mock.Anything,
+		time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC),
+		"UTC", "clicks", 10).Return([]dto.LeaderboardEntry{}, nil)
+
+	result, err := service.GetDashboardLeaderboards(ctx, 1, dto.DashboardLeaderboardRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "clicks", result.Data.Sort)
+	assert.Equal(t, dto.ComparePreviousPeriod, result.Data.Compare)
+	mockClickRepo.AssertNumberOfCalls(t, "GetClickLeaderboard", 4)
+}
+
+func TestGetDashboardLeaderboards_SamePeriodLastYear(t *testing.T) {
+	mockClickRepo := new(mocks.MockClickRepository)
+	mockConversionRepo := new(mocks.MockConversionRepository)
+	service := NewDashboardService(mockClickRepo, mockConversionRepo, new(mocks.MockProductRepository), time.UTC)
+
+	ctx := context.Background()
+	mockClickRepo.On("GetClickLeaderboard", ctx, int64(1), mock.Anything,
+		time.Date(2025, 11, 11, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 11, 11, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 11, 12, 0, 0, 0, 0, time.UTC),
+		"UTC", "clicks", 10).Return([]dto.LeaderboardEntry{}, nil)
+
+	result, err := service.GetDashboardLeaderboards(ctx, 1, dto.DashboardLeaderboardRequest{StartAt: "2026-11-11", EndAt: "2026-11-12", Compare: dto.ComparePreviousYear})
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC), result.Data.PreviousEndAt)
+	mockClickRepo.AssertNumberOfCalls(t, "GetClickLeaderboard", 4)
+}
+
+func TestGetDashboardBreakdown(t *testing.T) {
+	mockClickRepo := new(mocks.MockClickRepository)
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	service := NewDashboardService(mockClickRepo, new(mocks.MockConversionRepository), new(mocks.MockProductRepository), bangkok)
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4()).String()
+	start := time.Date(2026, 3, 8, 0, 0, 0, 0, bangkok)
+	end := time.Date(2026, 3, 15, 0, 0, 0, 0, bangkok)
+	previousStart := time.Date(2026, 3, 1, 0, 0, 0, 0, bangkok)
+	mockClickRepo.On("GetClickBreakdown", ctx, int64(1), dto.BreakdownSource, previousStart, start, start, end, campaignId, "", "clicks", 20).Return([]dto.LeaderboardEntry{
+		{Key: "facebook", Label: "Facebook", Clicks: 30, UniqueVisitors: 25, PreviousClicks: 20},
+		{Key: "direct", Label: "Direct", Clicks: 5, UniqueVisitors: 5},
+	}, nil)
+
+	result, err := service.GetDashboardBreakdown(ctx, 1, dto.DashboardBreakdownRequest{Dimension: dto.BreakdownSource, StartAt: "2026-03-08", EndAt: "2026-03-15", CampaignId: campaignId})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, dto.BreakdownSource, result.Data.Dimension)
+	assert.Equal(t, "Asia/Bangkok", result.Data.Timezone)
+	assert.Equal(t, previousStart, result.Data.PreviousStartAt)
+	assert.Len(t, result.Data.Entries, 2)
+	assert.Equal(t, 1, result.Data.Entries[0].Rank)
+	assert.Equal(t, int64(10), result.Data.Entries[0].Delta)
+	assert.Equal(t, 0.5, *result.Data.Entries[0].DeltaRatio)
+	assert.Nil(t, result.Data.Entries[1].DeltaRatio)
+}
+
+func TestGetDashboardBreakdown_Failure(t *testing.T) {
+	mockClickRepo := new(mocks.MockClickRepository)
+	service := NewDashboardService(mockClickRepo, new(mocks.MockConversionRepository), new(mocks.MockProductRepository), time.UTC)
+	mockClickRepo.On("GetClickBreakdown", mock.Anything, int64(1), dto.BreakdownDevice, mock.Anything, mock.Anything, mock.Anything, mock.Anything, "", "", "unique_visitors", 5).Return([]dto.LeaderboardEntry(nil), errors.New("connection reset"))
+
+	result, err := service.GetDashboardBreakdown(context.Background(), 1, dto.DashboardBreakdownRequest{Dimension: dto.BreakdownDevice, Sort: "unique_visitors", Limit: 5})
+
+	assert.Error(t, err)
+	assert.Equal(t, 500, result.HttpCode)
+	assert.Equal(t, 4019, result.Code)
+
+	result, err = service.GetDashboardBreakdown(context.Background(), 1, dto.DashboardBreakdownRequest{Dimension: dto.BreakdownDevice, Timezone: "Mars/Olympus"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 400, result.HttpCode)
+	assert.Equal(t, 4013, result.Code)
+}
+
+func TestGetDashboardMetrics_ComparesWithPreviousPeriod(t *testing.T) {
+	mockClickRepo := new(mocks.MockClickRepository)
+	mockConversionRepo := new(mocks.MockConversionRepository)
+	service := NewDashboardService(mockClickRepo, mockConversionRepo, new(mocks.MockProductRepository), time.UTC)
+
+	ctx := context.Background()
+	summer := uuid.Must(uuid.NewV4())
+	winter := uuid.Must(uuid.NewV4())
+	start := time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	previousStart := time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)
+	mockClickRepo.On("CountClicksByDateRange", ctx, int64(1), start, end, "day", "UTC").Return([]dto.MetrictItem{
+		{Date: "2026-03-08", ClickCount: 6, CampaignId: summer, Marketplace: "lazada"},
+	}, nil)
+	mockClickRepo.On("CountClicksByDateRange", ctx, int64(1), previousStart, start, "day", "UTC").Return([]dto.MetrictItem{
+		{Date: "2026-03-06", ClickCount: 4, CampaignId: summer, Marketplace: "lazada"},
+		{Date: "2026-03-07", ClickCount: 2, CampaignId: winter, Marketplace: "shopee"},
+	}, nil)
+	mockConversionRepo.On("CountConversionsByDateRange", ctx, int64(1), mock.Anything, mock.Anything, "day", "UTC").Return([]dto.MetrictItem{}, nil)
+	mockClickRepo.On("GetClickLeaderboard", ctx, int64(1), dto.LeaderboardProducts, mock.Anything, mock.Anything, mock.Anything, mock.Anything, "UTC", "clicks", 1).Return([]dto.LeaderboardEntry{}, nil)
+
+	result, err := service.GetDashboardMetrics(ctx, 1, dto.DashboardMetricsRequest{StartAt: "2026-03-08", EndAt: "2026-03-10", Compare: dto.ComparePreviousPeriod})
+
+	assert.NoError(t, err)
+	// The winter series only had clicks before, so it is listed with zeros now.
+	assert.Len(t, result.Data.Metrics, 4)
+	assert.Equal(t, winter, result.Data.Metrics[1].CampaignId)
+	assert.Equal(t, 0, result.Data.Metrics[1].ClickCount)
+	comparison := result.Data.Comparison
+	assert.Equal(t, previousStart, comparison.StartAt)
+	assert.Equal(t, start, comparison.EndAt)
+	assert.Len(t, comparison.Metrics, 4)
+	assert.Equal(t, "2026-03-06", comparison.Metrics[0].Date)
+	assert.Equal(t, 4, comparison.Metrics[0].ClickCount)
+	assert.Equal(t, int64(6), comparison.Series[0].Clicks.Current)
+	assert.Equal(t, 0.5, *comparison.Series[0].Clicks.DeltaRatio)
+	allGone := -1.0
+	assert.Equal(t, dto.MetricDelta{Current: 0, Previous: 2, Delta: -2, DeltaRatio: &allGone}, comparison.Series[1].Clicks)
+	assert.Equal(t, int64(0), comparison.Clicks.Delta)
+}
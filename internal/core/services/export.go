@@ -0,0 +1,56 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/pkg/export"
+)
+
+// writeTable writes a sheet of rows under header, row(n) giving the values of the nth row.
+func writeTable(w export.Writer, sheet string, header []any, rows int, row func(n int) []any) error {
+	err := w.Sheet(sheet)
+	if err != nil {
+		return err
+	}
+	err = w.WriteRow(header...)
+	if err != nil {
+		return err
+	}
+	for n := range rows {
+		err = w.WriteRow(row(n)...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportedId leaves the nil id, under which unattributed orders are listed, blank.
+func exportedId(id uuid.UUID) any {
+	if id == uuid.Nil {
+		return nil
+	}
+	return id
+}
+
+// failedExport reports an export that could not be read or written. Once rows have been sent,
+// the handler can only cut the download short.
+func failedExport(code int, err error) (dto.Response[any], error) {
+	return dto.Response[any]{
+		HttpCode: http.StatusInternalServerError,
+		Success:  false,
+		Code:     code,
+		Message:  "Failed to export",
+	}, err
+}
+
+func exported() (dto.Response[any], error) {
+	return dto.Response[any]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Export written successfully",
+	}, nil
+}
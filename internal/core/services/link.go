@@ -1,15 +1,25 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/gofrs/uuid"
 	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/market-place-affiliate/api/internal/core/dto"
 	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/market-place-affiliate/api/pkg/customtime"
+	"github.com/market-place-affiliate/api/pkg/export"
 	"github.com/market-place-affiliate/api/pkg/random"
-	"github.com/market-place-affiliate/commonlib/lazada"
-	"github.com/market-place-affiliate/commonlib/shopee"
+	"github.com/market-place-affiliate/api/pkg/useragent"
 )
 
 type linkService struct {
@@ -18,13 +28,14 @@ type linkService struct {
 	productRepo    ports.ProductRepository
 	campaignRepo   ports.CampaignRepository
 	offerRepo      ports.OfferRepository
-	lazadaRepo     lazada.LazadaRepository
-	shopeeRepo     shopee.ShopeeRepository
+	userRepo       ports.UserRepository
+	marketplaces   ports.MarketplaceRegistry
 	marketCredRepo ports.MarketplaceRepository
+	events         ports.EventPublisher
 }
 
-func NewLinkService(linkRepo ports.LinkRepository, clickRepo ports.ClickRepository, productRepo ports.ProductRepository, campaignRepo ports.CampaignRepository, offerRepo ports.OfferRepository, lazadaRepo lazada.LazadaRepository, shopeeRepo shopee.ShopeeRepository, marketCredRepo ports.MarketplaceRepository) ports.LinkService {
-	return &linkService{linkRepo: linkRepo, clickRepo: clickRepo, productRepo: productRepo, campaignRepo: campaignRepo, offerRepo: offerRepo, lazadaRepo: lazadaRepo, shopeeRepo: shopeeRepo, marketCredRepo: marketCredRepo}
+func NewLinkService(linkRepo ports.LinkRepository, clickRepo ports.ClickRepository, productRepo ports.ProductRepository, campaignRepo ports.CampaignRepository, offerRepo ports.OfferRepository, userRepo ports.UserRepository, marketplaces ports.MarketplaceRegistry, marketCredRepo ports.MarketplaceRepository, events ports.EventPublisher) ports.LinkService {
+	return &linkService{linkRepo: linkRepo, clickRepo: clickRepo, productRepo: productRepo, campaignRepo: campaignRepo, offerRepo: offerRepo, userRepo: userRepo, marketplaces: marketplaces, marketCredRepo: marketCredRepo, events: events}
 }
 
 func (s *linkService) CreateLink(ctx context.Context, userId int64, link dto.CreateLinkRequest) (dto.Response[domains.Link], error) {
@@ -66,16 +77,6 @@ func (s *linkService) CreateLink(ctx context.Context, userId int64, link dto.Cre
 		}, nil
 	}
 
-	offer, err := s.offerRepo.GetOffersByProductId(ctx, product.Id.String())
-	if err != nil {
-		return dto.Response[domains.Link]{
-			HttpCode: http.StatusInternalServerError,
-			Success:  false,
-			Code:     4006,
-			Message:  "Offer not found for this product",
-		}, err
-	}
-
 	minByte := 4
 	newShortCode := random.RandStringBytes(minByte)
 	_, err = s.linkRepo.GetLinkByShortCode(ctx, newShortCode)
@@ -85,63 +86,15 @@ func (s *linkService) CreateLink(ctx context.Context, userId int64, link dto.Cre
 		_, err = s.linkRepo.GetLinkByShortCode(ctx, newShortCode)
 	}
 
+	targetUrl, res, err := s.affiliateUrl(ctx, userId, product, campaign, newShortCode)
+	if err != nil || !res.Success {
+		return res, err
+	}
 	newLink := domains.Link{
 		ProductId:  link.ProductId,
 		CampaignId: link.CampaignId,
 		ShortCode:  newShortCode,
-	}
-
-	switch offer.Marketplace {
-	case "lazada":
-		cred, err := s.marketCredRepo.GetByUserIdAndPlatform(ctx, userId, "lazada")
-		if err != nil {
-			return dto.Response[domains.Link]{
-				HttpCode: http.StatusInternalServerError,
-				Success:  false,
-				Code:     4009,
-				Message:  "Lazada marketplace credentials not found",
-			}, err
-		}
-		lazadaResp, err := s.lazadaRepo.GetBatchPromoteLink(lazada.LazadaCredentials{
-			AppKey:     cred.AppKey,
-			AppSecret:  cred.AppSecret,
-			SignMethod: "sha256",
-			UserToken:  cred.UserToken,
-		}, "url", product.SourceUrl, [6]string{campaign.UtmCampaign})
-		if err != nil || len(lazadaResp.Result.Data.URLBatchGetLinkInfoList) == 0 {
-			return dto.Response[domains.Link]{
-				HttpCode: http.StatusInternalServerError,
-				Success:  false,
-				Code:     4007,
-				Message:  "Failed to generate lazada affiliate link",
-			}, err
-		}
-		newLink.TargetURL = lazadaResp.Result.Data.URLBatchGetLinkInfoList[0].RegularPromotionLink
-	case "shopee":
-
-		cred, err := s.marketCredRepo.GetByUserIdAndPlatform(ctx, userId, "shopee")
-		if err != nil {
-			return dto.Response[domains.Link]{
-				HttpCode: http.StatusInternalServerError,
-				Success:  false,
-				Code:     4009,
-				Message:  "Shopee marketplace credentials not found",
-			}, err
-		}
-
-		shopeeResp, err := s.shopeeRepo.GetShortLink(shopee.ShopeeCredentials{
-			AppId:     cred.AppId,
-			AppSecret: cred.AppSecret,
-		}, product.SourceUrl, [5]string{campaign.UtmCampaign})
-		if err != nil || shopeeResp.Data.GenerateShortLink.ShortLink == "" {
-			return dto.Response[domains.Link]{
-				HttpCode: http.StatusInternalServerError,
-				Success:  false,
-				Code:     4008,
-				Message:  "Failed to generate shopee affiliate link",
-			}, err
-		}
-		newLink.TargetURL = shopeeResp.Data.GenerateShortLink.ShortLink
+		TargetURL:  targetUrl,
 	}
 
 	createdLink, err := s.linkRepo.SaveLink(ctx, newLink)
@@ -163,7 +116,136 @@ func (s *linkService) CreateLink(ctx context.Context, userId int64, link dto.Cre
 	}, nil
 }
 
-func (s *linkService) ClickByShortCode(ctx context.Context, shortCode string) (dto.Response[domains.Link], error) {
+// RegenerateLink asks the marketplace for a new affiliate url for an existing link, using the
+// campaign's current UtmCampaign. The short code, and so every url already shared, is kept.
+func (s *linkService) RegenerateLink(ctx context.Context, userId int64, linkId string) (dto.Response[domains.Link], error) {
+	link, err := s.linkRepo.GetLinkById(ctx, linkId)
+	if err != nil {
+		return dto.Response[domains.Link]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     4011,
+			Message:  "Failed to fetch link",
+		}, err
+	}
+	product, err := s.productRepo.GetProductById(ctx, link.ProductId.String())
+	if err != nil {
+		return dto.Response[domains.Link]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     4002,
+			Message:  "Product not found",
+		}, err
+	}
+	if product.UserId != userId {
+		return dto.Response[domains.Link]{
+			HttpCode: http.StatusForbidden,
+			Success:  false,
+			Code:     4003,
+			Message:  "You are not allowed to update link for this product",
+		}, nil
+	}
+	campaign, err := s.campaignRepo.GetCampaignById(ctx, link.CampaignId.String())
+	if err != nil {
+		return dto.Response[domains.Link]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     4004,
+			Message:  "Campaign not found",
+		}, err
+	}
+	if campaign.UserId != userId {
+		return dto.Response[domains.Link]{
+			HttpCode: http.StatusForbidden,
+			Success:  false,
+			Code:     4005,
+			Message:  "You are not allowed to update link for this campaign",
+		}, nil
+	}
+
+	targetUrl, res, err := s.affiliateUrl(ctx, userId, product, campaign, link.ShortCode)
+	if err != nil || !res.Success {
+		return res, err
+	}
+	link.TargetURL = targetUrl
+	updatedLink, err := s.linkRepo.SaveLink(ctx, link)
+	if err != nil {
+		return dto.Response[domains.Link]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     4012,
+			Message:  "Failed to update link",
+		}, err
+	}
+	return dto.Response[domains.Link]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Data:     updatedLink,
+		Message:  "Link regenerated successfully",
+	}, nil
+}
+
+// affiliateUrl generates the marketplace affiliate url of product for campaign. The campaign's
+// UtmCampaign and the link's short code are passed as sub ids, so imported conversions can be
+// attributed back to the link. On failure the returned response describes the error.
+func (s *linkService) affiliateUrl(ctx context.Context, userId int64, product domains.Product, campaign domains.Campaign, shortCode string) (string, dto.Response[domains.Link], error) {
+	offer, err := s.offerRepo.GetOffersByProductId(ctx, product.Id.String())
+	if err != nil {
+		return "", dto.Response[domains.Link]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     4006,
+			Message:  "Offer not found for this product",
+		}, err
+	}
+	return s.offerAffiliateUrl(ctx, userId, offer.Marketplace, product.SourceUrl, campaign, shortCode)
+}
+
+// offerAffiliateUrl asks marketplace for an affiliate url of sourceUrl carrying the link's sub
+// ids (see linkSubIds), with the user's credentials.
+func (s *linkService) offerAffiliateUrl(ctx context.Context, userId int64, marketplace, sourceUrl string, campaign domains.Campaign, shortCode string) (string, dto.Response[domains.Link], error) {
+	provider, err := s.marketplaces.Get(marketplace)
+	if err != nil {
+		return "", dto.Response[domains.Link]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     4010,
+			Message:  "Unsupported marketplace",
+		}, err
+	}
+	cred, err := s.marketCredRepo.GetByUserIdAndPlatform(ctx, userId, provider.Name())
+	if err != nil {
+		return "", dto.Response[domains.Link]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     4009,
+			Message:  "Marketplace credentials not found",
+		}, err
+	}
+	targetUrl, err := provider.GenerateAffiliateLink(ctx, cred, sourceUrl, linkSubIds(campaign, shortCode))
+	if err != nil {
+		return "", dto.Response[domains.Link]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     4007,
+			Message:  "Failed to generate " + provider.Name() + " affiliate link",
+		}, err
+	}
+	return targetUrl, dto.Response[domains.Link]{Success: true}, nil
+}
+
+// shortCodeSubId is the position of the link's short code among the sub ids of its affiliate
+// url, which is where conversion reports are attributed from.
+const shortCodeSubId = 1
+
+// linkSubIds are the sub ids of a link's affiliate url: the campaign's UtmCampaign and then the
+// short code at shortCodeSubId.
+func linkSubIds(campaign domains.Campaign, shortCode string) []string {
+	return []string{campaign.UtmCampaign, shortCode}
+}
+
+func (s *linkService) ClickByShortCode(ctx context.Context, shortCode string, click dto.ClickRequest) (dto.Response[domains.Link], error) {
 	link, err := s.linkRepo.GetLinkByShortCode(ctx, shortCode)
 	if err != nil {
 		return dto.Response[domains.Link]{
@@ -173,9 +255,24 @@ func (s *linkService) ClickByShortCode(ctx context.Context, shortCode string) (d
 			Message:  "Failed to get link by short code",
 		}, err
 	}
-	err = s.clickRepo.SaveClick(ctx, domains.Click{
-		LinkId: link.Id,
-	})
+	// The id is generated here rather than by the database so the redirect can pass it on.
+	id, err := uuid.NewV7()
+	agent := useragent.Parse(click.UserAgent)
+	target, marketplace := s.redirectTarget(ctx, link)
+	saved := domains.Click{
+		Id:             id,
+		LinkId:         link.Id,
+		VisitorId:      visitorId(click),
+		ReferrerDomain: referrerDomain(click.Referrer),
+		Device:         agent.Device,
+		Os:             agent.Os,
+		Browser:        agent.Browser,
+		Language:       preferredLanguage(click.AcceptLanguage),
+		Marketplace:    marketplace,
+	}
+	if err == nil {
+		err = s.clickRepo.SaveClick(ctx, saved)
+	}
 	if err != nil {
 		return dto.Response[domains.Link]{
 			HttpCode: http.StatusInternalServerError,
@@ -184,6 +281,16 @@ func (s *linkService) ClickByShortCode(ctx context.Context, shortCode string) (d
 			Message:  "Failed to record click",
 		}, err
 	}
+	s.events.Publish(ctx, domains.TopicClickRecorded, domains.ClickRecorded{
+		ClickId:    saved.ClickId(),
+		LinkId:     link.Id,
+		ShortCode:  link.ShortCode,
+		CampaignId: link.CampaignId,
+		ProductId:  link.ProductId,
+		VisitorId:  saved.VisitorId,
+		At:         customtime.Now(),
+	})
+	link.TargetURL = withClickId(target, saved.ClickId())
 	return dto.Response[domains.Link]{
 		HttpCode: http.StatusOK,
 		Success:  true,
@@ -192,6 +299,99 @@ func (s *linkService) ClickByShortCode(ctx context.Context, shortCode string) (d
 	}, nil
 }
 
+// redirectTarget applies the owner's unavailable link policy when the product's primary offer
+// is no longer available, and tells the marketplace of the offer it sends to, if any. Lookup
+// failures fall back to the link's own target so a shopper is never left without a redirect.
+func (s *linkService) redirectTarget(ctx context.Context, link domains.Link) (string, string) {
+	offers, err := s.offerRepo.ListOffersByProductId(ctx, link.ProductId.String())
+	if err != nil || len(offers) == 0 {
+		return link.TargetURL, ""
+	}
+	primary, alternatives := primaryOffer(offers)
+	if primary.Availability == domains.OfferAvailable {
+		return link.TargetURL, primary.Marketplace
+	}
+	product, err := s.productRepo.GetProductById(ctx, link.ProductId.String())
+	if err != nil {
+		return link.TargetURL, primary.Marketplace
+	}
+	user, err := s.userRepo.GetUserByID(ctx, product.UserId)
+	if err != nil {
+		return link.TargetURL, primary.Marketplace
+	}
+	switch user.UnavailableLinkPolicy {
+	case domains.LinkPolicyReroute:
+		if target, offer, ok := s.rerouteTarget(ctx, user.Id, link, alternatives); ok {
+			return target, offer.Marketplace
+		}
+		if user.FallbackUrl != "" {
+			return user.FallbackUrl, ""
+		}
+	case domains.LinkPolicyFallback:
+		if user.FallbackUrl != "" {
+			return user.FallbackUrl, ""
+		}
+	}
+	return link.TargetURL, primary.Marketplace
+}
+
+// rerouteTarget is an affiliate url, with the link's sub ids, of the first available offer
+// among alternatives, and that offer. Offers whose url cannot be made an affiliate url are
+// passed over, since clicks sent there would not be attributed.
+func (s *linkService) rerouteTarget(ctx context.Context, userId int64, link domains.Link, alternatives []domains.Offer) (string, domains.Offer, bool) {
+	var campaign *domains.Campaign
+	for _, offer := range alternatives {
+		if offer.Availability != domains.OfferAvailable || offer.Url == "" {
+			continue
+		}
+		if campaign == nil {
+			found, err := s.campaignRepo.GetCampaignById(ctx, link.CampaignId.String())
+			if err != nil {
+				return "", domains.Offer{}, false
+			}
+			campaign = &found
+		}
+		target, _, err := s.offerAffiliateUrl(ctx, userId, offer.Marketplace, offer.Url, *campaign, link.ShortCode)
+		if err == nil {
+			return target, offer, true
+		}
+	}
+	return "", domains.Offer{}, false
+}
+
+// primaryOffer splits offers into the primary offer, the one imported with the product, and
+// the others, oldest first, whatever order they were read in.
+func primaryOffer(offers []domains.Offer) (domains.Offer, []domains.Offer) {
+	sorted := slices.Clone(offers)
+	slices.SortStableFunc(sorted, func(a, b domains.Offer) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.Id.Bytes(), b.Id.Bytes())
+	})
+	return sorted[0], sorted[1:]
+}
+
+// withClickId passes clickId to the destination, in place of its domains.ClickIdMacro or else
+// as the domains.ClickIdParam query parameter, so networks can post conversions back against it.
+// The rest of the target is left as it is.
+func withClickId(target, clickId string) string {
+	if strings.Contains(target, domains.ClickIdMacro) {
+		return strings.ReplaceAll(target, domains.ClickIdMacro, clickId)
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+	param := domains.ClickIdParam + "=" + clickId
+	if u.RawQuery == "" {
+		u.RawQuery = param
+	} else {
+		u.RawQuery += "&" + param
+	}
+	return u.String()
+}
+
 func (s *linkService) GetLinkByCampaign(ctx context.Context, campaignId string) (dto.Response[[]domains.Link], error) {
 	links, err := s.linkRepo.GetLinksByCampaignId(ctx, campaignId)
 	if err != nil {
@@ -210,6 +410,51 @@ func (s *linkService) GetLinkByCampaign(ctx context.Context, campaignId string)
 	}, nil
 }
 
+// ExportCampaignLinks writes the links of one of the user's campaigns to w.
+func (s *linkService) ExportCampaignLinks(ctx context.Context, userId int64, campaignId string, w export.Writer) (dto.Response[any], error) {
+	campaign, err := s.campaignRepo.GetCampaignById(ctx, campaignId)
+	if errors.Is(err, ports.ErrCampaignNotFound) {
+		return dto.Response[any]{
+			HttpCode: http.StatusNotFound,
+			Success:  false,
+			Code:     4013,
+			Message:  "Campaign not found",
+		}, err
+	}
+	if err != nil {
+		return dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     4004,
+			Message:  "Campaign not found",
+		}, err
+	}
+	if campaign.UserId != userId {
+		return dto.Response[any]{
+			HttpCode: http.StatusForbidden,
+			Success:  false,
+			Code:     4014,
+			Message:  "You do not have permission to export this campaign's links",
+		}, nil
+	}
+	links, err := s.linkRepo.GetLinksByCampaignId(ctx, campaignId)
+	if err != nil {
+		return dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     4001,
+			Message:  "Failed to get links by campaign id",
+		}, err
+	}
+	err = writeTable(w, "Links", []any{"link_id", "short_code", "product_id", "target_url", "created_at"}, len(links), func(n int) []any {
+		return []any{links[n].Id, links[n].ShortCode, links[n].ProductId, links[n].TargetURL, links[n].CreatedAt}
+	})
+	if err != nil {
+		return failedExport(4015, err)
+	}
+	return exported()
+}
+
 func (s *linkService) DeleteLinkById(ctx context.Context, userId int64, linkId string) (dto.Response[any], error) {
 	link, err := s.linkRepo.GetLinkById(ctx, linkId)
 	if err != nil {
@@ -240,16 +485,7 @@ func (s *linkService) DeleteLinkById(ctx context.Context, userId int64, linkId s
 		}, nil
 	}
 
-	err = s.clickRepo.DeleteClicksByLinkId(ctx, linkId)
-	if err != nil {
-		return dto.Response[any]{
-			HttpCode: http.StatusInternalServerError,
-			Success:  false,
-			Code:     5004,
-			Message:  "Failed to delete clicks for the link",
-		}, err
-	}
-
+	// The link is soft deleted; its clicks are purged with it after the retention period.
 	err = s.linkRepo.DeleteLink(ctx, linkId)
 	if err != nil {
 		return dto.Response[any]{
@@ -303,3 +539,47 @@ func (s *linkService) GetLinkByShortCode(ctx context.Context, shortCode string)
 		Data:     link,
 	}, nil
 }
+
+// visitorId is a one-way hash of the visitor's ip address and user agent, used to count
+// unique visitors.
+func visitorId(click dto.ClickRequest) string {
+	if click.IpAddress == "" && click.UserAgent == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(click.IpAddress + "|" + click.UserAgent))
+	return hex.EncodeToString(sum[:16])
+}
+
+// referrerDomain is the lower case host of referrer without "www.", or empty when it has no
+// valid one.
+func referrerDomain(referrer string) string {
+	u, err := url.Parse(referrer)
+	if err != nil || len(u.Hostname()) > 253 {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// preferredLanguage is the primary subtag, such as "th", of the language an Accept-Language
+// header weighs highest, the first one among equals. It is empty when no language is named.
+func preferredLanguage(header string) string {
+	language, best := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		primary, _, _ := strings.Cut(tag, "-")
+		primary = strings.ToLower(strings.TrimSpace(primary))
+		if primary == "" || primary == "*" || len(primary) > 8 || weight <= best {
+			continue
+		}
+		language, best = primary, weight
+	}
+	return language
+}
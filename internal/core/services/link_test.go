@@ -1,13 +1,18 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
 	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/market-place-affiliate/api/pkg/eventbus"
+	"github.com/market-place-affiliate/api/pkg/export"
 	"github.com/market-place-affiliate/commonlib/lazada"
 	"github.com/market-place-affiliate/commonlib/shopee"
 	"github.com/stretchr/testify/assert"
@@ -24,7 +29,7 @@ func TestCreateLink_Lazada_Success(t *testing.T) {
 	mockShopeeRepo := new(mocks.MockShopeeRepository)
 	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo)
+	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, new(mocks.MockUserRepository), testMarketplaces(mockLazadaRepo, mockShopeeRepo), mockMarketCredRepo, eventbus.New())
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -127,7 +132,7 @@ func TestCreateLink_Shopee_Success(t *testing.T) {
 	mockShopeeRepo := new(mocks.MockShopeeRepository)
 	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo)
+	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, new(mocks.MockUserRepository), testMarketplaces(mockLazadaRepo, mockShopeeRepo), mockMarketCredRepo, eventbus.New())
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -221,7 +226,7 @@ func TestCreateLink_ProductNotOwned(t *testing.T) {
 	mockShopeeRepo := new(mocks.MockShopeeRepository)
 	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo)
+	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, new(mocks.MockUserRepository), testMarketplaces(mockLazadaRepo, mockShopeeRepo), mockMarketCredRepo, eventbus.New())
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -249,6 +254,52 @@ func TestCreateLink_ProductNotOwned(t *testing.T) {
 	mockProductRepo.AssertExpectations(t)
 }
 
+func TestRegenerateLink_KeepsShortCode(t *testing.T) {
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockOfferRepo := new(mocks.MockOfferRepository)
+	mockShopeeRepo := new(mocks.MockShopeeRepository)
+	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
+
+	service := NewLinkService(mockLinkRepo, new(mocks.MockClickRepository), mockProductRepo, mockCampaignRepo, mockOfferRepo, new(mocks.MockUserRepository), testMarketplaces(new(mocks.MockLazadaRepository), mockShopeeRepo), mockMarketCredRepo, eventbus.New())
+
+	ctx := context.Background()
+	userId := int64(1)
+	productId := uuid.Must(uuid.NewV4())
+	campaignId := uuid.Must(uuid.NewV4())
+	link := domains.Link{
+		Id:         uuid.Must(uuid.NewV4()),
+		ProductId:  productId,
+		CampaignId: campaignId,
+		ShortCode:  "abc123",
+		TargetURL:  "https://shopee.co.th/old-link",
+	}
+	product := domains.Product{Id: productId, UserId: userId, SourceUrl: "https://shopee.co.th/product"}
+	campaign := domains.Campaign{Id: campaignId, UserId: userId, UtmCampaign: "renamed_campaign"}
+
+	var shopeeResp shopee.ShopeeGetShortLink
+	shopeeResp.Data.GenerateShortLink.ShortLink = "https://shopee.co.th/new-link"
+
+	mockLinkRepo.On("GetLinkById", ctx, link.Id.String()).Return(link, nil)
+	mockProductRepo.On("GetProductById", ctx, productId.String()).Return(product, nil)
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(campaign, nil)
+	mockOfferRepo.On("GetOffersByProductId", ctx, productId.String()).Return(domains.Offer{ProductId: productId, Marketplace: "shopee"}, nil)
+	mockMarketCredRepo.On("GetByUserIdAndPlatform", ctx, userId, "shopee").Return(domains.MarketplaceCredential{UserId: userId, Marketplace: "shopee"}, nil)
+	mockShopeeRepo.On("GetShortLink", mock.AnythingOfType("shopee.ShopeeCredentials"), product.SourceUrl, [5]string{"renamed_campaign", "abc123"}).Return(shopeeResp, nil)
+	mockLinkRepo.On("SaveLink", ctx, mock.MatchedBy(func(l domains.Link) bool {
+		return l.Id == link.Id && l.ShortCode == "abc123" && l.TargetURL == "https://shopee.co.th/new-link"
+	})).Return(domains.Link{Id: link.Id, ShortCode: "abc123", TargetURL: "https://shopee.co.th/new-link"}, nil)
+
+	result, err := service.RegenerateLink(ctx, userId, link.Id.String())
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "https://shopee.co.th/new-link", result.Data.TargetURL)
+	mockShopeeRepo.AssertExpectations(t)
+	mockLinkRepo.AssertExpectations(t)
+}
+
 func TestClickByShortCode_Success(t *testing.T) {
 	mockLinkRepo := new(mocks.MockLinkRepository)
 	mockClickRepo := new(mocks.MockClickRepository)
@@ -259,33 +310,269 @@ func TestClickByShortCode_Success(t *testing.T) {
 	mockShopeeRepo := new(mocks.MockShopeeRepository)
 	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo)
+	bus := eventbus.New()
+	var published []domains.ClickRecorded
+	bus.Subscribe(domains.TopicClickRecorded, func(ctx context.Context, payload any) {
+		published = append(published, payload.(domains.ClickRecorded))
+	})
+	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, new(mocks.MockUserRepository), testMarketplaces(mockLazadaRepo, mockShopeeRepo), mockMarketCredRepo, bus)
 
 	ctx := context.Background()
 	shortCode := "abc123"
 	linkId := uuid.Must(uuid.NewV4())
 
 	link := domains.Link{
-		Id:        linkId,
-		ShortCode: shortCode,
-		TargetURL: "https://example.com",
+		Id:         linkId,
+		CampaignId: uuid.Must(uuid.NewV4()),
+		ShortCode:  shortCode,
+		TargetURL:  "https://example.com",
 	}
 
 	mockLinkRepo.On("GetLinkByShortCode", ctx, shortCode).Return(link, nil)
+	var saved domains.Click
 	mockClickRepo.On("SaveClick", ctx, mock.MatchedBy(func(c domains.Click) bool {
-		return c.LinkId == linkId
-	})).Return(nil)
+		return c.LinkId == linkId && c.Id.Version() == uuid.V7 && len(c.VisitorId) == 32 && c.VisitorId == visitorId(dto.ClickRequest{IpAddress: "203.0.113.7", UserAgent: "Mozilla/5.0"})
+	})).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(domains.Click)
+	}).Return(nil)
+	mockOfferRepo.On("ListOffersByProductId", ctx, link.ProductId.String()).Return([]domains.Offer{{Availability: domains.OfferAvailable}}, nil)
 
-	result, err := service.ClickByShortCode(ctx, shortCode)
+	result, err := service.ClickByShortCode(ctx, shortCode, dto.ClickRequest{IpAddress: "203.0.113.7", UserAgent: "Mozilla/5.0"})
 
 	assert.NoError(t, err)
 	assert.True(t, result.Success)
 	assert.Equal(t, 0, result.Code)
+	link.TargetURL = "https://example.com?sub_id=" + saved.ClickId()
 	assert.Equal(t, link, result.Data)
+	if assert.Len(t, published, 1) {
+		assert.Equal(t, saved.ClickId(), published[0].ClickId)
+		assert.Equal(t, link.CampaignId, published[0].CampaignId)
+		assert.Equal(t, saved.VisitorId, published[0].VisitorId)
+	}
 	mockLinkRepo.AssertExpectations(t)
 	mockClickRepo.AssertExpectations(t)
 }
 
+func TestClickByShortCode_VisitorDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		click   dto.ClickRequest
+		details domains.Click
+	}{
+		{
+			name: "facebook app on iphone",
+			click: dto.ClickRequest{
+				UserAgent:      "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 [FBAN/FBIOS;FBAV/456.0.0.41.109]",
+				Referrer:       "https://m.facebook.com/",
+				AcceptLanguage: "th-TH,th;q=0.9,en;q=0.8",
+			},
+			details: domains.Click{ReferrerDomain: "m.facebook.com", Device: "mobile", Os: "iOS", Browser: "Facebook", Language: "th"},
+		},
+		{
+			name: "chrome on android tablet",
+			click: dto.ClickRequest{
+				UserAgent:      "Mozilla/5.0 (Linux; Android 14; SM-X710) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+				Referrer:       "https://www.Google.co.th/search?q=earbuds",
+				AcceptLanguage: "en;q=0.5, ms-MY",
+			},
+			details: domains.Click{ReferrerDomain: "google.co.th", Device: "tablet", Os: "Android", Browser: "Chrome", Language: "ms"},
+		},
+		{
+			name: "edge on windows",
+			click: dto.ClickRequest{
+				UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.2478.51",
+			},
+			details: domains.Click{Device: "desktop", Os: "Windows", Browser: "Edge"},
+		},
+		{
+			name:    "link preview",
+			click:   dto.ClickRequest{UserAgent: "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)", AcceptLanguage: "*"},
+			details: domains.Click{Device: "bot", Os: "Other", Browser: "Other"},
+		},
+		{
+			name: "nothing sent",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLinkRepo := new(mocks.MockLinkRepository)
+			mockClickRepo := new(mocks.MockClickRepository)
+			mockOfferRepo := new(mocks.MockOfferRepository)
+			service := NewLinkService(mockLinkRepo, mockClickRepo, new(mocks.MockProductRepository), new(mocks.MockCampaignRepository), mockOfferRepo, new(mocks.MockUserRepository), testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)), new(mocks.MockMarketplaceRepository), eventbus.New())
+
+			ctx := context.Background()
+			link := domains.Link{Id: uuid.Must(uuid.NewV4()), ShortCode: "abc123", TargetURL: "https://example.com"}
+			mockLinkRepo.On("GetLinkByShortCode", ctx, "abc123").Return(link, nil)
+			var saved domains.Click
+			mockClickRepo.On("SaveClick", ctx, mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(domains.Click)
+			}).Return(nil)
+			mockOfferRepo.On("ListOffersByProductId", ctx, link.ProductId.String()).Return([]domains.Offer{}, nil)
+
+			_, err := service.ClickByShortCode(ctx, "abc123", tt.click)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.details, domains.Click{ReferrerDomain: saved.ReferrerDomain, Device: saved.Device, Os: saved.Os, Browser: saved.Browser, Language: saved.Language})
+		})
+	}
+}
+
+func TestClickByShortCode_UnavailablePolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		fallbackUrl string
+		offers      []domains.Offer
+		rerouted    string
+		target      string
+		marketplace string
+	}{
+		{
+			name:        "keep",
+			policy:      domains.LinkPolicyKeep,
+			offers:      []domains.Offer{{Availability: domains.OfferOutOfStock, Marketplace: "lazada"}},
+			target:      "https://c.lazada.co.th/t/original",
+			marketplace: "lazada",
+		},
+		{
+			name:   "reroute to available offer",
+			policy: domains.LinkPolicyReroute,
+			offers: []domains.Offer{
+				{Availability: domains.OfferAvailable, Marketplace: "shopee", Url: "https://shope.ee/c", CreatedAt: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)},
+				{Availability: domains.OfferDelisted, Marketplace: "shopee", Url: "https://shope.ee/a", CreatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+				{Availability: domains.OfferOutOfStock, Marketplace: "shopee", Url: "https://shope.ee/b", CreatedAt: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)},
+			},
+			rerouted:    "https://shope.ee/c",
+			target:      "https://shopee.co.th/rerouted",
+			marketplace: "shopee",
+		},
+		{
+			name:   "primary offer is the oldest, whatever the order",
+			policy: domains.LinkPolicyReroute,
+			offers: []domains.Offer{
+				{Availability: domains.OfferDelisted, Url: "https://shope.ee/a", CreatedAt: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)},
+				{Availability: domains.OfferAvailable, Url: "https://shope.ee/c", CreatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			target: "https://c.lazada.co.th/t/original",
+		},
+		{
+			name:        "reroute without available offer uses fallback",
+			policy:      domains.LinkPolicyReroute,
+			fallbackUrl: "https://example.com/shop",
+			offers:      []domains.Offer{{Availability: domains.OfferDelisted}},
+			target:      "https://example.com/shop",
+		},
+		{
+			name:        "fallback",
+			policy:      domains.LinkPolicyFallback,
+			fallbackUrl: "https://example.com/shop",
+			offers:      []domains.Offer{{Availability: domains.OfferDelisted, Marketplace: "lazada"}, {Availability: domains.OfferAvailable, Marketplace: "shopee", Url: "https://shope.ee/c"}},
+			target:      "https://example.com/shop",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLinkRepo := new(mocks.MockLinkRepository)
+			mockClickRepo := new(mocks.MockClickRepository)
+			mockProductRepo := new(mocks.MockProductRepository)
+			mockOfferRepo := new(mocks.MockOfferRepository)
+			mockUserRepo := new(mocks.MockUserRepository)
+			mockCampaignRepo := new(mocks.MockCampaignRepository)
+			mockShopeeRepo := new(mocks.MockShopeeRepository)
+			mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
+
+			service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, mockUserRepo, testMarketplaces(new(mocks.MockLazadaRepository), mockShopeeRepo), mockMarketCredRepo, eventbus.New())
+
+			ctx := context.Background()
+			productId := uuid.Must(uuid.NewV4())
+			campaignId := uuid.Must(uuid.NewV4())
+			link := domains.Link{
+				Id:         uuid.Must(uuid.NewV4()),
+				ProductId:  productId,
+				CampaignId: campaignId,
+				ShortCode:  "abc123",
+				TargetURL:  "https://c.lazada.co.th/t/original",
+			}
+			if tt.rerouted != "" {
+				var shopeeResp shopee.ShopeeGetShortLink
+				shopeeResp.Data.GenerateShortLink.ShortLink = tt.target
+				mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UtmCampaign: "summer_sale"}, nil)
+				mockMarketCredRepo.On("GetByUserIdAndPlatform", ctx, int64(7), "shopee").Return(domains.MarketplaceCredential{UserId: 7, Marketplace: "shopee"}, nil)
+				mockShopeeRepo.On("GetShortLink", mock.AnythingOfType("shopee.ShopeeCredentials"), tt.rerouted, [5]string{"summer_sale", "abc123"}).Return(shopeeResp, nil)
+			}
+
+			mockLinkRepo.On("GetLinkByShortCode", ctx, "abc123").Return(link, nil)
+			var saved domains.Click
+			mockClickRepo.On("SaveClick", ctx, mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(domains.Click)
+			}).Return(nil)
+			mockOfferRepo.On("ListOffersByProductId", ctx, productId.String()).Return(tt.offers, nil)
+			mockProductRepo.On("GetProductById", ctx, productId.String()).Return(domains.Product{Id: productId, UserId: 7}, nil)
+			mockUserRepo.On("GetUserByID", ctx, int64(7)).Return(domains.User{Id: 7, UnavailableLinkPolicy: tt.policy, FallbackUrl: tt.fallbackUrl}, nil)
+
+			result, err := service.ClickByShortCode(ctx, "abc123", dto.ClickRequest{})
+
+			assert.NoError(t, err)
+			assert.True(t, result.Success)
+			assert.Equal(t, tt.target+"?sub_id="+saved.ClickId(), result.Data.TargetURL)
+			assert.Equal(t, tt.marketplace, saved.Marketplace)
+			mockClickRepo.AssertExpectations(t)
+			mockShopeeRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWithClickId(t *testing.T) {
+	clickId := "0190a5f2c4d37b3e9f2a1c5d6e7f8091"
+
+	assert.Equal(t, "https://network.example/track?aff=7&sub_id="+clickId, withClickId("https://network.example/track?aff=7", clickId))
+	assert.Equal(t, "https://network.example/track?cid="+clickId+"&aff=7", withClickId("https://network.example/track?cid={click_id}&aff=7", clickId))
+	parsed, err := uuid.FromString(clickId)
+	assert.NoError(t, err)
+	assert.Equal(t, clickId, domains.Click{Id: parsed}.ClickId())
+}
+
+func TestExportCampaignLinks(t *testing.T) {
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	service := NewLinkService(mockLinkRepo, new(mocks.MockClickRepository), new(mocks.MockProductRepository), mockCampaignRepo, new(mocks.MockOfferRepository), new(mocks.MockUserRepository), testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)), new(mocks.MockMarketplaceRepository), eventbus.New())
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	linkId := uuid.Must(uuid.FromString("0195a1b2-0000-7000-8000-000000000001"))
+	productId := uuid.Must(uuid.FromString("0195a1b2-0000-7000-8000-000000000002"))
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, UserId: 1}, nil)
+	mockLinkRepo.On("GetLinksByCampaignId", ctx, campaignId.String()).Return([]domains.Link{
+		{Id: linkId, ProductId: productId, ShortCode: "abc123", TargetURL: "https://s.shopee.co.th/abc", CreatedAt: time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)},
+	}, nil)
+
+	var out bytes.Buffer
+	w, _ := export.NewWriter(export.FormatCSV, &out)
+	result, err := service.ExportCampaignLinks(ctx, 1, campaignId.String(), w)
+	assert.NoError(t, w.Close())
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "link_id,short_code,product_id,target_url,created_at\n"+
+		"0195a1b2-0000-7000-8000-000000000001,abc123,0195a1b2-0000-7000-8000-000000000002,https://s.shopee.co.th/abc,2026-03-01T09:30:00Z\n", out.String())
+
+	result, err = service.ExportCampaignLinks(ctx, 2, campaignId.String(), w)
+
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 403, result.HttpCode)
+	assert.Equal(t, 4014, result.Code)
+
+	unknownId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignById", ctx, unknownId.String()).Return(domains.Campaign{}, ports.ErrCampaignNotFound)
+
+	result, err = service.ExportCampaignLinks(ctx, 1, unknownId.String(), w)
+
+	assert.Error(t, err)
+	assert.Equal(t, 404, result.HttpCode)
+	assert.Equal(t, 4013, result.Code)
+}
+
 func TestGetLinkByCampaign_Success(t *testing.T) {
 	mockLinkRepo := new(mocks.MockLinkRepository)
 	mockClickRepo := new(mocks.MockClickRepository)
@@ -296,7 +583,7 @@ func TestGetLinkByCampaign_Success(t *testing.T) {
 	mockShopeeRepo := new(mocks.MockShopeeRepository)
 	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo)
+	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, new(mocks.MockUserRepository), testMarketplaces(mockLazadaRepo, mockShopeeRepo), mockMarketCredRepo, eventbus.New())
 
 	ctx := context.Background()
 	campaignId := uuid.Must(uuid.NewV4())
@@ -335,7 +622,7 @@ func TestDeleteLinkById_Success(t *testing.T) {
 	mockShopeeRepo := new(mocks.MockShopeeRepository)
 	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo)
+	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, new(mocks.MockUserRepository), testMarketplaces(mockLazadaRepo, mockShopeeRepo), mockMarketCredRepo, eventbus.New())
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -354,7 +641,6 @@ func TestDeleteLinkById_Success(t *testing.T) {
 
 	mockLinkRepo.On("GetLinkById", ctx, linkId.String()).Return(link, nil)
 	mockProductRepo.On("GetProductById", ctx, productId.String()).Return(product, nil)
-	mockClickRepo.On("DeleteClicksByLinkId", ctx, linkId.String()).Return(nil)
 	mockLinkRepo.On("DeleteLink", ctx, linkId.String()).Return(nil)
 
 	result, err := service.DeleteLinkById(ctx, userId, linkId.String())
@@ -364,7 +650,6 @@ func TestDeleteLinkById_Success(t *testing.T) {
 	assert.Equal(t, 0, result.Code)
 	mockLinkRepo.AssertExpectations(t)
 	mockProductRepo.AssertExpectations(t)
-	mockClickRepo.AssertExpectations(t)
 }
 
 func TestDeleteLinkById_Forbidden(t *testing.T) {
@@ -377,7 +662,7 @@ func TestDeleteLinkById_Forbidden(t *testing.T) {
 	mockShopeeRepo := new(mocks.MockShopeeRepository)
 	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo)
+	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, new(mocks.MockUserRepository), testMarketplaces(mockLazadaRepo, mockShopeeRepo), mockMarketCredRepo, eventbus.New())
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -417,7 +702,7 @@ func TestGetLinkById_Success(t *testing.T) {
 	mockShopeeRepo := new(mocks.MockShopeeRepository)
 	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo)
+	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, new(mocks.MockUserRepository), testMarketplaces(mockLazadaRepo, mockShopeeRepo), mockMarketCredRepo, eventbus.New())
 
 	ctx := context.Background()
 	linkId := uuid.Must(uuid.NewV4())
@@ -449,7 +734,7 @@ func TestGetLinkByShortCode_Success(t *testing.T) {
 	mockShopeeRepo := new(mocks.MockShopeeRepository)
 	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo)
+	service := NewLinkService(mockLinkRepo, mockClickRepo, mockProductRepo, mockCampaignRepo, mockOfferRepo, new(mocks.MockUserRepository), testMarketplaces(mockLazadaRepo, mockShopeeRepo), mockMarketCredRepo, eventbus.New())
 
 	ctx := context.Background()
 	shortCode := "abc123"
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+// marketplaceErrorHttpCode maps a provider error to the status returned to the client.
+func marketplaceErrorHttpCode(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrUnsupportedMarketplace), errors.Is(err, ports.ErrUnsupportedRegion), errors.Is(err, ports.ErrInvalidProductUrl):
+		return http.StatusBadRequest
+	case errors.Is(err, ports.ErrProductNotAvailable):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func marketplaceErrorMessage(marketplace string, err error) string {
+	switch {
+	case errors.Is(err, ports.ErrInvalidProductUrl):
+		return "Invalid " + marketplace + " product url"
+	case errors.Is(err, ports.ErrProductNotAvailable):
+		return "This product is not available for affiliation"
+	case errors.Is(err, ports.ErrUnsupportedRegion):
+		return "Your " + marketplace + " credential region is not supported"
+	default:
+		return "Failed to fetch product from " + marketplace
+	}
+}
+
+// resolveErrorCode maps a url resolver error to a product error code:
+// 2008 for urls we refuse to follow, 2009 for redirect loops and 2010 when the short link
+// could not be followed at all.
+func resolveErrorCode(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrInvalidProductUrl), errors.Is(err, ports.ErrUrlHostNotAllowed):
+		return 2008
+	case errors.Is(err, ports.ErrTooManyRedirects):
+		return 2009
+	default:
+		return 2010
+	}
+}
+
+func resolveErrorHttpCode(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrInvalidProductUrl), errors.Is(err, ports.ErrUrlHostNotAllowed), errors.Is(err, ports.ErrTooManyRedirects):
+		return http.StatusBadRequest
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func resolveErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, ports.ErrInvalidProductUrl):
+		return "Product url must be an http or https url"
+	case errors.Is(err, ports.ErrUrlHostNotAllowed):
+		return "Product url is not a supported marketplace link"
+	case errors.Is(err, ports.ErrTooManyRedirects):
+		return "Product link redirects too many times"
+	default:
+		return "Unable to open product link, please paste the full product url"
+	}
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/market-place-affiliate/api/internal/fakemarket"
+	"github.com/market-place-affiliate/api/internal/repositories/marketplace"
+	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/market-place-affiliate/commonlib/shopee"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testMarketplaces(lazadaRepo *mocks.MockLazadaRepository, shopeeRepo *mocks.MockShopeeRepository) ports.MarketplaceRegistry {
+	return marketplace.NewRegistry(
+		marketplace.NewLazadaProvider(marketplace.LazadaClients{"th": lazadaRepo}),
+		marketplace.NewShopeeProvider(marketplace.ShopeeClients{"th": shopeeRepo}),
+	)
+}
+
+func TestMarketplaceErrorHttpCode(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, marketplaceErrorHttpCode(fmt.Errorf("%w: bad", ports.ErrInvalidProductUrl)))
+	assert.Equal(t, http.StatusUnprocessableEntity, marketplaceErrorHttpCode(ports.ErrProductNotAvailable))
+	assert.Equal(t, http.StatusBadGateway, marketplaceErrorHttpCode(assert.AnError))
+}
+
+func TestCreateProduct_UnsupportedMarketplace(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockOfferRepo := new(mocks.MockOfferRepository)
+	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	mockClickRepo := new(mocks.MockClickRepository)
+
+	service := NewProductService(mockProductRepo, mockOfferRepo, marketplace.NewRegistry(), new(mocks.MockUrlResolver), mockMarketCredRepo, mockLinkRepo, mockClickRepo)
+
+	result, err := service.CreateProduct(context.Background(), int64(1), dto.CreateProductRequest{
+		SourceUrl:   "https://www.tiktok.com/view/product/1",
+		Marketplace: "tiktok",
+	})
+
+	assert.ErrorIs(t, err, ports.ErrUnsupportedMarketplace)
+	assert.False(t, result.Success)
+	assert.Equal(t, http.StatusBadRequest, result.HttpCode)
+	assert.Equal(t, 2007, result.Code)
+	mockMarketCredRepo.AssertNotCalled(t, "GetByUserIdAndPlatform")
+}
+
+func TestSaveMarketplaceCredential_MissingFields(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockMarketRepo := new(mocks.MockMarketplaceRepository)
+
+	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo, testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)))
+
+	result, err := service.SaveMarketplaceCredential(context.Background(), int64(1), dto.MarketplaceCredentialRequest{
+		Platform: "lazada",
+		AppKey:   "key123",
+	})
+
+	assert.Error(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 1010, result.Code)
+	assert.Equal(t, "Invalid marketplace credential: missing credential fields: app_secret, user_token", result.Message)
+	mockMarketRepo.AssertNotCalled(t, "Save", context.Background(), domains.MarketplaceCredential{})
+}
+
+func TestSaveMarketplaceCredential_UnsupportedRegion(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockMarketRepo := new(mocks.MockMarketplaceRepository)
+
+	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo, testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)))
+
+	result, err := service.SaveMarketplaceCredential(context.Background(), int64(1), dto.MarketplaceCredentialRequest{
+		Platform:  "shopee",
+		Region:    "my",
+		AppId:     "app123",
+		AppSecret: "secret123",
+	})
+
+	assert.ErrorIs(t, err, ports.ErrUnsupportedRegion)
+	assert.False(t, result.Success)
+	assert.Equal(t, 1010, result.Code)
+	mockMarketRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestCreateProduct_UsesCredentialRegion(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockOfferRepo := new(mocks.MockOfferRepository)
+	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
+	mockUrlResolver := new(mocks.MockUrlResolver)
+	thShopeeRepo := new(mocks.MockShopeeRepository)
+	myShopeeRepo := new(mocks.MockShopeeRepository)
+	marketplaces := marketplace.NewRegistry(
+		marketplace.NewShopeeProvider(marketplace.ShopeeClients{"th": thShopeeRepo, "my": myShopeeRepo}),
+	)
+
+	service := NewProductService(mockProductRepo, mockOfferRepo, marketplaces, mockUrlResolver, mockMarketCredRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository))
+
+	ctx := context.Background()
+	userId := int64(1)
+	sourceUrl := "https://shopee.com.my/product/123/456"
+	mockUrlResolver.On("Resolve", ctx, sourceUrl).Return(sourceUrl, nil)
+	mockMarketCredRepo.On("GetByUserIdAndPlatform", ctx, userId, "shopee").Return(domains.MarketplaceCredential{
+		UserId:      userId,
+		Marketplace: "shopee",
+		Region:      "my",
+		AppId:       "app123",
+		AppSecret:   "secret123",
+	}, nil)
+
+	var shopeeResp shopee.ShopeeGetProductOfferList
+	err := json.Unmarshal([]byte(`{"data":{"productOfferV2":{"nodes":[
+		{"productName":"Kettle","imageUrl":"https://cf.shopee.com.my/a.jpg","shopName":"Shop MY","price":"49.90","commissionRate":"0.05","commission":"2.50","sales":10}
+	]}}}`), &shopeeResp)
+	assert.NoError(t, err)
+	myShopeeRepo.On("GetProductOfferListV2", mock.AnythingOfType("shopee.ShopeeCredentials"), "123", "456").Return(shopeeResp, nil)
+	mockProductRepo.On("SaveProduct", ctx, mock.Anything).Return(domains.Product{Id: uuid.Must(uuid.NewV4()), UserId: userId, Title: "Kettle"}, nil)
+	mockOfferRepo.On("SaveOffer", ctx, mock.MatchedBy(func(o domains.Offer) bool {
+		return o.StoreName == "Shop MY" && o.Currency == "MYR"
+	})).Return(nil)
+
+	result, err := service.CreateProduct(ctx, userId, dto.CreateProductRequest{
+		SourceUrl:   sourceUrl,
+		Marketplace: "shopee",
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	myShopeeRepo.AssertExpectations(t)
+	thShopeeRepo.AssertNotCalled(t, "GetProductOfferListV2", mock.Anything, mock.Anything, mock.Anything)
+	mockOfferRepo.AssertExpectations(t)
+}
+
+func fakeMarketplaces(fake *fakemarket.Instance) ports.MarketplaceRegistry {
+	return marketplace.NewRegistry(
+		marketplace.NewLazadaProvider(marketplace.NewLazadaClients(string(fake.LazadaGateway()), false)),
+		marketplace.NewShopeeProvider(marketplace.NewShopeeClients(fake.ShopeeEndpoint(), false)),
+	)
+}
+
+// fakeUrlResolver treats the local fake marketplace as a short link host.
+func fakeUrlResolver() ports.UrlResolver {
+	return marketplace.NewUrlResolver(marketplace.UrlResolverConfig{
+		AllowedHosts:   []string{"127.0.0.1", "lazada.co.th", "shopee.co.th"},
+		ShortLinkHosts: []string{"127.0.0.1"},
+		MaxHops:        3,
+		Timeout:        time.Second,
+	})
+}
+
+func TestCreateProduct_FakeMarket(t *testing.T) {
+	fake := fakemarket.Start(fakemarket.DefaultFixtures())
+	defer fake.Close()
+
+	lazadaCred := domains.MarketplaceCredential{AppKey: "key", AppSecret: "secret", UserToken: "token"}
+	shopeeCred := domains.MarketplaceCredential{AppId: "app", AppSecret: "secret"}
+	tests := []struct {
+		name        string
+		marketplace string
+		sourceUrl   string
+		resolvedUrl string
+		cred        domains.MarketplaceCredential
+		title       string
+		price       float64
+	}{
+		{
+			name:        "lazada",
+			marketplace: "lazada",
+			sourceUrl:   "https://www.lazada.co.th/products/wireless-earbuds-i1001.html",
+			resolvedUrl: "https://www.lazada.co.th/products/wireless-earbuds-i1001.html",
+			cred:        lazadaCred,
+			title:       "Wireless Earbuds",
+			price:       599,
+		},
+		{
+			name:        "lazada share link",
+			marketplace: "lazada",
+			sourceUrl:   fake.URL() + "/share/lzd-1001",
+			resolvedUrl: "https://www.lazada.co.th/products/wireless-earbuds-i1001.html",
+			cred:        lazadaCred,
+			title:       "Wireless Earbuds",
+			price:       599,
+		},
+		{
+			name:        "shopee",
+			marketplace: "shopee",
+			sourceUrl:   "https://shopee.co.th/Stainless-Water-Bottle-i.2001.3001",
+			resolvedUrl: "https://shopee.co.th/Stainless-Water-Bottle-i.2001.3001",
+			cred:        shopeeCred,
+			title:       "Stainless Water Bottle",
+			price:       259,
+		},
+		{
+			name:        "shopee share link",
+			marketplace: "shopee",
+			sourceUrl:   fake.URL() + "/share/shp-3001",
+			resolvedUrl: "https://shopee.co.th/product/2001/3001",
+			cred:        shopeeCred,
+			title:       "Stainless Water Bottle",
+			price:       259,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProductRepo := new(mocks.MockProductRepository)
+			mockOfferRepo := new(mocks.MockOfferRepository)
+			mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
+			service := NewProductService(mockProductRepo, mockOfferRepo, fakeMarketplaces(fake), fakeUrlResolver(), mockMarketCredRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository))
+
+			ctx := context.Background()
+			mockMarketCredRepo.On("GetByUserIdAndPlatform", ctx, int64(1), tt.marketplace).Return(tt.cred, nil)
+			mockProductRepo.On("SaveProduct", ctx, mock.MatchedBy(func(p domains.Product) bool {
+				return p.Title == tt.title && p.SourceUrl == tt.resolvedUrl && len(p.Images) > 0
+			})).Return(domains.Product{Id: uuid.Must(uuid.NewV4()), Title: tt.title}, nil)
+			mockOfferRepo.On("SaveOffer", ctx, mock.MatchedBy(func(o domains.Offer) bool {
+				return o.Marketplace == tt.marketplace && o.Price == tt.price
+			})).Return(nil)
+
+			result, err := service.CreateProduct(ctx, int64(1), dto.CreateProductRequest{
+				SourceUrl:   tt.sourceUrl,
+				Marketplace: tt.marketplace,
+			})
+
+			assert.NoError(t, err)
+			assert.True(t, result.Success)
+			assert.Len(t, result.Data, 1)
+			mockProductRepo.AssertExpectations(t)
+			mockOfferRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateProduct_FakeMarketFailure(t *testing.T) {
+	fake := fakemarket.Start(fakemarket.DefaultFixtures())
+	defer fake.Close()
+	fake.Fail(fakemarket.EndpointLazadaProductFeed, fakemarket.Failure{Code: "ApiCallLimit", Times: 1})
+
+	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
+	service := NewProductService(new(mocks.MockProductRepository), new(mocks.MockOfferRepository), fakeMarketplaces(fake), fakeUrlResolver(), mockMarketCredRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository))
+
+	ctx := context.Background()
+	cred := domains.MarketplaceCredential{AppKey: "key", AppSecret: "secret", UserToken: "token"}
+	mockMarketCredRepo.On("GetByUserIdAndPlatform", ctx, int64(1), "lazada").Return(cred, nil)
+
+	result, err := service.CreateProduct(ctx, int64(1), dto.CreateProductRequest{
+		SourceUrl:   "https://www.lazada.co.th/products/wireless-earbuds-i1001.html",
+		Marketplace: "lazada",
+	})
+
+	assert.Error(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, http.StatusBadGateway, result.HttpCode)
+	assert.Equal(t, "Failed to fetch product from lazada", result.Message)
+	assert.Equal(t, 1, fake.Calls(fakemarket.EndpointLazadaProductFeed))
+}
+
+func TestCreateProduct_ResolveErrors(t *testing.T) {
+	fake := fakemarket.Start(fakemarket.Fixtures{ShareLinks: map[string]string{
+		"loop":    "/share/loop",
+		"foreign": "https://example.com/product/1",
+	}})
+	defer fake.Close()
+
+	tests := []struct {
+		name      string
+		sourceUrl string
+		httpCode  int
+		code      int
+		err       error
+	}{
+		{"not a url", "shopee.co.th/product/1/2", http.StatusBadRequest, 2008, ports.ErrInvalidProductUrl},
+		{"host not allowed", "https://example.com/product/1", http.StatusBadRequest, 2008, ports.ErrUrlHostNotAllowed},
+		{"redirect leaves allowlist", fake.URL() + "/share/foreign", http.StatusBadRequest, 2008, ports.ErrUrlHostNotAllowed},
+		{"redirect loop", fake.URL() + "/share/loop", http.StatusBadRequest, 2009, ports.ErrTooManyRedirects},
+		{"unknown short link", fake.URL() + "/share/missing", http.StatusBadGateway, 2010, ports.ErrUrlResolveFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
+			service := NewProductService(new(mocks.MockProductRepository), new(mocks.MockOfferRepository), fakeMarketplaces(fake), fakeUrlResolver(), mockMarketCredRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository))
+
+			result, err := service.CreateProduct(context.Background(), int64(1), dto.CreateProductRequest{
+				SourceUrl:   tt.sourceUrl,
+				Marketplace: "shopee",
+			})
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.False(t, result.Success)
+			assert.Equal(t, tt.httpCode, result.HttpCode)
+			assert.Equal(t, tt.code, result.Code)
+			mockMarketCredRepo.AssertNotCalled(t, "GetByUserIdAndPlatform")
+		})
+	}
+}
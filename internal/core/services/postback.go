@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/market-place-affiliate/api/pkg/customtime"
+)
+
+type postbackService struct {
+	clickRepo      ports.ClickRepository
+	linkRepo       ports.LinkRepository
+	campaignRepo   ports.CampaignRepository
+	offerRepo      ports.OfferRepository
+	userRepo       ports.UserRepository
+	conversionRepo ports.ConversionRepository
+}
+
+func NewPostbackService(clickRepo ports.ClickRepository, linkRepo ports.LinkRepository, campaignRepo ports.CampaignRepository, offerRepo ports.OfferRepository, userRepo ports.UserRepository, conversionRepo ports.ConversionRepository) ports.PostbackService {
+	return &postbackService{clickRepo: clickRepo, linkRepo: linkRepo, campaignRepo: campaignRepo, offerRepo: offerRepo, userRepo: userRepo, conversionRepo: conversionRepo}
+}
+
+// RecordPostback saves the order of a postback as a conversion of the clicked link, once the
+// postback is authenticated by the secret of the link's owner. An order posted back again, e.g.
+// once it is approved or cancelled, updates the conversion recorded for it.
+func (s *postbackService) RecordPostback(ctx context.Context, postback dto.PostbackRequest) (dto.Response[domains.Conversion], error) {
+	clickId, err := uuid.FromString(postback.ClickId)
+	if err != nil {
+		return dto.Response[domains.Conversion]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     10001,
+			Message:  "Invalid click id",
+		}, err
+	}
+	click, err := s.clickRepo.GetClickById(ctx, clickId.String())
+	if err != nil {
+		return failedPostbackClick(err)
+	}
+	link, err := s.linkRepo.GetLinkById(ctx, click.LinkId.String())
+	if err != nil {
+		return failedPostbackClick(err)
+	}
+	campaign, err := s.campaignRepo.GetCampaignById(ctx, link.CampaignId.String())
+	if err != nil {
+		return failedPostbackClick(err)
+	}
+	user, err := s.userRepo.GetUserByID(ctx, campaign.UserId)
+	if err != nil {
+		return failedPostbackClick(err)
+	}
+	if !validPostback(postback, user.PostbackSecret) {
+		return dto.Response[domains.Conversion]{
+			HttpCode: http.StatusUnauthorized,
+			Success:  false,
+			Code:     10003,
+			Message:  "Invalid postback secret or signature",
+		}, fmt.Errorf("invalid postback for click %s", postback.ClickId)
+	}
+
+	conversion := domains.Conversion{
+		UserId:     campaign.UserId,
+		OrderId:    postback.OrderId,
+		Status:     domains.ConversionApproved,
+		Source:     domains.ConversionSourcePostback,
+		SubIds:     []string{postback.ClickId},
+		LinkId:     uuid.NullUUID{UUID: link.Id, Valid: true},
+		CampaignId: uuid.NullUUID{UUID: link.CampaignId, Valid: true},
+		ProductId:  uuid.NullUUID{UUID: link.ProductId, Valid: true},
+		ClickId:    uuid.NullUUID{UUID: click.Id, Valid: true},
+		Currency:   strings.ToUpper(postback.Currency),
+		OrderedAt:  customtime.Now(),
+	}
+	if postback.Status != "" {
+		conversion.Status = postback.Status
+	}
+	// The numeric binding has already checked the amounts.
+	conversion.Commission, _ = strconv.ParseFloat(postback.Payout, 64)
+	conversion.Amount, _ = strconv.ParseFloat(postback.Amount, 64)
+	// Postbacks do not name the marketplace. It is the one the click was sent to or, for clicks
+	// sent elsewhere, that of the link's own offer, the product's primary one. A missing currency
+	// comes from the same offer.
+	conversion.Marketplace = click.Marketplace
+	offers, err := s.offerRepo.ListOffersByProductId(ctx, link.ProductId.String())
+	if err == nil && len(offers) > 0 {
+		offer, _ := primaryOffer(offers)
+		if i := slices.IndexFunc(offers, func(o domains.Offer) bool { return o.Marketplace == click.Marketplace }); i >= 0 {
+			offer = offers[i]
+		}
+		conversion.Marketplace = offer.Marketplace
+		if conversion.Currency == "" {
+			conversion.Currency = offer.Currency
+		}
+	}
+
+	if err := s.conversionRepo.SavePostbackConversion(ctx, conversion); err != nil {
+		return dto.Response[domains.Conversion]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     10004,
+			Message:  "Failed to record conversion",
+		}, err
+	}
+	return dto.Response[domains.Conversion]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Conversion recorded",
+		Data:     conversion,
+	}, nil
+}
+
+// validPostback accepts the postback when it carries secret, or is signed with it as described
+// by dto.PostbackRequest.SignedPayload. Users without a secret do not accept postbacks.
+func validPostback(postback dto.PostbackRequest, secret string) bool {
+	if secret == "" {
+		return false
+	}
+	if postback.Secret != "" {
+		return subtle.ConstantTimeCompare([]byte(postback.Secret), []byte(secret)) == 1
+	}
+	signature, err := hex.DecodeString(postback.Signature)
+	if err != nil || len(signature) == 0 {
+		return false
+	}
+	hash := hmac.New(sha256.New, []byte(secret))
+	hash.Write([]byte(postback.SignedPayload()))
+	return hmac.Equal(signature, hash.Sum(nil))
+}
+
+// failedPostbackClick does not tell an unknown click from a deleted link or campaign, since
+// neither can be posted back against.
+func failedPostbackClick(err error) (dto.Response[domains.Conversion], error) {
+	return dto.Response[domains.Conversion]{
+		HttpCode: http.StatusNotFound,
+		Success:  false,
+		Code:     10002,
+		Message:  "Click not found",
+	}, err
+}
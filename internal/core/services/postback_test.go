@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/market-place-affiliate/api/pkg/customtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type postbackMocks struct {
+	clickRepo      *mocks.MockClickRepository
+	linkRepo       *mocks.MockLinkRepository
+	campaignRepo   *mocks.MockCampaignRepository
+	offerRepo      *mocks.MockOfferRepository
+	userRepo       *mocks.MockUserRepository
+	conversionRepo *mocks.MockConversionRepository
+}
+
+// newPostbackFixture sets up a click on a link of a campaign of user 7, whose postback secret
+// is "s3cret". The link's product is offered on Lazada, its primary offer, and Shopee.
+func newPostbackFixture(ctx context.Context) (postbackMocks, domains.Click, domains.Link) {
+	m := postbackMocks{
+		clickRepo:      new(mocks.MockClickRepository),
+		linkRepo:       new(mocks.MockLinkRepository),
+		campaignRepo:   new(mocks.MockCampaignRepository),
+		offerRepo:      new(mocks.MockOfferRepository),
+		userRepo:       new(mocks.MockUserRepository),
+		conversionRepo: new(mocks.MockConversionRepository),
+	}
+	link := domains.Link{Id: uuid.Must(uuid.NewV4()), CampaignId: uuid.Must(uuid.NewV4()), ProductId: uuid.Must(uuid.NewV4())}
+	click := domains.Click{Id: uuid.Must(uuid.NewV7()), LinkId: link.Id}
+	m.clickRepo.On("GetClickById", ctx, click.Id.String()).Return(click, nil)
+	m.linkRepo.On("GetLinkById", ctx, link.Id.String()).Return(link, nil)
+	m.campaignRepo.On("GetCampaignById", ctx, link.CampaignId.String()).Return(domains.Campaign{Id: link.CampaignId, UserId: 7}, nil)
+	m.userRepo.On("GetUserByID", ctx, int64(7)).Return(domains.User{Id: 7, PostbackSecret: "s3cret"}, nil)
+	m.offerRepo.On("ListOffersByProductId", ctx, link.ProductId.String()).Return([]domains.Offer{
+		{Marketplace: "shopee", Currency: "MYR", CreatedAt: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{Marketplace: "lazada", Currency: "THB", CreatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil)
+	return m, click, link
+}
+
+func (m postbackMocks) service() ports.PostbackService {
+	return NewPostbackService(m.clickRepo, m.linkRepo, m.campaignRepo, m.offerRepo, m.userRepo, m.conversionRepo)
+}
+
+func TestRecordPostback_Signed(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC)
+	customtime.Now = func() time.Time { return now }
+	defer func() { customtime.Now = time.Now }()
+	m, click, link := newPostbackFixture(ctx)
+	postback := dto.PostbackRequest{ClickId: click.ClickId(), OrderId: "NW-1001", Payout: "42.50", Amount: "850"}
+	hash := hmac.New(sha256.New, []byte("s3cret"))
+	hash.Write([]byte("amount=850&click_id=" + click.ClickId() + "&order_id=NW-1001&payout=42.50"))
+	postback.Signature = hex.EncodeToString(hash.Sum(nil))
+	m.conversionRepo.On("SavePostbackConversion", ctx, domains.Conversion{
+		UserId:      7,
+		Marketplace: "lazada",
+		OrderId:     "NW-1001",
+		Status:      domains.ConversionApproved,
+		Source:      domains.ConversionSourcePostback,
+		SubIds:      []string{click.ClickId()},
+		LinkId:      uuid.NullUUID{UUID: link.Id, Valid: true},
+		CampaignId:  uuid.NullUUID{UUID: link.CampaignId, Valid: true},
+		ProductId:   uuid.NullUUID{UUID: link.ProductId, Valid: true},
+		ClickId:     uuid.NullUUID{UUID: click.Id, Valid: true},
+		Amount:      850,
+		Commission:  42.5,
+		Currency:    "THB",
+		OrderedAt:   now,
+	}).Return(nil)
+
+	result, err := m.service().RecordPostback(ctx, postback)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "Conversion recorded", result.Message)
+	assert.Equal(t, "NW-1001", result.Data.OrderId)
+	m.conversionRepo.AssertExpectations(t)
+}
+
+func TestRecordPostback_StatusChange(t *testing.T) {
+	ctx := context.Background()
+	m, click, _ := newPostbackFixture(ctx)
+	var saved []domains.Conversion
+	m.conversionRepo.On("SavePostbackConversion", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(1).(domains.Conversion))
+	}).Return(nil)
+
+	for _, status := range []string{domains.ConversionPending, domains.ConversionApproved} {
+		result, err := m.service().RecordPostback(ctx, dto.PostbackRequest{ClickId: click.ClickId(), OrderId: "NW-1001", Payout: "42.50", Currency: "usd", Status: status, Secret: "s3cret"})
+
+		assert.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, "Conversion recorded", result.Message)
+		assert.Equal(t, status, result.Data.Status)
+	}
+	if assert.Len(t, saved, 2) {
+		assert.Equal(t, domains.ConversionPending, saved[0].Status)
+		assert.Equal(t, domains.ConversionApproved, saved[1].Status)
+		for _, conversion := range saved {
+			assert.Equal(t, "NW-1001", conversion.OrderId)
+			assert.Equal(t, "lazada", conversion.Marketplace)
+			assert.Equal(t, "USD", conversion.Currency)
+			assert.Equal(t, 42.5, conversion.Commission)
+		}
+	}
+}
+
+func TestRecordPostback_ReroutedClick(t *testing.T) {
+	ctx := context.Background()
+	m, _, link := newPostbackFixture(ctx)
+	click := domains.Click{Id: uuid.Must(uuid.NewV7()), LinkId: link.Id, Marketplace: "shopee"}
+	m.clickRepo.On("GetClickById", ctx, click.Id.String()).Return(click, nil)
+	m.conversionRepo.On("SavePostbackConversion", ctx, mock.MatchedBy(func(c domains.Conversion) bool {
+		return c.Marketplace == "shopee" && c.Currency == "MYR"
+	})).Return(nil)
+
+	result, err := m.service().RecordPostback(ctx, dto.PostbackRequest{ClickId: click.ClickId(), OrderId: "SP-77", Secret: "s3cret"})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	m.conversionRepo.AssertExpectations(t)
+}
+
+func TestRecordPostback_Unauthorized(t *testing.T) {
+	ctx := context.Background()
+	m, click, _ := newPostbackFixture(ctx)
+
+	for _, postback := range []dto.PostbackRequest{
+		{ClickId: click.ClickId(), OrderId: "NW-1001", Secret: "wrong"},
+		{ClickId: click.ClickId(), OrderId: "NW-1001", Signature: "abcdef"},
+		{ClickId: click.ClickId(), OrderId: "NW-1001"},
+	} {
+		result, err := m.service().RecordPostback(ctx, postback)
+
+		assert.Error(t, err)
+		assert.Equal(t, 401, result.HttpCode)
+		assert.Equal(t, 10003, result.Code)
+	}
+	m.conversionRepo.AssertNotCalled(t, "SavePostbackConversion", mock.Anything, mock.Anything)
+}
+
+func TestRecordPostback_UnknownClick(t *testing.T) {
+	ctx := context.Background()
+	m, _, _ := newPostbackFixture(ctx)
+	unknown := uuid.Must(uuid.NewV7())
+	m.clickRepo.On("GetClickById", ctx, unknown.String()).Return(domains.Click{}, errors.New("record not found"))
+
+	result, err := m.service().RecordPostback(ctx, dto.PostbackRequest{ClickId: hex.EncodeToString(unknown.Bytes()), OrderId: "NW-1001", Secret: "s3cret"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 404, result.HttpCode)
+	assert.Equal(t, 10002, result.Code)
+
+	result, err = m.service().RecordPostback(ctx, dto.PostbackRequest{ClickId: "not-a-click", OrderId: "NW-1001", Secret: "s3cret"})
+
+	assert.Error(t, err)
+	assert.Equal(t, 400, result.HttpCode)
+	assert.Equal(t, 10001, result.Code)
+}
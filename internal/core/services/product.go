@@ -2,33 +2,33 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
-	"strconv"
 
+	"github.com/gofrs/uuid"
 	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/market-place-affiliate/api/internal/core/dto"
 	"github.com/market-place-affiliate/api/internal/core/ports"
 	"github.com/market-place-affiliate/api/pkg/customtime"
-	"github.com/market-place-affiliate/commonlib/lazada"
-	"github.com/market-place-affiliate/commonlib/shopee"
 )
 
 type productService struct {
 	productRepo    ports.ProductRepository
 	offerRepo      ports.OfferRepository
-	lazadaRepo     lazada.LazadaRepository
-	shopeeRepo     shopee.ShopeeRepository
+	marketplaces   ports.MarketplaceRegistry
+	urlResolver    ports.UrlResolver
 	marketCredRepo ports.MarketplaceRepository
 	linkRepo       ports.LinkRepository
 	clickRepo      ports.ClickRepository
 }
 
-func NewProductService(productRepo ports.ProductRepository, offerRepo ports.OfferRepository, lazadaRepo lazada.LazadaRepository, shopeeRepo shopee.ShopeeRepository, marketCredRepo ports.MarketplaceRepository, linkRepo ports.LinkRepository, clickRepo ports.ClickRepository) ports.ProductService {
+func NewProductService(productRepo ports.ProductRepository, offerRepo ports.OfferRepository, marketplaces ports.MarketplaceRegistry, urlResolver ports.UrlResolver, marketCredRepo ports.MarketplaceRepository, linkRepo ports.LinkRepository, clickRepo ports.ClickRepository) ports.ProductService {
 	return &productService{
 		productRepo:    productRepo,
 		offerRepo:      offerRepo,
-		lazadaRepo:     lazadaRepo,
-		shopeeRepo:     shopeeRepo,
+		marketplaces:   marketplaces,
+		urlResolver:    urlResolver,
 		marketCredRepo: marketCredRepo,
 		linkRepo:       linkRepo,
 		clickRepo:      clickRepo,
@@ -37,193 +37,277 @@ func NewProductService(productRepo ports.ProductRepository, offerRepo ports.Offe
 
 func (s *productService) CreateProduct(ctx context.Context, userId int64, product dto.CreateProductRequest) (dto.Response[[]domains.Product], error) {
 	resPProducts := []domains.Product{}
-	switch product.Marketplace {
-	case "lazada":
+	provider, err := s.marketplaces.Get(product.Marketplace)
+	if err != nil {
+		return dto.Response[[]domains.Product]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     2007,
+			Message:  "Unsupported marketplace",
+		}, err
+	}
 
-		cred, err := s.marketCredRepo.GetByUserIdAndPlatform(ctx, userId, "lazada")
-		if err != nil {
-			return dto.Response[[]domains.Product]{
-				HttpCode: http.StatusInternalServerError,
-				Success:  false,
-				Code:     2001,
-				Message:  "Failed to fetch marketplace credential",
-			}, err
-		}
+	// Share links like s.shopee.co.th/xxxx are expanded so providers only ever see product urls.
+	sourceUrl, err := s.urlResolver.Resolve(ctx, product.SourceUrl)
+	if err != nil {
+		return dto.Response[[]domains.Product]{
+			HttpCode: resolveErrorHttpCode(err),
+			Success:  false,
+			Code:     resolveErrorCode(err),
+			Message:  resolveErrorMessage(err),
+		}, err
+	}
+
+	cred, err := s.marketCredRepo.GetByUserIdAndPlatform(ctx, userId, provider.Name())
+	if err != nil {
+		return dto.Response[[]domains.Product]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     2001,
+			Message:  "Failed to fetch marketplace credential",
+		}, err
+	}
 
-		lazadaResp, err := s.lazadaRepo.GetBatchPromoteLink(lazada.LazadaCredentials{
-			AppKey:     cred.AppKey,
-			AppSecret:  cred.AppSecret,
-			SignMethod: "sha256",
-			UserToken:  cred.UserToken,
-		}, "url", product.SourceUrl, [6]string{})
+	marketProducts, err := provider.FetchProduct(ctx, cred, sourceUrl)
+	if err != nil {
+		return dto.Response[[]domains.Product]{
+			HttpCode: marketplaceErrorHttpCode(err),
+			Success:  false,
+			Code:     2001,
+			Message:  marketplaceErrorMessage(provider.Name(), err),
+		}, err
+	}
+
+	for _, marketProduct := range marketProducts {
+		prod := marketProduct.Product
+		prod.UserId = userId
+		prod.SourceUrl = sourceUrl
+
+		createdProd, err := s.productRepo.SaveProduct(ctx, prod)
 		if err != nil {
 			return dto.Response[[]domains.Product]{
 				HttpCode: http.StatusInternalServerError,
 				Success:  false,
 				Code:     2001,
-				Message:  "Failed to fetch product from lazada",
+				Message:  "Failed to save product",
 			}, err
 		}
-		if len(lazadaResp.Result.Data.URLBatchGetLinkInfoList) == 0 {
-			return dto.Response[[]domains.Product]{
-				HttpCode: http.StatusInternalServerError,
-				Success:  false,
-				Code:     2001,
-				Message:  "Failed to fetch product from lazada",
-			}, nil
-		}
+		resPProducts = append(resPProducts, createdProd)
 
-		for _, promote := range lazadaResp.Result.Data.URLBatchGetLinkInfoList {
-			lazadaProductFeed, err := s.lazadaRepo.GetProductFeed(lazada.LazadaCredentials{
-				AppKey:     cred.AppKey,
-				AppSecret:  cred.AppSecret,
-				SignMethod: "sha256",
-				UserToken:  cred.UserToken,
-			}, promote.ProductID, 1, 1)
+		for _, offer := range marketProduct.Offers {
+			offer.ProductId = createdProd.Id
+			offer.Marketplace = provider.Name()
+			if offer.Url == "" {
+				offer.Url = sourceUrl
+			}
+			if offer.Availability == "" {
+				offer.Availability = domains.OfferAvailable
+			}
+			offer.LastCheckedAt = customtime.Now()
+			err = s.offerRepo.SaveOffer(ctx, offer)
 			if err != nil {
 				return dto.Response[[]domains.Product]{
 					HttpCode: http.StatusInternalServerError,
 					Success:  false,
 					Code:     2001,
-					Message:  "Failed to fetch product from lazada",
+					Message:  "Failed to save offer",
 				}, err
 			}
-			if len(lazadaProductFeed.Result.Data) == 0 {
-				return dto.Response[[]domains.Product]{
-					HttpCode: http.StatusInternalServerError,
-					Success:  false,
-					Code:     2001,
-					Message:  "This product is not available for affiliation",
-				}, nil
-			}
-			for _, feed := range lazadaProductFeed.Result.Data {
-				prod := domains.Product{
-					Title:     feed.ProductName,
-					ImageUrl:  feed.Pictures[0],
-					UserId:    userId,
-					SourceUrl: product.SourceUrl,
-				}
-				storeName := feed.BrandName
-				if storeName == "" {
-					storeName = "Lazada Official Store"
-				}
-				offer := domains.Offer{
-					Marketplace:   product.Marketplace,
-					StoreName:     storeName,
-					Price:         feed.DiscountPrice,
-					LastCheckedAt: customtime.Now(),
-				}
+		}
+	}
 
-				createdProd, err := s.productRepo.SaveProduct(ctx, prod)
-				if err != nil {
-					return dto.Response[[]domains.Product]{
-						HttpCode: http.StatusInternalServerError,
-						Success:  false,
-						Code:     2001,
-						Message:  "Failed to save product",
-					}, err
-				}
-				resPProducts = append(resPProducts, createdProd)
-				offer.ProductId = createdProd.Id
+	return dto.Response[[]domains.Product]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Product created successfully",
+		Data:     resPProducts,
+	}, nil
+}
 
-				err = s.offerRepo.SaveOffer(ctx, offer)
-				if err != nil {
-					return dto.Response[[]domains.Product]{
-						HttpCode: http.StatusInternalServerError,
-						Success:  false,
-						Code:     2001,
-						Message:  "Failed to save offer",
-					}, err
-				}
-			}
+// RefreshProduct re-fetches a product from its marketplace with the owner's credentials and
+// updates it in place, so its links and clicks survive. Offers are matched by store name.
+func (s *productService) RefreshProduct(ctx context.Context, userId int64, productId string) (dto.Response[dto.ProductRefreshResponse], error) {
+	product, err := s.productRepo.GetProductById(ctx, productId)
+	if err != nil {
+		return dto.Response[dto.ProductRefreshResponse]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     2002,
+			Message:  "Failed to fetch product",
+		}, err
+	}
+	if product.UserId != userId {
+		return dto.Response[dto.ProductRefreshResponse]{
+			HttpCode: http.StatusForbidden,
+			Success:  false,
+			Code:     2003,
+			Message:  "You do not have access to refresh this product",
+		}, nil
+	}
+	offers, err := s.offerRepo.ListOffersByProductId(ctx, productId)
+	if err != nil {
+		return dto.Response[dto.ProductRefreshResponse]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     2004,
+			Message:  "Failed to fetch offers",
+		}, err
+	}
+	if len(offers) == 0 {
+		return dto.Response[dto.ProductRefreshResponse]{
+			HttpCode: http.StatusUnprocessableEntity,
+			Success:  false,
+			Code:     2012,
+			Message:  "Product has no marketplace offer to refresh from",
+		}, fmt.Errorf("product %s has no offers", productId)
+	}
+
+	primary, _ := primaryOffer(offers)
+	provider, err := s.marketplaces.Get(primary.Marketplace)
+	if err != nil {
+		return dto.Response[dto.ProductRefreshResponse]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     2007,
+			Message:  "Unsupported marketplace",
+		}, err
+	}
+	cred, err := s.marketCredRepo.GetByUserIdAndPlatform(ctx, userId, provider.Name())
+	if err != nil {
+		return dto.Response[dto.ProductRefreshResponse]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     2001,
+			Message:  "Failed to fetch marketplace credential",
+		}, err
+	}
+
+	// A delisted product keeps its details; every offer is marked removed below.
+	fetched := dto.MarketplaceProduct{Product: product}
+	marketProducts, err := provider.FetchProduct(ctx, cred, product.SourceUrl)
+	switch {
+	case errors.Is(err, ports.ErrProductNotAvailable):
+	case err != nil:
+		return dto.Response[dto.ProductRefreshResponse]{
+			HttpCode: marketplaceErrorHttpCode(err),
+			Success:  false,
+			Code:     2001,
+			Message:  marketplaceErrorMessage(provider.Name(), err),
+		}, err
+	case len(marketProducts) > 0:
+		fetched = refreshedProduct(marketProducts, product, offers, provider.Name())
+	}
+
+	// Details the marketplace did not send this time are kept rather than blanked.
+	refreshed := product
+	if fetched.Product.Title != "" {
+		refreshed.Title = fetched.Product.Title
+	}
+	if fetched.Product.ImageUrl != "" {
+		refreshed.ImageUrl = fetched.Product.ImageUrl
+	}
+	if fetched.Product.Category != "" {
+		refreshed.Category = fetched.Product.Category
+	}
+	refreshed.Images = fetched.Product.Images
+	result := dto.ProductRefreshResponse{
+		Changes:       productChanges(product, refreshed),
+		OffersAdded:   []domains.Offer{},
+		OffersUpdated: []dto.OfferChange{},
+		OffersRemoved: []domains.Offer{},
+	}
+	images := product.Images
+	tags := product.Tags
+	product.Title = refreshed.Title
+	product.ImageUrl = refreshed.ImageUrl
+	product.Category = refreshed.Category
+	product.Images = nil
+	product.Tags = nil
+	product, err = s.productRepo.SaveProduct(ctx, product)
+	if err == nil && imagesChanged(images, fetched.Product.Images) {
+		images = []domains.ProductImage{}
+		for _, image := range fetched.Product.Images {
+			image.ProductId = product.Id
+			images = append(images, image)
 		}
-	case "shopee":
-		shopId, ItemId, err := shopee.ExtractShopIdAndItemIdFromLink(product.SourceUrl)
-		if err != nil {
-			return dto.Response[[]domains.Product]{
-				HttpCode: http.StatusInternalServerError,
-				Success:  false,
-				Code:     http.StatusInternalServerError,
-				Message:  "Failed to fetch product from shopee",
-			}, err
+		err = s.productRepo.ReplaceProductImages(ctx, productId, images)
+	}
+	if err != nil {
+		return dto.Response[dto.ProductRefreshResponse]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     2013,
+			Message:  "Failed to update product",
+		}, err
+	}
+	product.Images = images
+	product.Tags = tags
+	result.Product = product
+
+	now := customtime.Now()
+	seen := map[uuid.UUID]bool{}
+	for _, fetchedOffer := range fetched.Offers {
+		fetchedOffer.ProductId = product.Id
+		fetchedOffer.Marketplace = provider.Name()
+		fetchedOffer.LastCheckedAt = now
+		if fetchedOffer.Url == "" {
+			fetchedOffer.Url = product.SourceUrl
 		}
-		cred, err := s.marketCredRepo.GetByUserIdAndPlatform(ctx, userId, "shopee")
-		if err != nil {
-			return dto.Response[[]domains.Product]{
-				HttpCode: http.StatusInternalServerError,
-				Success:  false,
-				Code:     2001,
-				Message:  "Failed to fetch marketplace credential",
-			}, err
+		if fetchedOffer.Availability == "" {
+			fetchedOffer.Availability = domains.OfferAvailable
+		}
+		existing, ok := matchOffer(offers, fetchedOffer, seen)
+		if ok {
+			seen[existing.Id] = true
+			fetchedOffer.Id = existing.Id
+			fetchedOffer.CreatedAt = existing.CreatedAt
+			if changes := offerChanges(existing, fetchedOffer); len(changes) > 0 {
+				result.OffersUpdated = append(result.OffersUpdated, dto.OfferChange{
+					OfferId:   existing.Id,
+					StoreName: existing.StoreName,
+					Changes:   changes,
+				})
+			}
+		} else {
+			result.OffersAdded = append(result.OffersAdded, fetchedOffer)
 		}
-		shoppeeResp, err := s.shopeeRepo.GetProductOfferListV2(shopee.ShopeeCredentials{
-			AppId:     cred.AppId,
-			AppSecret: cred.AppSecret,
-		}, shopId, ItemId)
+		err = s.offerRepo.SaveOffer(ctx, fetchedOffer)
 		if err != nil {
-			return dto.Response[[]domains.Product]{
+			return dto.Response[dto.ProductRefreshResponse]{
 				HttpCode: http.StatusInternalServerError,
 				Success:  false,
-				Code:     http.StatusInternalServerError,
-				Message:  "Failed to fetch product from shopee",
+				Code:     2013,
+				Message:  "Failed to save offer",
 			}, err
 		}
-
-		if len(shoppeeResp.Data.ProductOfferV2.Nodes) == 0 {
-			return dto.Response[[]domains.Product]{
-				HttpCode: http.StatusInternalServerError,
-				Success:  false,
-				Code:     http.StatusInternalServerError,
-				Message:  "Failed to fetch product from shopee",
-			}, nil
+	}
+	for _, offer := range offers {
+		if seen[offer.Id] {
+			continue
 		}
-
-		prod := domains.Product{
-			Title:     shoppeeResp.Data.ProductOfferV2.Nodes[0].ProductName,
-			ImageUrl:  shoppeeResp.Data.ProductOfferV2.Nodes[0].ImageURL,
-			UserId:    userId,
-			SourceUrl: product.SourceUrl,
+		offer.LastCheckedAt = now
+		if offer.Availability != domains.OfferDelisted {
+			offer.Availability = domains.OfferDelisted
+			result.OffersRemoved = append(result.OffersRemoved, offer)
 		}
-
-		createdProd, err := s.productRepo.SaveProduct(ctx, prod)
+		err = s.offerRepo.SaveOffer(ctx, offer)
 		if err != nil {
-			return dto.Response[[]domains.Product]{
+			return dto.Response[dto.ProductRefreshResponse]{
 				HttpCode: http.StatusInternalServerError,
 				Success:  false,
-				Code:     2001,
-				Message:  "Failed to save product",
+				Code:     2013,
+				Message:  "Failed to save offer",
 			}, err
 		}
-		resPProducts = append(resPProducts, createdProd)
-
-		for _, offer := range shoppeeResp.Data.ProductOfferV2.Nodes {
-			price, _ := strconv.ParseFloat(offer.Price, 64)
-			offer := domains.Offer{
-				ProductId:     createdProd.Id,
-				Marketplace:   product.Marketplace,
-				StoreName:     offer.ShopName,
-				Price:         price,
-				LastCheckedAt: customtime.Now(),
-			}
-			err = s.offerRepo.SaveOffer(ctx, offer)
-			if err != nil {
-				return dto.Response[[]domains.Product]{
-					HttpCode: http.StatusInternalServerError,
-					Success:  false,
-					Code:     2001,
-					Message:  "Failed to save offer",
-				}, err
-			}
-		}
-
 	}
 
-	return dto.Response[[]domains.Product]{
+	return dto.Response[dto.ProductRefreshResponse]{
 		HttpCode: http.StatusOK,
 		Success:  true,
 		Code:     0,
-		Message:  "Product created successfully",
-		Data:     resPProducts,
+		Message:  "Product refreshed successfully",
+		Data:     result,
 	}, nil
 }
 
@@ -263,8 +347,14 @@ func (s *productService) GetOffer(ctx context.Context, userId int64, productId s
 	}, nil
 }
 
-func (s *productService) GetProductsByUserId(ctx context.Context, userId int64) (dto.Response[[]domains.Product], error) {
-	products, err := s.productRepo.GetAllProducts(ctx, userId)
+func (s *productService) GetProductsByUserId(ctx context.Context, userId int64, query dto.GetProductsQueryRequest) (dto.Response[[]domains.Product], error) {
+	var products []domains.Product
+	var err error
+	if query.TagId == "" && query.CollectionId == "" {
+		products, err = s.productRepo.GetAllProducts(ctx, userId)
+	} else {
+		products, err = s.productRepo.GetProductsByQuery(ctx, userId, query)
+	}
 	if err != nil {
 		return dto.Response[[]domains.Product]{
 			HttpCode: http.StatusInternalServerError,
@@ -288,6 +378,53 @@ func (s *productService) GetProductsByUserId(ctx context.Context, userId int64)
 	}, nil
 }
 
+func (s *productService) GetUnavailableProducts(ctx context.Context, userId int64) (dto.Response[[]dto.UnavailableProduct], error) {
+	products, err := s.productRepo.GetUnavailableProducts(ctx, userId)
+	if err != nil {
+		return dto.Response[[]dto.UnavailableProduct]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     2011,
+			Message:  "Failed to fetch unavailable products",
+		}, err
+	}
+	report := []dto.UnavailableProduct{}
+	for _, product := range products {
+		offers, err := s.offerRepo.ListOffersByProductId(ctx, product.Id.String())
+		if err != nil {
+			return dto.Response[[]dto.UnavailableProduct]{
+				HttpCode: http.StatusInternalServerError,
+				Success:  false,
+				Code:     2004,
+				Message:  "Failed to fetch offers",
+			}, err
+		}
+		links, err := s.linkRepo.GetLinksByProductId(ctx, product.Id.String())
+		if err != nil {
+			return dto.Response[[]dto.UnavailableProduct]{
+				HttpCode: http.StatusInternalServerError,
+				Success:  false,
+				Code:     2006,
+				Message:  "Failed to fetch links associated with the product",
+			}, err
+		}
+		report = append(report, dto.UnavailableProduct{
+			Product:   product,
+			Offers:    offers,
+			LinkCount: len(links),
+		})
+	}
+	return dto.Response[[]dto.UnavailableProduct]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Unavailable products fetched successfully",
+		Data:     report,
+	}, nil
+}
+
+// DeleteProductById soft deletes the product and its links. It can be restored until the
+// retention period has passed.
 func (s *productService) DeleteProductById(ctx context.Context, userId int64, productId string) (dto.Response[any], error) {
 	product, err := s.productRepo.GetProductById(ctx, productId)
 	if err != nil {
@@ -307,60 +444,65 @@ func (s *productService) DeleteProductById(ctx context.Context, userId int64, pr
 		}, nil
 	}
 
-	links, err := s.linkRepo.GetLinksByProductId(ctx, productId)
+	err = s.productRepo.DeleteProductById(ctx, productId)
 	if err != nil {
 		return dto.Response[any]{
 			HttpCode: http.StatusInternalServerError,
 			Success:  false,
 			Code:     2006,
-			Message:  "Failed to fetch links associated with the product",
+			Message:  "Failed to delete product",
 		}, err
 	}
+	return dto.Response[any]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Product deleted successfully",
+	}, nil
+}
 
-	for _, link := range links {
-		err = s.clickRepo.DeleteClicksByLinkId(ctx, link.Id.String())
-		if err != nil {
-			return dto.Response[any]{
-				HttpCode: http.StatusInternalServerError,
-				Success:  false,
-				Code:     2006,
-				Message:  "Failed to delete clicks associated with the product links",
-			}, err
-		}
-	}
-
-	err = s.linkRepo.DeleteLinkByProductId(ctx, productId)
+func (s *productService) RestoreProduct(ctx context.Context, userId int64, productId string) (dto.Response[domains.Product], error) {
+	product, err := s.productRepo.GetProductByIdWithDeleted(ctx, productId)
 	if err != nil {
-		return dto.Response[any]{
+		return dto.Response[domains.Product]{
 			HttpCode: http.StatusInternalServerError,
 			Success:  false,
-			Code:     2006,
-			Message:  "Failed to delete links associated with the product",
+			Code:     2002,
+			Message:  "Failed to fetch product",
 		}, err
 	}
-	err = s.offerRepo.DeleteOfferByProductId(ctx, productId)
-	if err != nil {
-		return dto.Response[any]{
-			HttpCode: http.StatusInternalServerError,
+	if product.UserId != userId {
+		return dto.Response[domains.Product]{
+			HttpCode: http.StatusForbidden,
 			Success:  false,
-			Code:     2006,
-			Message:  "Failed to delete offers associated with the product",
-		}, err
+			Code:     2003,
+			Message:  "You do not have access to restore this product",
+		}, nil
 	}
-	err = s.productRepo.DeleteProductById(ctx, productId)
+	if product.DeletedAt == nil {
+		return dto.Response[domains.Product]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     2014,
+			Message:  "Product is not deleted",
+		}, errors.New("product is not deleted")
+	}
+	err = s.productRepo.RestoreProduct(ctx, productId)
 	if err != nil {
-		return dto.Response[any]{
+		return dto.Response[domains.Product]{
 			HttpCode: http.StatusInternalServerError,
 			Success:  false,
-			Code:     2006,
-			Message:  "Failed to delete product",
+			Code:     2015,
+			Message:  "Failed to restore product",
 		}, err
 	}
-	return dto.Response[any]{
+	product.DeletedAt = nil
+	return dto.Response[domains.Product]{
 		HttpCode: http.StatusOK,
 		Success:  true,
 		Code:     0,
-		Message:  "Product deleted successfully",
+		Message:  "Product restored successfully",
+		Data:     product,
 	}, nil
 }
 
@@ -381,4 +523,104 @@ func (s *productService) GetProductById(ctx context.Context, productId string) (
 		Message:  "Product fetched successfully",
 		Data:     product,
 	}, nil
-}
\ No newline at end of file
+}
+
+// refreshedProduct picks, among the products found at a product's source url, the one that is
+// the stored product: the one selling through a store an offer of the product on marketplace
+// has, or else the one with the same title. The first product is taken when none is.
+func refreshedProduct(marketProducts []dto.MarketplaceProduct, product domains.Product, offers []domains.Offer, marketplace string) dto.MarketplaceProduct {
+	for _, marketProduct := range marketProducts {
+		for _, fetchedOffer := range marketProduct.Offers {
+			fetchedOffer.Marketplace = marketplace
+			if _, ok := matchOffer(offers, fetchedOffer, nil); ok {
+				return marketProduct
+			}
+		}
+	}
+	for _, marketProduct := range marketProducts {
+		if marketProduct.Product.Title == product.Title {
+			return marketProduct
+		}
+	}
+	return marketProducts[0]
+}
+
+// matchOffer finds the stored offer from the same store that has not been matched yet.
+func matchOffer(offers []domains.Offer, fetched domains.Offer, seen map[uuid.UUID]bool) (domains.Offer, bool) {
+	for _, offer := range offers {
+		if !seen[offer.Id] && offer.Marketplace == fetched.Marketplace && offer.StoreName == fetched.StoreName {
+			return offer, true
+		}
+	}
+	return domains.Offer{}, false
+}
+
+func productChanges(old, new domains.Product) []dto.FieldChange {
+	changes := []dto.FieldChange{}
+	if old.Title != new.Title {
+		changes = append(changes, dto.FieldChange{Field: "title", Old: old.Title, New: new.Title})
+	}
+	if old.ImageUrl != new.ImageUrl {
+		changes = append(changes, dto.FieldChange{Field: "image_url", Old: old.ImageUrl, New: new.ImageUrl})
+	}
+	if old.Category != new.Category {
+		changes = append(changes, dto.FieldChange{Field: "category", Old: old.Category, New: new.Category})
+	}
+	if imagesChanged(old.Images, new.Images) {
+		changes = append(changes, dto.FieldChange{Field: "images", Old: imageUrls(old.Images), New: imageUrls(new.Images)})
+	}
+	return changes
+}
+
+// imagesChanged reports whether the gallery differs. An empty fetched gallery keeps the old one.
+func imagesChanged(old, new []domains.ProductImage) bool {
+	if len(new) == 0 {
+		return false
+	}
+	if len(old) != len(new) {
+		return true
+	}
+	for i := range old {
+		if old[i].Url != new[i].Url {
+			return true
+		}
+	}
+	return false
+}
+
+func imageUrls(images []domains.ProductImage) []string {
+	urls := []string{}
+	for _, image := range images {
+		urls = append(urls, image.Url)
+	}
+	return urls
+}
+
+func offerChanges(old, new domains.Offer) []dto.FieldChange {
+	changes := []dto.FieldChange{}
+	if old.Price != new.Price {
+		changes = append(changes, dto.FieldChange{Field: "price", Old: old.Price, New: new.Price})
+	}
+	if old.CommissionRate != new.CommissionRate {
+		changes = append(changes, dto.FieldChange{Field: "commission_rate", Old: old.CommissionRate, New: new.CommissionRate})
+	}
+	if old.Commission != new.Commission {
+		changes = append(changes, dto.FieldChange{Field: "commission", Old: old.Commission, New: new.Commission})
+	}
+	if old.RatingStar != new.RatingStar {
+		changes = append(changes, dto.FieldChange{Field: "rating_star", Old: old.RatingStar, New: new.RatingStar})
+	}
+	if old.Sales != new.Sales {
+		changes = append(changes, dto.FieldChange{Field: "sales", Old: old.Sales, New: new.Sales})
+	}
+	if old.Availability != new.Availability {
+		changes = append(changes, dto.FieldChange{Field: "availability", Old: old.Availability, New: new.Availability})
+	}
+	if old.Url != new.Url {
+		changes = append(changes, dto.FieldChange{Field: "url", Old: old.Url, New: new.Url})
+	}
+	if old.Currency != new.Currency {
+		changes = append(changes, dto.FieldChange{Field: "currency", Old: old.Currency, New: new.Currency})
+	}
+	return changes
+}
@@ -2,12 +2,18 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
 	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/market-place-affiliate/commonlib/lazada"
+	"github.com/market-place-affiliate/commonlib/shopee"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetOffer_Success(t *testing.T) {
@@ -19,7 +25,7 @@ func TestGetOffer_Success(t *testing.T) {
 	mockLinkRepo := new(mocks.MockLinkRepository)
 	mockClickRepo := new(mocks.MockClickRepository)
 
-	service := NewProductService(mockProductRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo, mockLinkRepo, mockClickRepo)
+	service := NewProductService(mockProductRepo, mockOfferRepo, testMarketplaces(mockLazadaRepo, mockShopeeRepo), new(mocks.MockUrlResolver), mockMarketCredRepo, mockLinkRepo, mockClickRepo)
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -60,7 +66,7 @@ func TestGetOffer_Forbidden(t *testing.T) {
 	mockLinkRepo := new(mocks.MockLinkRepository)
 	mockClickRepo := new(mocks.MockClickRepository)
 
-	service := NewProductService(mockProductRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo, mockLinkRepo, mockClickRepo)
+	service := NewProductService(mockProductRepo, mockOfferRepo, testMarketplaces(mockLazadaRepo, mockShopeeRepo), new(mocks.MockUrlResolver), mockMarketCredRepo, mockLinkRepo, mockClickRepo)
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -92,7 +98,7 @@ func TestGetProductsByUserId_Success(t *testing.T) {
 	mockLinkRepo := new(mocks.MockLinkRepository)
 	mockClickRepo := new(mocks.MockClickRepository)
 
-	service := NewProductService(mockProductRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo, mockLinkRepo, mockClickRepo)
+	service := NewProductService(mockProductRepo, mockOfferRepo, testMarketplaces(mockLazadaRepo, mockShopeeRepo), new(mocks.MockUrlResolver), mockMarketCredRepo, mockLinkRepo, mockClickRepo)
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -112,7 +118,7 @@ func TestGetProductsByUserId_Success(t *testing.T) {
 
 	mockProductRepo.On("GetAllProducts", ctx, userId).Return(products, nil)
 
-	result, err := service.GetProductsByUserId(ctx, userId)
+	result, err := service.GetProductsByUserId(ctx, userId, dto.GetProductsQueryRequest{})
 
 	assert.NoError(t, err)
 	assert.True(t, result.Success)
@@ -130,12 +136,11 @@ func TestDeleteProductById_Success(t *testing.T) {
 	mockLinkRepo := new(mocks.MockLinkRepository)
 	mockClickRepo := new(mocks.MockClickRepository)
 
-	service := NewProductService(mockProductRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo, mockLinkRepo, mockClickRepo)
+	service := NewProductService(mockProductRepo, mockOfferRepo, testMarketplaces(mockLazadaRepo, mockShopeeRepo), new(mocks.MockUrlResolver), mockMarketCredRepo, mockLinkRepo, mockClickRepo)
 
 	ctx := context.Background()
 	userId := int64(1)
 	productId := uuid.Must(uuid.NewV4())
-	linkId := uuid.Must(uuid.NewV4())
 
 	product := domains.Product{
 		Id:     productId,
@@ -143,18 +148,7 @@ func TestDeleteProductById_Success(t *testing.T) {
 		Title:  "Test Product",
 	}
 
-	links := []domains.Link{
-		{
-			Id:        linkId,
-			ProductId: productId,
-		},
-	}
-
 	mockProductRepo.On("GetProductById", ctx, productId.String()).Return(product, nil)
-	mockLinkRepo.On("GetLinksByProductId", ctx, productId.String()).Return(links, nil)
-	mockClickRepo.On("DeleteClicksByLinkId", ctx, linkId.String()).Return(nil)
-	mockLinkRepo.On("DeleteLinkByProductId", ctx, productId.String()).Return(nil)
-	mockOfferRepo.On("DeleteOfferByProductId", ctx, productId.String()).Return(nil)
 	mockProductRepo.On("DeleteProductById", ctx, productId.String()).Return(nil)
 
 	result, err := service.DeleteProductById(ctx, userId, productId.String())
@@ -163,9 +157,8 @@ func TestDeleteProductById_Success(t *testing.T) {
 	assert.True(t, result.Success)
 	assert.Equal(t, 0, result.Code)
 	mockProductRepo.AssertExpectations(t)
-	mockLinkRepo.AssertExpectations(t)
-	mockClickRepo.AssertExpectations(t)
-	mockOfferRepo.AssertExpectations(t)
+	// Offers and clicks are kept for restore and historical analytics.
+	mockOfferRepo.AssertNotCalled(t, "DeleteOfferByProductId", mock.Anything, mock.Anything)
 }
 
 func TestDeleteProductById_Forbidden(t *testing.T) {
@@ -177,7 +170,7 @@ func TestDeleteProductById_Forbidden(t *testing.T) {
 	mockLinkRepo := new(mocks.MockLinkRepository)
 	mockClickRepo := new(mocks.MockClickRepository)
 
-	service := NewProductService(mockProductRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo, mockLinkRepo, mockClickRepo)
+	service := NewProductService(mockProductRepo, mockOfferRepo, testMarketplaces(mockLazadaRepo, mockShopeeRepo), new(mocks.MockUrlResolver), mockMarketCredRepo, mockLinkRepo, mockClickRepo)
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -209,7 +202,7 @@ func TestGetProductById_Success(t *testing.T) {
 	mockLinkRepo := new(mocks.MockLinkRepository)
 	mockClickRepo := new(mocks.MockClickRepository)
 
-	service := NewProductService(mockProductRepo, mockOfferRepo, mockLazadaRepo, mockShopeeRepo, mockMarketCredRepo, mockLinkRepo, mockClickRepo)
+	service := NewProductService(mockProductRepo, mockOfferRepo, testMarketplaces(mockLazadaRepo, mockShopeeRepo), new(mocks.MockUrlResolver), mockMarketCredRepo, mockLinkRepo, mockClickRepo)
 
 	ctx := context.Background()
 	productId := uuid.Must(uuid.NewV4())
@@ -230,3 +223,251 @@ func TestGetProductById_Success(t *testing.T) {
 	assert.Equal(t, product, result.Data)
 	mockProductRepo.AssertExpectations(t)
 }
+
+func TestCreateProduct_Shopee_StoresMetadata(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockOfferRepo := new(mocks.MockOfferRepository)
+	mockLazadaRepo := new(mocks.MockLazadaRepository)
+	mockShopeeRepo := new(mocks.MockShopeeRepository)
+	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	mockClickRepo := new(mocks.MockClickRepository)
+	mockUrlResolver := new(mocks.MockUrlResolver)
+
+	service := NewProductService(mockProductRepo, mockOfferRepo, testMarketplaces(mockLazadaRepo, mockShopeeRepo), mockUrlResolver, mockMarketCredRepo, mockLinkRepo, mockClickRepo)
+
+	ctx := context.Background()
+	userId := int64(1)
+	productId := uuid.Must(uuid.NewV4())
+	sourceUrl := "https://shopee.co.th/Test-Product-i.123.456"
+	mockUrlResolver.On("Resolve", ctx, sourceUrl).Return(sourceUrl, nil)
+
+	request := dto.CreateProductRequest{
+		SourceUrl:   sourceUrl,
+		Marketplace: "shopee",
+	}
+
+	credential := domains.MarketplaceCredential{
+		UserId:      userId,
+		Marketplace: "shopee",
+		AppId:       "test_app_id",
+		AppSecret:   "test_secret",
+	}
+
+	var shopeeResp shopee.ShopeeGetProductOfferList
+	err := json.Unmarshal([]byte(`{"data":{"productOfferV2":{"nodes":[
+		{"productName":"Test Product","imageUrl":"https://cf.shopee.co.th/a.jpg","shopName":"Shop A","price":"199.00","commissionRate":"0.08","commission":"15.92","ratingStar":"4.8","sales":1200,"productCatIds":[100001,100017,0]},
+		{"productName":"Test Product","imageUrl":"https://cf.shopee.co.th/b.jpg","shopName":"Shop B","price":"189.00","commissionRate":"0.05","commission":"9.45","ratingStar":"4.5","sales":300,"productCatIds":[100001,100017,0]}
+	]}}}`), &shopeeResp)
+	assert.NoError(t, err)
+
+	mockMarketCredRepo.On("GetByUserIdAndPlatform", ctx, userId, "shopee").Return(credential, nil)
+	mockShopeeRepo.On("GetProductOfferListV2", mock.AnythingOfType("shopee.ShopeeCredentials"), "123", "456").Return(shopeeResp, nil)
+	mockProductRepo.On("SaveProduct", ctx, mock.MatchedBy(func(p domains.Product) bool {
+		return p.Category == "100001/100017" &&
+			len(p.Images) == 2 &&
+			p.Images[1].Url == "https://cf.shopee.co.th/b.jpg" &&
+			p.Images[1].Position == 1
+	})).Return(domains.Product{Id: productId, UserId: userId, Title: "Test Product"}, nil)
+	mockOfferRepo.On("SaveOffer", ctx, mock.MatchedBy(func(o domains.Offer) bool {
+		return o.StoreName == "Shop A" && o.CommissionRate == 0.08 && o.Commission == 15.92 && o.RatingStar == 4.8 && o.Sales == 1200
+	})).Return(nil)
+	mockOfferRepo.On("SaveOffer", ctx, mock.MatchedBy(func(o domains.Offer) bool {
+		return o.StoreName == "Shop B" && o.CommissionRate == 0.05 && o.Sales == 300
+	})).Return(nil)
+
+	result, err := service.CreateProduct(ctx, userId, request)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, 1, len(result.Data))
+	mockProductRepo.AssertExpectations(t)
+	mockOfferRepo.AssertExpectations(t)
+}
+
+func TestGetUnavailableProducts_Success(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockOfferRepo := new(mocks.MockOfferRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+
+	service := NewProductService(mockProductRepo, mockOfferRepo, testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)), new(mocks.MockUrlResolver), new(mocks.MockMarketplaceRepository), mockLinkRepo, new(mocks.MockClickRepository))
+
+	ctx := context.Background()
+	userId := int64(1)
+	productId := uuid.Must(uuid.NewV4())
+	offers := []domains.Offer{{ProductId: productId, Availability: domains.OfferOutOfStock}}
+
+	mockProductRepo.On("GetUnavailableProducts", ctx, userId).Return([]domains.Product{{Id: productId, UserId: userId}}, nil)
+	mockOfferRepo.On("ListOffersByProductId", ctx, productId.String()).Return(offers, nil)
+	mockLinkRepo.On("GetLinksByProductId", ctx, productId.String()).Return([]domains.Link{{}, {}}, nil)
+
+	result, err := service.GetUnavailableProducts(ctx, userId)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Len(t, result.Data, 1)
+	assert.Equal(t, offers, result.Data[0].Offers)
+	assert.Equal(t, 2, result.Data[0].LinkCount)
+}
+
+func TestRefreshProduct_Success(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockOfferRepo := new(mocks.MockOfferRepository)
+	mockShopeeRepo := new(mocks.MockShopeeRepository)
+	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
+
+	service := NewProductService(mockProductRepo, mockOfferRepo, testMarketplaces(new(mocks.MockLazadaRepository), mockShopeeRepo), new(mocks.MockUrlResolver), mockMarketCredRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository))
+
+	ctx := context.Background()
+	userId := int64(1)
+	productId := uuid.Must(uuid.NewV4())
+	shopAId := uuid.Must(uuid.NewV4())
+	shopCId := uuid.Must(uuid.NewV4())
+	product := domains.Product{
+		Id:        productId,
+		UserId:    userId,
+		Title:     "Old Title",
+		ImageUrl:  "https://cf.shopee.co.th/a.jpg",
+		Images:    []domains.ProductImage{{Url: "https://cf.shopee.co.th/a.jpg"}},
+		Category:  "100001/100017",
+		SourceUrl: "https://shopee.co.th/Test-Product-i.123.456",
+	}
+	offers := []domains.Offer{
+		{Id: shopAId, ProductId: productId, Marketplace: "shopee", StoreName: "Shop A", Price: 199, Availability: domains.OfferAvailable, Url: "https://shope.ee/a"},
+		{Id: shopCId, ProductId: productId, Marketplace: "shopee", StoreName: "Shop C", Price: 210, Availability: domains.OfferAvailable},
+	}
+
+	var shopeeResp shopee.ShopeeGetProductOfferList
+	err := json.Unmarshal([]byte(`{"data":{"productOfferV2":{"nodes":[
+		{"productName":"New Title","imageUrl":"https://cf.shopee.co.th/a.jpg","shopName":"Shop A","price":"179.00","offerLink":"https://shope.ee/a","productCatIds":[100001,100017]},
+		{"productName":"New Title","imageUrl":"https://cf.shopee.co.th/b.jpg","shopName":"Shop B","price":"189.00","offerLink":"https://shope.ee/b","productCatIds":[100001,100017]}
+	]}}}`), &shopeeResp)
+	assert.NoError(t, err)
+
+	mockProductRepo.On("GetProductById", ctx, productId.String()).Return(product, nil)
+	mockOfferRepo.On("ListOffersByProductId", ctx, productId.String()).Return(offers, nil)
+	mockMarketCredRepo.On("GetByUserIdAndPlatform", ctx, userId, "shopee").Return(domains.MarketplaceCredential{AppId: "app", AppSecret: "secret"}, nil)
+	mockShopeeRepo.On("GetProductOfferListV2", mock.AnythingOfType("shopee.ShopeeCredentials"), "123", "456").Return(shopeeResp, nil)
+	mockProductRepo.On("SaveProduct", ctx, mock.MatchedBy(func(p domains.Product) bool {
+		return p.Id == productId && p.Title == "New Title" && p.Images == nil
+	})).Return(domains.Product{Id: productId, UserId: userId, Title: "New Title"}, nil)
+	mockProductRepo.On("ReplaceProductImages", ctx, productId.String(), mock.MatchedBy(func(images []domains.ProductImage) bool {
+		return len(images) == 2 && images[1].Url == "https://cf.shopee.co.th/b.jpg" && images[1].ProductId == productId
+	})).Return(nil)
+	mockOfferRepo.On("SaveOffer", ctx, mock.MatchedBy(func(o domains.Offer) bool {
+		return o.Id == shopAId && o.Price == 179
+	})).Return(nil)
+	mockOfferRepo.On("SaveOffer", ctx, mock.MatchedBy(func(o domains.Offer) bool {
+		return o.Id == uuid.Nil && o.StoreName == "Shop B"
+	})).Return(nil)
+	mockOfferRepo.On("SaveOffer", ctx, mock.MatchedBy(func(o domains.Offer) bool {
+		return o.Id == shopCId && o.Availability == domains.OfferDelisted
+	})).Return(nil)
+
+	result, err := service.RefreshProduct(ctx, userId, productId.String())
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, []string{"title", "images"}, []string{result.Data.Changes[0].Field, result.Data.Changes[1].Field})
+	assert.Len(t, result.Data.OffersAdded, 1)
+	assert.Len(t, result.Data.OffersUpdated, 1)
+	assert.Equal(t, "price", result.Data.OffersUpdated[0].Changes[0].Field)
+	assert.Len(t, result.Data.OffersRemoved, 1)
+	assert.Equal(t, shopCId, result.Data.OffersRemoved[0].Id)
+	mockProductRepo.AssertExpectations(t)
+	mockOfferRepo.AssertExpectations(t)
+}
+
+func TestRefreshProduct_MatchesStoredProduct(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockOfferRepo := new(mocks.MockOfferRepository)
+	mockLazadaRepo := new(mocks.MockLazadaRepository)
+	mockMarketCredRepo := new(mocks.MockMarketplaceRepository)
+
+	service := NewProductService(mockProductRepo, mockOfferRepo, testMarketplaces(mockLazadaRepo, new(mocks.MockShopeeRepository)), new(mocks.MockUrlResolver), mockMarketCredRepo, new(mocks.MockLinkRepository), new(mocks.MockClickRepository))
+
+	ctx := context.Background()
+	userId := int64(1)
+	productId := uuid.Must(uuid.NewV4())
+	offerId := uuid.Must(uuid.NewV4())
+	product := domains.Product{
+		Id:        productId,
+		UserId:    userId,
+		Title:     "Acme Kettle",
+		ImageUrl:  "https://img.lazcdn.com/kettle.jpg",
+		Category:  "42",
+		SourceUrl: "https://www.lazada.co.th/products/acme-kettle-i100.html",
+	}
+	offers := []domains.Offer{
+		{Id: offerId, ProductId: productId, Marketplace: "lazada", StoreName: "Acme", Price: 990, Currency: "THB", Availability: domains.OfferAvailable, CreatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	var promoteResp lazada.LazadaResponse[lazada.BatchPromoteLinkResponse]
+	err := json.Unmarshal([]byte(`{"code":"0","result":{"success":true,"data":{"urlBatchGetLinkInfoList":[{"productId":"200"},{"productId":"100"}]}}}`), &promoteResp)
+	assert.NoError(t, err)
+	var otherFeed, storedFeed lazada.LazadaResponse[[]lazada.ProductFeedResponse]
+	err = json.Unmarshal([]byte(`{"code":"0","result":{"success":true,"data":[{"productName":"Other Kettle","pictures":["https://img.lazcdn.com/other.jpg"],"brandName":"Other","discountPrice":500,"currency":"THB","categoryL1":7}]}}`), &otherFeed)
+	assert.NoError(t, err)
+	err = json.Unmarshal([]byte(`{"code":"0","result":{"success":true,"data":[{"productName":"Acme Kettle 1.7L","pictures":[],"brandName":"Acme","discountPrice":890,"currency":"THB"}]}}`), &storedFeed)
+	assert.NoError(t, err)
+
+	mockProductRepo.On("GetProductById", ctx, productId.String()).Return(product, nil)
+	mockOfferRepo.On("ListOffersByProductId", ctx, productId.String()).Return(offers, nil)
+	mockMarketCredRepo.On("GetByUserIdAndPlatform", ctx, userId, "lazada").Return(domains.MarketplaceCredential{AppKey: "key", AppSecret: "secret", UserToken: "token"}, nil)
+	mockLazadaRepo.On("GetBatchPromoteLink", mock.AnythingOfType("lazada.LazadaCredentials"), "url", product.SourceUrl, [6]string{}).Return(promoteResp, nil)
+	mockLazadaRepo.On("GetProductFeed", mock.AnythingOfType("lazada.LazadaCredentials"), "200", 1, 1).Return(otherFeed, nil)
+	mockLazadaRepo.On("GetProductFeed", mock.AnythingOfType("lazada.LazadaCredentials"), "100", 1, 1).Return(storedFeed, nil)
+	mockProductRepo.On("SaveProduct", ctx, mock.MatchedBy(func(p domains.Product) bool {
+		return p.Title == "Acme Kettle 1.7L" && p.ImageUrl == "https://img.lazcdn.com/kettle.jpg" && p.Category == "42"
+	})).Return(domains.Product{Id: productId, UserId: userId, Title: "Acme Kettle 1.7L", ImageUrl: "https://img.lazcdn.com/kettle.jpg", Category: "42"}, nil)
+	mockOfferRepo.On("SaveOffer", ctx, mock.MatchedBy(func(o domains.Offer) bool {
+		return o.Id == offerId && o.Price == 890 && o.Availability == domains.OfferAvailable
+	})).Return(nil)
+
+	result, err := service.RefreshProduct(ctx, userId, productId.String())
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, []dto.FieldChange{{Field: "title", Old: "Acme Kettle", New: "Acme Kettle 1.7L"}}, result.Data.Changes)
+	assert.Empty(t, result.Data.OffersAdded)
+	assert.Empty(t, result.Data.OffersRemoved)
+	mockProductRepo.AssertExpectations(t)
+	mockOfferRepo.AssertExpectations(t)
+}
+
+func TestRefreshProduct_Forbidden(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	service := NewProductService(mockProductRepo, new(mocks.MockOfferRepository), testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)), new(mocks.MockUrlResolver), new(mocks.MockMarketplaceRepository), new(mocks.MockLinkRepository), new(mocks.MockClickRepository))
+
+	ctx := context.Background()
+	productId := uuid.Must(uuid.NewV4())
+	mockProductRepo.On("GetProductById", ctx, productId.String()).Return(domains.Product{Id: productId, UserId: 2}, nil)
+
+	result, err := service.RefreshProduct(ctx, int64(1), productId.String())
+
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 2003, result.Code)
+}
+
+func TestRestoreProduct_Forbidden(t *testing.T) {
+	mockProductRepo := new(mocks.MockProductRepository)
+	service := NewProductService(mockProductRepo, new(mocks.MockOfferRepository), testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)), new(mocks.MockUrlResolver), new(mocks.MockMarketplaceRepository), new(mocks.MockLinkRepository), new(mocks.MockClickRepository))
+
+	ctx := context.Background()
+	productId := uuid.Must(uuid.NewV4())
+	deletedAt := time.Now()
+	mockProductRepo.On("GetProductByIdWithDeleted", ctx, productId.String()).Return(domains.Product{
+		Id:        productId,
+		UserId:    2,
+		DeletedAt: &deletedAt,
+	}, nil)
+
+	result, err := service.RestoreProduct(ctx, 1, productId.String())
+
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 403, result.HttpCode)
+	mockProductRepo.AssertNotCalled(t, "RestoreProduct", mock.Anything, mock.Anything)
+}
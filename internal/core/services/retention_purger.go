@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+// RetentionPurger hard deletes campaigns, products and links that have been soft deleted for
+// longer than the retention period, together with their clicks.
+type RetentionPurger struct {
+	campaignRepo ports.CampaignRepository
+	productRepo  ports.ProductRepository
+	linkRepo     ports.LinkRepository
+	retention    time.Duration
+	interval     time.Duration
+}
+
+func NewRetentionPurger(campaignRepo ports.CampaignRepository, productRepo ports.ProductRepository, linkRepo ports.LinkRepository, retention, interval time.Duration) *RetentionPurger {
+	return &RetentionPurger{campaignRepo: campaignRepo, productRepo: productRepo, linkRepo: linkRepo, retention: retention, interval: interval}
+}
+
+// Run purges immediately and then every interval until ctx is done.
+func (p *RetentionPurger) Run(ctx context.Context) {
+	runEvery(ctx, p.interval, "retention purger", p.Tick)
+}
+
+// Tick purges everything deleted before now minus the retention period. Campaigns and products
+// go first so the links deleted with them are purged in the same pass.
+func (p *RetentionPurger) Tick(ctx context.Context, now time.Time) error {
+	before := now.Add(-p.retention)
+	campaigns, err := p.campaignRepo.PurgeDeletedCampaigns(ctx, before)
+	if err != nil {
+		return err
+	}
+	products, err := p.productRepo.PurgeDeletedProducts(ctx, before)
+	if err != nil {
+		return err
+	}
+	links, err := p.linkRepo.PurgeDeletedLinks(ctx, before)
+	if err != nil {
+		return err
+	}
+	if campaigns+products+links > 0 {
+		log.Printf("Purged %d campaigns, %d products and %d links deleted before %s\n", campaigns, products, links, before.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRetentionPurgerTick(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	purger := NewRetentionPurger(mockCampaignRepo, mockProductRepo, mockLinkRepo, 30*24*time.Hour, time.Hour)
+
+	ctx := context.Background()
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockCampaignRepo.On("PurgeDeletedCampaigns", ctx, cutoff).Return(int64(2), nil)
+	mockProductRepo.On("PurgeDeletedProducts", ctx, cutoff).Return(int64(1), nil)
+	mockLinkRepo.On("PurgeDeletedLinks", ctx, cutoff).Return(int64(0), nil)
+
+	err := purger.Tick(ctx, now)
+
+	assert.NoError(t, err)
+	mockCampaignRepo.AssertExpectations(t)
+	mockProductRepo.AssertExpectations(t)
+	mockLinkRepo.AssertExpectations(t)
+}
+
+func TestRetentionPurgerTick_StopsOnError(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	purger := NewRetentionPurger(mockCampaignRepo, mockProductRepo, mockLinkRepo, time.Hour, time.Hour)
+
+	ctx := context.Background()
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	mockCampaignRepo.On("PurgeDeletedCampaigns", ctx, now.Add(-time.Hour)).Return(int64(0), assert.AnError)
+
+	err := purger.Tick(ctx, now)
+
+	assert.ErrorIs(t, err, assert.AnError)
+	mockProductRepo.AssertNotCalled(t, "PurgeDeletedProducts", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+// storefrontCampaignLimit caps how many campaigns a user storefront lists.
+const storefrontCampaignLimit = 100
+
+type storefrontService struct {
+	campaignRepo  ports.CampaignRepository
+	linkRepo      ports.LinkRepository
+	productRepo   ports.ProductRepository
+	offerRepo     ports.OfferRepository
+	publicBaseUrl string
+}
+
+// NewStorefrontService builds short urls as publicBaseUrl + /go/{short_code}.
+func NewStorefrontService(campaignRepo ports.CampaignRepository, linkRepo ports.LinkRepository, productRepo ports.ProductRepository, offerRepo ports.OfferRepository, publicBaseUrl string) ports.StorefrontService {
+	return &storefrontService{
+		campaignRepo:  campaignRepo,
+		linkRepo:      linkRepo,
+		productRepo:   productRepo,
+		offerRepo:     offerRepo,
+		publicBaseUrl: strings.TrimRight(publicBaseUrl, "/"),
+	}
+}
+
+// GetCampaignStorefront only exposes active campaigns. Any other campaign is reported as not
+// found so drafts and paused campaigns cannot be discovered by id.
+func (s *storefrontService) GetCampaignStorefront(ctx context.Context, campaignId string) (dto.Response[dto.StorefrontCampaign], error) {
+	notFound := dto.Response[dto.StorefrontCampaign]{
+		HttpCode: http.StatusNotFound,
+		Success:  false,
+		Code:     9001,
+		Message:  "Campaign not found",
+	}
+	if _, err := uuid.FromString(campaignId); err != nil {
+		return notFound, err
+	}
+	campaign, err := s.campaignRepo.GetCampaignById(ctx, campaignId)
+	if errors.Is(err, ports.ErrCampaignNotFound) {
+		return notFound, err
+	}
+	if err != nil {
+		return dto.Response[dto.StorefrontCampaign]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     9002,
+			Message:  "Failed to fetch campaign",
+		}, err
+	}
+	if campaign.State != domains.CampaignActive {
+		return notFound, errors.New("campaign is not active")
+	}
+	storefront, res, err := s.campaignStorefront(ctx, campaign)
+	if err != nil {
+		return res, err
+	}
+	return dto.Response[dto.StorefrontCampaign]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Storefront fetched successfully",
+		Data:     storefront,
+	}, nil
+}
+
+func (s *storefrontService) GetUserStorefront(ctx context.Context, userId int64) (dto.Response[dto.UserStorefront], error) {
+	campaigns, _, err := s.campaignRepo.GetCampaignByQuery(ctx, userId, dto.GetCampaignByQueryRequest{
+		State: domains.CampaignActive,
+		Limit: storefrontCampaignLimit,
+		Sort:  "end_at",
+		Order: "asc",
+	})
+	if err != nil {
+		return dto.Response[dto.UserStorefront]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     9002,
+			Message:  "Failed to fetch campaigns",
+		}, err
+	}
+	storefront := dto.UserStorefront{UserId: userId, Campaigns: []dto.StorefrontCampaign{}}
+	for _, campaign := range campaigns {
+		campaignStorefront, res, err := s.campaignStorefront(ctx, campaign)
+		if err != nil {
+			return dto.Response[dto.UserStorefront]{
+				HttpCode: res.HttpCode,
+				Success:  false,
+				Code:     res.Code,
+				Message:  res.Message,
+			}, err
+		}
+		storefront.Campaigns = append(storefront.Campaigns, campaignStorefront)
+	}
+	return dto.Response[dto.UserStorefront]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Storefront fetched successfully",
+		Data:     storefront,
+	}, nil
+}
+
+// campaignStorefront lists each product linked from the campaign once, with the first link
+// created for it.
+func (s *storefrontService) campaignStorefront(ctx context.Context, campaign domains.Campaign) (dto.StorefrontCampaign, dto.Response[dto.StorefrontCampaign], error) {
+	storefront := dto.StorefrontCampaign{
+		Id:       campaign.Id,
+		Name:     campaign.Name,
+		StartAt:  campaign.StartAt,
+		EndAt:    campaign.EndAt,
+		Products: []dto.StorefrontProduct{},
+	}
+	links, err := s.linkRepo.GetLinksByCampaignId(ctx, campaign.Id.String())
+	if err != nil {
+		return dto.StorefrontCampaign{}, dto.Response[dto.StorefrontCampaign]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     9003,
+			Message:  "Failed to fetch campaign links",
+		}, err
+	}
+	seen := map[uuid.UUID]bool{}
+	for _, link := range links {
+		if seen[link.ProductId] {
+			continue
+		}
+		seen[link.ProductId] = true
+		product, err := s.productRepo.GetProductById(ctx, link.ProductId.String())
+		if err != nil {
+			return dto.StorefrontCampaign{}, dto.Response[dto.StorefrontCampaign]{
+				HttpCode: http.StatusInternalServerError,
+				Success:  false,
+				Code:     9004,
+				Message:  "Failed to fetch campaign products",
+			}, err
+		}
+		offers, err := s.offerRepo.ListOffersByProductId(ctx, link.ProductId.String())
+		if err != nil {
+			return dto.StorefrontCampaign{}, dto.Response[dto.StorefrontCampaign]{
+				HttpCode: http.StatusInternalServerError,
+				Success:  false,
+				Code:     9005,
+				Message:  "Failed to fetch product offers",
+			}, err
+		}
+		storefront.Products = append(storefront.Products, dto.StorefrontProduct{
+			Id:        product.Id,
+			Title:     product.Title,
+			ImageUrl:  product.ImageUrl,
+			Images:    imageUrls(product.Images),
+			Category:  product.Category,
+			BestOffer: bestOffer(offers),
+			ShortCode: link.ShortCode,
+			ShortUrl:  s.publicBaseUrl + "/go/" + link.ShortCode,
+		})
+	}
+	return storefront, dto.Response[dto.StorefrontCampaign]{Success: true}, nil
+}
+
+// bestOffer is the cheapest available offer, preferring the better rated store on a tie.
+func bestOffer(offers []domains.Offer) *dto.StorefrontOffer {
+	var best *domains.Offer
+	for i, offer := range offers {
+		if offer.Availability != domains.OfferAvailable {
+			continue
+		}
+		if best == nil || offer.Price < best.Price || (offer.Price == best.Price && offer.RatingStar > best.RatingStar) {
+			best = &offers[i]
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return &dto.StorefrontOffer{
+		Marketplace: best.Marketplace,
+		StoreName:   best.StoreName,
+		Price:       best.Price,
+		Currency:    best.Currency,
+		RatingStar:  best.RatingStar,
+	}
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetCampaignStorefront(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+	mockOfferRepo := new(mocks.MockOfferRepository)
+	service := NewStorefrontService(mockCampaignRepo, mockLinkRepo, mockProductRepo, mockOfferRepo, "https://aff.example.com/")
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	productId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, Name: "Summer", State: domains.CampaignActive}, nil)
+	mockLinkRepo.On("GetLinksByCampaignId", ctx, campaignId.String()).Return([]domains.Link{
+		{ProductId: productId, CampaignId: campaignId, ShortCode: "abc123"},
+		{ProductId: productId, CampaignId: campaignId, ShortCode: "def456"},
+	}, nil)
+	mockProductRepo.On("GetProductById", ctx, productId.String()).Return(domains.Product{
+		Id:       productId,
+		Title:    "Wireless Earbuds",
+		ImageUrl: "https://img.example.com/1.jpg",
+		Images:   []domains.ProductImage{{Url: "https://img.example.com/1.jpg"}, {Url: "https://img.example.com/2.jpg"}},
+	}, nil)
+	mockOfferRepo.On("ListOffersByProductId", ctx, productId.String()).Return([]domains.Offer{
+		{Marketplace: "lazada", StoreName: "Cheapest", Price: 399, Currency: "THB", Availability: domains.OfferOutOfStock},
+		{Marketplace: "lazada", StoreName: "Soundly", Price: 599, Currency: "THB", Availability: domains.OfferAvailable},
+		{Marketplace: "shopee", StoreName: "Hydro", Price: 549, Currency: "THB", Availability: domains.OfferAvailable},
+	}, nil)
+
+	result, err := service.GetCampaignStorefront(ctx, campaignId.String())
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, []dto.StorefrontProduct{{
+		Id:        productId,
+		Title:     "Wireless Earbuds",
+		ImageUrl:  "https://img.example.com/1.jpg",
+		Images:    []string{"https://img.example.com/1.jpg", "https://img.example.com/2.jpg"},
+		BestOffer: &dto.StorefrontOffer{Marketplace: "shopee", StoreName: "Hydro", Price: 549, Currency: "THB"},
+		ShortCode: "abc123",
+		ShortUrl:  "https://aff.example.com/go/abc123",
+	}}, result.Data.Products)
+}
+
+func TestGetCampaignStorefront_InactiveIsNotFound(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	service := NewStorefrontService(mockCampaignRepo, mockLinkRepo, new(mocks.MockProductRepository), new(mocks.MockOfferRepository), "https://aff.example.com")
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(domains.Campaign{Id: campaignId, State: domains.CampaignPaused}, nil)
+
+	result, err := service.GetCampaignStorefront(ctx, campaignId.String())
+
+	assert.Error(t, err)
+	assert.Equal(t, 404, result.HttpCode)
+	assert.Equal(t, 9001, result.Code)
+	mockLinkRepo.AssertNotCalled(t, "GetLinksByCampaignId", mock.Anything, mock.Anything)
+}
+
+func TestGetCampaignStorefront_UnknownCampaign(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	service := NewStorefrontService(mockCampaignRepo, new(mocks.MockLinkRepository), new(mocks.MockProductRepository), new(mocks.MockOfferRepository), "https://aff.example.com")
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignById", ctx, campaignId.String()).Return(domains.Campaign{}, ports.ErrCampaignNotFound)
+
+	result, err := service.GetCampaignStorefront(ctx, campaignId.String())
+	assert.Error(t, err)
+	assert.Equal(t, 404, result.HttpCode)
+
+	result, err = service.GetCampaignStorefront(ctx, "not-a-uuid")
+	assert.Error(t, err)
+	assert.Equal(t, 404, result.HttpCode)
+	mockCampaignRepo.AssertNumberOfCalls(t, "GetCampaignById", 1)
+}
+
+func TestGetUserStorefront_ListsActiveCampaigns(t *testing.T) {
+	mockCampaignRepo := new(mocks.MockCampaignRepository)
+	mockLinkRepo := new(mocks.MockLinkRepository)
+	service := NewStorefrontService(mockCampaignRepo, mockLinkRepo, new(mocks.MockProductRepository), new(mocks.MockOfferRepository), "https://aff.example.com")
+
+	ctx := context.Background()
+	campaignId := uuid.Must(uuid.NewV4())
+	mockCampaignRepo.On("GetCampaignByQuery", ctx, int64(7), mock.MatchedBy(func(q dto.GetCampaignByQueryRequest) bool {
+		return q.State == domains.CampaignActive
+	})).Return([]domains.Campaign{{Id: campaignId, Name: "Summer", State: domains.CampaignActive, UserId: 7}}, dto.PageMeta{}, nil)
+	mockLinkRepo.On("GetLinksByCampaignId", ctx, campaignId.String()).Return([]domains.Link{}, nil)
+
+	result, err := service.GetUserStorefront(ctx, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), result.Data.UserId)
+	assert.Equal(t, []dto.StorefrontCampaign{{Id: campaignId, Name: "Summer", Products: []dto.StorefrontProduct{}}}, result.Data.Campaigns)
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+type tagService struct {
+	tagRepo     ports.TagRepository
+	productRepo ports.ProductRepository
+}
+
+func NewTagService(tagRepo ports.TagRepository, productRepo ports.ProductRepository) ports.TagService {
+	return &tagService{tagRepo: tagRepo, productRepo: productRepo}
+}
+
+func (s *tagService) CreateTag(ctx context.Context, userId int64, tag dto.TagRequest) (dto.Response[domains.Tag], error) {
+	newTag, err := s.tagRepo.SaveTag(ctx, domains.Tag{
+		Name:   tag.Name,
+		UserId: userId,
+	})
+	if err != nil {
+		return dto.Response[domains.Tag]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     7001,
+			Message:  "Failed to create tag",
+		}, err
+	}
+	return dto.Response[domains.Tag]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Tag created successfully",
+		Data:     newTag,
+	}, nil
+}
+
+func (s *tagService) GetTags(ctx context.Context, userId int64) (dto.Response[[]domains.Tag], error) {
+	tags, err := s.tagRepo.GetTagsByUserId(ctx, userId)
+	if err != nil {
+		return dto.Response[[]domains.Tag]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     7002,
+			Message:  "Failed to fetch tags",
+		}, err
+	}
+	return dto.Response[[]domains.Tag]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Tags fetched successfully",
+		Data:     tags,
+	}, nil
+}
+
+func (s *tagService) UpdateTag(ctx context.Context, userId int64, tagId string, tag dto.TagRequest) (dto.Response[domains.Tag], error) {
+	existing, err := s.tagRepo.GetTagById(ctx, tagId)
+	if err != nil {
+		return dto.Response[domains.Tag]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     7003,
+			Message:  "Failed to fetch tag",
+		}, err
+	}
+	if existing.UserId != userId {
+		return dto.Response[domains.Tag]{
+			HttpCode: http.StatusForbidden,
+			Success:  false,
+			Code:     7004,
+			Message:  "You do not have access to this tag",
+		}, nil
+	}
+	existing.Name = tag.Name
+	updated, err := s.tagRepo.SaveTag(ctx, existing)
+	if err != nil {
+		return dto.Response[domains.Tag]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     7005,
+			Message:  "Failed to update tag",
+		}, err
+	}
+	return dto.Response[domains.Tag]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Tag updated successfully",
+		Data:     updated,
+	}, nil
+}
+
+func (s *tagService) DeleteTag(ctx context.Context, userId int64, tagId string) (dto.Response[any], error) {
+	tag, err := s.tagRepo.GetTagById(ctx, tagId)
+	if err != nil {
+		return dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     7003,
+			Message:  "Failed to fetch tag",
+		}, err
+	}
+	if tag.UserId != userId {
+		return dto.Response[any]{
+			HttpCode: http.StatusForbidden,
+			Success:  false,
+			Code:     7004,
+			Message:  "You do not have access to this tag",
+		}, nil
+	}
+	err = s.tagRepo.DeleteTag(ctx, tagId)
+	if err != nil {
+		return dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     7006,
+			Message:  "Failed to delete tag",
+		}, err
+	}
+	return dto.Response[any]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Tag deleted successfully",
+	}, nil
+}
+
+func (s *tagService) AddProductTag(ctx context.Context, userId int64, productId, tagId string) (dto.Response[any], error) {
+	res, err := s.checkProductAndTag(ctx, userId, productId, tagId)
+	if err != nil || !res.Success {
+		return res, err
+	}
+	err = s.tagRepo.AddProductTag(ctx, productId, tagId)
+	if err != nil {
+		return dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     7008,
+			Message:  "Failed to tag product",
+		}, err
+	}
+	return dto.Response[any]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Product tagged successfully",
+	}, nil
+}
+
+func (s *tagService) RemoveProductTag(ctx context.Context, userId int64, productId, tagId string) (dto.Response[any], error) {
+	res, err := s.checkProductAndTag(ctx, userId, productId, tagId)
+	if err != nil || !res.Success {
+		return res, err
+	}
+	err = s.tagRepo.RemoveProductTag(ctx, productId, tagId)
+	if err != nil {
+		return dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     7009,
+			Message:  "Failed to untag product",
+		}, err
+	}
+	return dto.Response[any]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Product untagged successfully",
+	}, nil
+}
+
+// checkProductAndTag makes sure both the product and the tag belong to the user.
+func (s *tagService) checkProductAndTag(ctx context.Context, userId int64, productId, tagId string) (dto.Response[any], error) {
+	product, err := s.productRepo.GetProductById(ctx, productId)
+	if err != nil {
+		return dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     7007,
+			Message:  "Failed to fetch product",
+		}, err
+	}
+	tag, err := s.tagRepo.GetTagById(ctx, tagId)
+	if err != nil {
+		return dto.Response[any]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     7003,
+			Message:  "Failed to fetch tag",
+		}, err
+	}
+	if product.UserId != userId || tag.UserId != userId {
+		return dto.Response[any]{
+			HttpCode: http.StatusForbidden,
+			Success:  false,
+			Code:     7004,
+			Message:  "You do not have access to this product or tag",
+		}, nil
+	}
+	return dto.Response[any]{Success: true}, nil
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/repositories/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTag_Success(t *testing.T) {
+	mockTagRepo := new(mocks.MockTagRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	service := NewTagService(mockTagRepo, mockProductRepo)
+
+	ctx := context.Background()
+	userId := int64(1)
+	tag := domains.Tag{Id: uuid.Must(uuid.NewV4()), Name: "skincare", UserId: userId}
+
+	mockTagRepo.On("SaveTag", ctx, mock.MatchedBy(func(t domains.Tag) bool {
+		return t.Name == "skincare" && t.UserId == userId
+	})).Return(tag, nil)
+
+	result, err := service.CreateTag(ctx, userId, dto.TagRequest{Name: "skincare"})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, tag, result.Data)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestAddProductTag_Success(t *testing.T) {
+	mockTagRepo := new(mocks.MockTagRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	service := NewTagService(mockTagRepo, mockProductRepo)
+
+	ctx := context.Background()
+	userId := int64(1)
+	productId := uuid.Must(uuid.NewV4())
+	tagId := uuid.Must(uuid.NewV4())
+
+	mockProductRepo.On("GetProductById", ctx, productId.String()).Return(domains.Product{Id: productId, UserId: userId}, nil)
+	mockTagRepo.On("GetTagById", ctx, tagId.String()).Return(domains.Tag{Id: tagId, UserId: userId}, nil)
+	mockTagRepo.On("AddProductTag", ctx, productId.String(), tagId.String()).Return(nil)
+
+	result, err := service.AddProductTag(ctx, userId, productId.String(), tagId.String())
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	mockProductRepo.AssertExpectations(t)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestAddProductTag_Forbidden(t *testing.T) {
+	mockTagRepo := new(mocks.MockTagRepository)
+	mockProductRepo := new(mocks.MockProductRepository)
+
+	service := NewTagService(mockTagRepo, mockProductRepo)
+
+	ctx := context.Background()
+	userId := int64(1)
+	productId := uuid.Must(uuid.NewV4())
+	tagId := uuid.Must(uuid.NewV4())
+
+	mockProductRepo.On("GetProductById", ctx, productId.String()).Return(domains.Product{Id: productId, UserId: userId}, nil)
+	mockTagRepo.On("GetTagById", ctx, tagId.String()).Return(domains.Tag{Id: tagId, UserId: int64(2)}, nil)
+
+	result, err := service.AddProductTag(ctx, userId, productId.String(), tagId.String())
+
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 7004, result.Code)
+	mockTagRepo.AssertNotCalled(t, "AddProductTag", mock.Anything, mock.Anything, mock.Anything)
+}
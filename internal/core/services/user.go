@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"log"
 	"net/http"
@@ -18,10 +19,11 @@ type userService struct {
 	jwtSalt        string
 	userRepo       ports.UserRepository
 	marketCredRepo ports.MarketplaceRepository
+	marketplaces   ports.MarketplaceRegistry
 }
 
-func NewUserService(passwordSalt, jwtSalt string, userRepo ports.UserRepository, marketCredRepo ports.MarketplaceRepository) ports.UserService {
-	return &userService{passwordSalt: passwordSalt, jwtSalt: jwtSalt, userRepo: userRepo, marketCredRepo: marketCredRepo}
+func NewUserService(passwordSalt, jwtSalt string, userRepo ports.UserRepository, marketCredRepo ports.MarketplaceRepository, marketplaces ports.MarketplaceRegistry) ports.UserService {
+	return &userService{passwordSalt: passwordSalt, jwtSalt: jwtSalt, userRepo: userRepo, marketCredRepo: marketCredRepo, marketplaces: marketplaces}
 }
 
 func (s *userService) Register(ctx context.Context, pwd, email string) (dto.Response[string], error) {
@@ -120,14 +122,37 @@ func (s *userService) VerifyAndGetUserId(token string) (int64, error) {
 }
 
 func (s *userService) SaveMarketplaceCredential(ctx context.Context, userId int64, cred dto.MarketplaceCredentialRequest) (dto.Response[string], error) {
-	_, err := s.marketCredRepo.Save(ctx, domains.MarketplaceCredential{
+	provider, err := s.marketplaces.Get(cred.Platform)
+	if err != nil {
+		return dto.Response[string]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     1009,
+			Message:  "Unsupported marketplace",
+		}, err
+	}
+	credential := domains.MarketplaceCredential{
 		UserId:      userId,
-		Marketplace: cred.Platform,
+		Marketplace: provider.Name(),
 		AppId:       cred.AppId,
 		AppSecret:   cred.AppSecret,
 		AppKey:      cred.AppKey,
 		UserToken:   cred.UserToken,
-	})
+		Region:      cred.Region,
+	}
+	if credential.Region == "" {
+		credential.Region = domains.DefaultMarketplaceRegion
+	}
+	err = provider.ValidateCredential(credential)
+	if err != nil {
+		return dto.Response[string]{
+			HttpCode: http.StatusBadRequest,
+			Success:  false,
+			Code:     1010,
+			Message:  "Invalid marketplace credential: " + err.Error(),
+		}, err
+	}
+	_, err = s.marketCredRepo.Save(ctx, credential)
 	if err != nil {
 		return dto.Response[string]{
 			HttpCode: http.StatusInternalServerError,
@@ -144,6 +169,68 @@ func (s *userService) SaveMarketplaceCredential(ctx context.Context, userId int6
 	}, nil
 }
 
+func (s *userService) UpdateLinkPolicy(ctx context.Context, userId int64, policy dto.LinkPolicyRequest) (dto.Response[domains.User], error) {
+	user, err := s.userRepo.GetUserByID(ctx, userId)
+	if err != nil {
+		return dto.Response[domains.User]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     1008,
+			Message:  "Failed to get user",
+		}, err
+	}
+	user.UnavailableLinkPolicy = policy.Policy
+	user.FallbackUrl = policy.FallbackUrl
+	user, err = s.userRepo.UpdateUser(ctx, user)
+	if err != nil {
+		return dto.Response[domains.User]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     1011,
+			Message:  "Failed to update link policy",
+		}, err
+	}
+	user.Password = ""
+	return dto.Response[domains.User]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Link policy updated successfully",
+		Data:     user,
+	}, nil
+}
+
+// RotatePostbackSecret replaces the user's postback secret, so postbacks signed with the old
+// one are refused from now on.
+func (s *userService) RotatePostbackSecret(ctx context.Context, userId int64) (dto.Response[dto.PostbackSecretResponse], error) {
+	user, err := s.userRepo.GetUserByID(ctx, userId)
+	if err != nil {
+		return dto.Response[dto.PostbackSecretResponse]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     1008,
+			Message:  "Failed to get user",
+		}, err
+	}
+	user.PostbackSecret = rand.Text()
+	user, err = s.userRepo.UpdateUser(ctx, user)
+	if err != nil {
+		return dto.Response[dto.PostbackSecretResponse]{
+			HttpCode: http.StatusInternalServerError,
+			Success:  false,
+			Code:     1012,
+			Message:  "Failed to generate postback secret",
+		}, err
+	}
+	return dto.Response[dto.PostbackSecretResponse]{
+		HttpCode: http.StatusOK,
+		Success:  true,
+		Code:     0,
+		Message:  "Postback secret generated successfully",
+		Data:     dto.PostbackSecretResponse{Secret: user.PostbackSecret},
+	}, nil
+}
+
 func (s *userService) CheckMarketplaceCredential(ctx context.Context, userId int64, platform string) (dto.Response[bool], error) {
 	_, err := s.marketCredRepo.GetByUserIdAndPlatform(ctx, userId, platform)
 	if err != nil {
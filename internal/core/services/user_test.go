@@ -16,7 +16,7 @@ func TestRegister_Success(t *testing.T) {
 	mockMarketRepo := new(mocks.MockMarketplaceRepository)
 
 	// Use a proper 32-byte salt for AES-256
-	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo)
+	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo, testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)))
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -40,7 +40,7 @@ func TestRegister_UserAlreadyExists(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockMarketRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo)
+	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo, testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)))
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -67,7 +67,7 @@ func TestLogin_UserNotFound(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockMarketRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo)
+	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo, testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)))
 
 	ctx := context.Background()
 	email := "notfound@example.com"
@@ -88,7 +88,7 @@ func TestGetMe_Success(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockMarketRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo)
+	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo, testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)))
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -115,7 +115,7 @@ func TestSaveMarketplaceCredential_Success(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockMarketRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo)
+	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo, testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)))
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -144,7 +144,7 @@ func TestCheckMarketplaceCredential_Success(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockMarketRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo)
+	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo, testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)))
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -171,7 +171,7 @@ func TestDeleteMarketplaceCredential_Success(t *testing.T) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockMarketRepo := new(mocks.MockMarketplaceRepository)
 
-	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo)
+	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo, testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)))
 
 	ctx := context.Background()
 	userId := int64(1)
@@ -186,3 +186,51 @@ func TestDeleteMarketplaceCredential_Success(t *testing.T) {
 	assert.Equal(t, 0, result.Code)
 	mockMarketRepo.AssertExpectations(t)
 }
+
+func TestUpdateLinkPolicy_Success(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockMarketRepo := new(mocks.MockMarketplaceRepository)
+
+	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo, testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)))
+
+	ctx := context.Background()
+	userId := int64(1)
+	user := domains.User{Id: userId, Email: "test@example.com", Password: "hashed_password"}
+
+	mockUserRepo.On("GetUserByID", ctx, userId).Return(user, nil)
+	mockUserRepo.On("UpdateUser", ctx, mock.MatchedBy(func(u domains.User) bool {
+		return u.UnavailableLinkPolicy == domains.LinkPolicyFallback && u.FallbackUrl == "https://example.com/shop"
+	})).Return(domains.User{Id: userId, Password: "hashed_password", UnavailableLinkPolicy: domains.LinkPolicyFallback, FallbackUrl: "https://example.com/shop"}, nil)
+
+	result, err := service.UpdateLinkPolicy(ctx, userId, dto.LinkPolicyRequest{
+		Policy:      domains.LinkPolicyFallback,
+		FallbackUrl: "https://example.com/shop",
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, domains.LinkPolicyFallback, result.Data.UnavailableLinkPolicy)
+	assert.Empty(t, result.Data.Password)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestRotatePostbackSecret_Success(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockMarketRepo := new(mocks.MockMarketplaceRepository)
+
+	service := NewUserService("12345678901234567890123456789012", "jwt_salt_12345678901234567890123456789012", mockUserRepo, mockMarketRepo, testMarketplaces(new(mocks.MockLazadaRepository), new(mocks.MockShopeeRepository)))
+
+	ctx := context.Background()
+	userId := int64(1)
+	mockUserRepo.On("GetUserByID", ctx, userId).Return(domains.User{Id: userId, PostbackSecret: "old_secret"}, nil)
+	mockUserRepo.On("UpdateUser", ctx, mock.MatchedBy(func(u domains.User) bool {
+		return len(u.PostbackSecret) == 26 && u.PostbackSecret != "old_secret"
+	})).Return(domains.User{Id: userId, PostbackSecret: "NEWSECRET"}, nil)
+
+	result, err := service.RotatePostbackSecret(ctx, userId)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "NEWSECRET", result.Data.Secret)
+	mockUserRepo.AssertExpectations(t)
+}
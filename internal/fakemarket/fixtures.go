@@ -0,0 +1,214 @@
+package fakemarket
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/market-place-affiliate/commonlib/lazada"
+)
+
+// Fixtures is the catalogue served by the fake marketplace. Everything returned by the fake
+// server is derived from it, so the same fixtures always produce the same responses.
+type Fixtures struct {
+	Lazada []LazadaProduct `json:"lazada"`
+	Shopee []ShopeeProduct `json:"shopee"`
+	// ShareLinks maps codes served at /share/{code} to the url they redirect to, like the
+	// s.shopee.co.th and s.lazada.co.th links creators copy from the apps.
+	ShareLinks map[string]string `json:"share_links"`
+	// LazadaConversions and ShopeeConversions are served by the conversion reports.
+	LazadaConversions []LazadaConversion `json:"lazada_conversions"`
+	ShopeeConversions []ShopeeConversion `json:"shopee_conversions"`
+}
+
+type LazadaProduct struct {
+	// Url is the product page url creators paste, e.g. https://www.lazada.co.th/products/x-i1001.html
+	Url  string                     `json:"url"`
+	Feed lazada.ProductFeedResponse `json:"feed"`
+}
+
+// ShopeeProduct mirrors a productOfferV2 node.
+type ShopeeProduct struct {
+	ProductName    string `json:"productName"`
+	ItemID         int64  `json:"itemId"`
+	ShopID         int    `json:"shopId"`
+	CommissionRate string `json:"commissionRate"`
+	Commission     string `json:"commission"`
+	Price          string `json:"price"`
+	Sales          int    `json:"sales"`
+	ImageURL       string `json:"imageUrl"`
+	ShopName       string `json:"shopName"`
+	ProductLink    string `json:"productLink"`
+	OfferLink      string `json:"offerLink"`
+	ProductCatIds  []int  `json:"productCatIds"`
+	RatingStar     string `json:"ratingStar"`
+}
+
+// LazadaConversion mirrors a row of the Lazada conversion report. ConversionTime is in
+// milliseconds since the epoch.
+type LazadaConversion struct {
+	OrderId        string  `json:"orderId"`
+	SubOrderId     string  `json:"subOrderId"`
+	ProductId      string  `json:"productId"`
+	ProductName    string  `json:"productName"`
+	OrderAmount    float64 `json:"orderAmount"`
+	EstPayout      float64 `json:"estPayout"`
+	Currency       string  `json:"currency"`
+	OrderStatus    string  `json:"orderStatus"`
+	ConversionTime int64   `json:"conversionTime"`
+	Sub1           string  `json:"sub1"`
+	Sub2           string  `json:"sub2"`
+	Sub3           string  `json:"sub3"`
+	Sub4           string  `json:"sub4"`
+	Sub5           string  `json:"sub5"`
+	Sub6           string  `json:"sub6"`
+}
+
+// ShopeeConversion mirrors a conversionReport node. PurchaseTime is in seconds since the epoch.
+type ShopeeConversion struct {
+	ConversionId int64                   `json:"conversionId"`
+	PurchaseTime int64                   `json:"purchaseTime"`
+	UtmContent   string                  `json:"utmContent"`
+	Orders       []ShopeeConversionOrder `json:"orders"`
+}
+
+type ShopeeConversionOrder struct {
+	OrderId     string                 `json:"orderId"`
+	OrderStatus string                 `json:"orderStatus"`
+	Items       []ShopeeConversionItem `json:"items"`
+}
+
+type ShopeeConversionItem struct {
+	ItemId              int64  `json:"itemId"`
+	ModelId             int64  `json:"modelId"`
+	ItemName            string `json:"itemName"`
+	Qty                 int    `json:"qty"`
+	ActualAmount        string `json:"actualAmount"`
+	ItemTotalCommission string `json:"itemTotalCommission"`
+}
+
+// DefaultFixtures returns a small catalogue with one product per marketplace plus an out of
+// stock Lazada product, a share link for each in-stock product, and orders of the in-stock
+// products placed on 2 March 2026 through links with the sub ids "summer_sale" and "lzd1001" or
+// "shp3001".
+func DefaultFixtures() Fixtures {
+	return Fixtures{
+		Lazada: []LazadaProduct{
+			{
+				Url: "https://www.lazada.co.th/products/wireless-earbuds-i1001.html",
+				Feed: lazada.ProductFeedResponse{
+					ProductID:             1001,
+					ProductName:           "Wireless Earbuds",
+					BrandName:             "Soundly",
+					DiscountPrice:         599,
+					TotalCommissionRate:   0.12,
+					TotalCommissionAmount: 71.88,
+					Sales7D:               320,
+					Pictures: []string{
+						"https://img.lazcdn.com/fake/1001-1.jpg",
+						"https://img.lazcdn.com/fake/1001-2.jpg",
+					},
+					CategoryL1: 10100,
+					Currency:   "THB",
+					Stock:      42,
+				},
+			},
+			{
+				Url: "https://www.lazada.co.th/products/desk-lamp-i1002.html",
+				Feed: lazada.ProductFeedResponse{
+					ProductID:             1002,
+					ProductName:           "LED Desk Lamp",
+					DiscountPrice:         349,
+					TotalCommissionRate:   0.08,
+					TotalCommissionAmount: 27.92,
+					Pictures:              []string{"https://img.lazcdn.com/fake/1002-1.jpg"},
+					CategoryL1:            10200,
+					Currency:              "THB",
+					OutOfStock:            true,
+				},
+			},
+		},
+		Shopee: []ShopeeProduct{
+			{
+				ProductName:    "Stainless Water Bottle",
+				ShopID:         2001,
+				ItemID:         3001,
+				CommissionRate: "0.1",
+				Commission:     "25.9",
+				Price:          "259",
+				Sales:          1500,
+				ImageURL:       "https://cf.shopee.co.th/file/fake-3001",
+				ShopName:       "Hydro Shop",
+				ProductLink:    "https://shopee.co.th/Stainless-Water-Bottle-i.2001.3001",
+				OfferLink:      "https://shope.ee/fake-3001",
+				ProductCatIds:  []int{100001, 100017},
+				RatingStar:     "4.8",
+			},
+		},
+		ShareLinks: map[string]string{
+			"lzd-1001": "https://www.lazada.co.th/products/wireless-earbuds-i1001.html",
+			"shp-3001": "https://shopee.co.th/product/2001/3001",
+		},
+		LazadaConversions: []LazadaConversion{
+			{
+				OrderId:        "LZ-9001",
+				SubOrderId:     "LZ-9001-1",
+				ProductId:      "1001",
+				ProductName:    "Wireless Earbuds",
+				OrderAmount:    599,
+				EstPayout:      71.88,
+				Currency:       "THB",
+				OrderStatus:    "Delivered",
+				ConversionTime: time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC).UnixMilli(),
+				Sub1:           "summer_sale",
+				Sub2:           "lzd1001",
+			},
+			{
+				OrderId:        "LZ-9002",
+				SubOrderId:     "LZ-9002-1",
+				ProductId:      "1001",
+				ProductName:    "Wireless Earbuds",
+				OrderAmount:    599,
+				EstPayout:      71.88,
+				Currency:       "THB",
+				OrderStatus:    "Canceled",
+				ConversionTime: time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC).UnixMilli(),
+				Sub1:           "summer_sale",
+				Sub2:           "lzd1001",
+			},
+		},
+		ShopeeConversions: []ShopeeConversion{
+			{
+				ConversionId: 7001,
+				PurchaseTime: time.Date(2026, 3, 2, 5, 0, 0, 0, time.UTC).Unix(),
+				UtmContent:   "summer_sale-shp3001---",
+				Orders: []ShopeeConversionOrder{{
+					OrderId:     "SH-8001",
+					OrderStatus: "PENDING",
+					Items: []ShopeeConversionItem{{
+						ItemId:              3001,
+						ModelId:             1,
+						ItemName:            "Stainless Water Bottle",
+						Qty:                 2,
+						ActualAmount:        "518",
+						ItemTotalCommission: "51.8",
+					}},
+				}},
+			},
+		},
+	}
+}
+
+// LoadFixtures reads fixtures from a JSON file in the Fixtures format.
+func LoadFixtures(path string) (Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixtures{}, err
+	}
+	var fixtures Fixtures
+	err = json.Unmarshal(data, &fixtures)
+	if err != nil {
+		return Fixtures{}, err
+	}
+	return fixtures, nil
+}
@@ -0,0 +1,49 @@
+package fakemarket
+
+import (
+	"net/http/httptest"
+
+	"github.com/market-place-affiliate/commonlib/lazada"
+)
+
+// Instance is a fake marketplace listening on a random local port, for use in tests.
+type Instance struct {
+	*Server
+	httpServer *httptest.Server
+}
+
+func Start(fixtures Fixtures) *Instance {
+	server := NewServer(fixtures)
+	return &Instance{
+		Server:     server,
+		httpServer: httptest.NewServer(server),
+	}
+}
+
+func (i *Instance) URL() string {
+	return i.httpServer.URL
+}
+
+// LazadaGateway is the value to pass to marketplace.NewLazadaClient.
+func (i *Instance) LazadaGateway() lazada.LazadaApiGateway {
+	return LazadaGateway(i.URL())
+}
+
+// ShopeeEndpoint is the value to pass to marketplace.NewShopeeClient.
+func (i *Instance) ShopeeEndpoint() string {
+	return ShopeeEndpoint(i.URL())
+}
+
+func (i *Instance) Close() {
+	i.httpServer.Close()
+}
+
+// LazadaGateway returns the Lazada API gateway of a fake marketplace served at baseUrl.
+func LazadaGateway(baseUrl string) lazada.LazadaApiGateway {
+	return lazada.LazadaApiGateway(baseUrl + "/rest")
+}
+
+// ShopeeEndpoint returns the Shopee GraphQL endpoint of a fake marketplace served at baseUrl.
+func ShopeeEndpoint(baseUrl string) string {
+	return baseUrl + "/graphql"
+}
@@ -0,0 +1,489 @@
+// Package fakemarket emulates the parts of the Lazada and Shopee affiliate APIs used by the
+// marketplace providers so the API can run locally and in tests without real credentials.
+package fakemarket
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/market-place-affiliate/commonlib/lazada"
+)
+
+// Endpoints that can be targeted by Fail.
+const (
+	EndpointLazadaProductFeed  = "lazada.product_feed"
+	EndpointLazadaGetLink      = "lazada.getlink"
+	EndpointShopeeProductOffer = "shopee.product_offer"
+	EndpointShopeeShortLink    = "shopee.short_link"
+
+	EndpointLazadaConversionReport = "lazada.conversion_report"
+	EndpointShopeeConversionReport = "shopee.conversion_report"
+)
+
+// Failure replaces the normal response of an endpoint. With Status 0 the endpoint answers
+// 200 with a marketplace error body, the way both APIs report most errors.
+type Failure struct {
+	Status  int           `json:"status"`
+	Code    string        `json:"code"`
+	Message string        `json:"message"`
+	Delay   time.Duration `json:"delay"`
+	// Times limits how many requests fail before the endpoint recovers; 0 fails until Reset.
+	Times int `json:"times"`
+}
+
+type Server struct {
+	mux *http.ServeMux
+
+	mu       sync.Mutex
+	fixtures Fixtures
+	failures map[string]*Failure
+	calls    map[string]int
+}
+
+func NewServer(fixtures Fixtures) *Server {
+	s := &Server{
+		mux:      http.NewServeMux(),
+		fixtures: fixtures,
+		failures: map[string]*Failure{},
+		calls:    map[string]int{},
+	}
+	s.mux.HandleFunc("GET /rest/marketing/product/feed", s.lazadaProductFeed)
+	s.mux.HandleFunc("GET /rest/marketing/getlink", s.lazadaGetLink)
+	s.mux.HandleFunc("GET /rest/marketing/conversion/report", s.lazadaConversionReport)
+	s.mux.HandleFunc("POST /graphql", s.shopeeGraphql)
+	s.mux.HandleFunc("GET /share/{code}", s.shareLink)
+	s.mux.HandleFunc("POST /_fake/failures", s.addFailure)
+	s.mux.HandleFunc("DELETE /_fake/failures", s.resetFailures)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Fail makes the next requests to endpoint fail as described by failure.
+func (s *Server) Fail(endpoint string, failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[endpoint] = &failure
+}
+
+// Reset clears injected failures and call counts.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = map[string]*Failure{}
+	s.calls = map[string]int{}
+}
+
+// Calls returns how many requests endpoint has received since the last Reset.
+func (s *Server) Calls(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[endpoint]
+}
+
+// begin records a call and returns the failure to apply to it, if any.
+func (s *Server) begin(endpoint string) *Failure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[endpoint]++
+	failure, ok := s.failures[endpoint]
+	if !ok {
+		return nil
+	}
+	if failure.Times > 0 {
+		failure.Times--
+		if failure.Times == 0 {
+			delete(s.failures, endpoint)
+		}
+	}
+	applied := *failure
+	return &applied
+}
+
+func (s *Server) lazadaProductFeed(w http.ResponseWriter, r *http.Request) {
+	if s.lazadaFailed(w, r, EndpointLazadaProductFeed) {
+		return
+	}
+	ids := strings.Split(strings.Trim(r.URL.Query().Get("productIds"), "[]"), ",")
+	feeds := []lazada.ProductFeedResponse{}
+	for _, id := range ids {
+		product, ok := s.lazadaProductById(strings.TrimSpace(id))
+		if ok {
+			feeds = append(feeds, product.Feed)
+		}
+	}
+	writeLazada(w, feeds)
+}
+
+func (s *Server) lazadaGetLink(w http.ResponseWriter, r *http.Request) {
+	if s.lazadaFailed(w, r, EndpointLazadaGetLink) {
+		return
+	}
+	query := r.URL.Query()
+	inputValue := query.Get("inputValue")
+
+	type linkInfo struct {
+		RegularCommission    string `json:"regularCommission"`
+		ProductID            string `json:"productId"`
+		OriginalURL          string `json:"originalUrl"`
+		RegularPromotionLink string `json:"regularPromotionLink"`
+		ProductName          string `json:"productName"`
+	}
+	type errorInfo struct {
+		InputValue string `json:"inputValue"`
+		ErrorCode  string `json:"errorCode"`
+		ErrorMsg   string `json:"errorMsg"`
+	}
+	data := struct {
+		URLBatchGetLinkInfoList []linkInfo  `json:"urlBatchGetLinkInfoList"`
+		ErrorInfoList           []errorInfo `json:"errorInfoList"`
+		ErrorCount              int         `json:"errorCount"`
+	}{URLBatchGetLinkInfoList: []linkInfo{}, ErrorInfoList: []errorInfo{}}
+
+	product, ok := s.lazadaProductByInput(query.Get("inputType"), inputValue)
+	if !ok {
+		data.ErrorInfoList = append(data.ErrorInfoList, errorInfo{
+			InputValue: inputValue,
+			ErrorCode:  "PRODUCT_NOT_FOUND",
+			ErrorMsg:   "product not found",
+		})
+		data.ErrorCount = 1
+		writeLazada(w, data)
+		return
+	}
+
+	productId := strconv.FormatInt(product.Feed.ProductID, 10)
+	subs := url.Values{}
+	for i := 1; i <= 6; i++ {
+		key := fmt.Sprintf("sub%d", i)
+		if v := query.Get(key); v != "" {
+			subs.Set(key, v)
+		}
+	}
+	link := "https://c.lazada.co.th/t/c.fake" + productId
+	if len(subs) > 0 {
+		link += "?" + subs.Encode()
+	}
+	data.URLBatchGetLinkInfoList = append(data.URLBatchGetLinkInfoList, linkInfo{
+		RegularCommission:    strconv.FormatFloat(product.Feed.TotalCommissionRate, 'f', -1, 64),
+		ProductID:            productId,
+		OriginalURL:          inputValue,
+		RegularPromotionLink: link,
+		ProductName:          product.Feed.ProductName,
+	})
+	writeLazada(w, data)
+}
+
+// lazadaConversionReport pages through the conversions whose UTC date is between dateStart and
+// dateEnd.
+func (s *Server) lazadaConversionReport(w http.ResponseWriter, r *http.Request) {
+	if s.lazadaFailed(w, r, EndpointLazadaConversionReport) {
+		return
+	}
+	query := r.URL.Query()
+	dateStart := query.Get("dateStart")
+	dateEnd := query.Get("dateEnd")
+	rows := []LazadaConversion{}
+	s.mu.Lock()
+	for _, conversion := range s.fixtures.LazadaConversions {
+		date := time.UnixMilli(conversion.ConversionTime).UTC().Format(time.DateOnly)
+		if date >= dateStart && date <= dateEnd {
+			rows = append(rows, conversion)
+		}
+	}
+	s.mu.Unlock()
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	writeLazada(w, pageOf(rows, (page-1)*limit, limit))
+}
+
+// lazadaFailed checks the request is signed and applies any injected failure. It returns true
+// when the response has already been written.
+func (s *Server) lazadaFailed(w http.ResponseWriter, r *http.Request, endpoint string) bool {
+	failure := s.begin(endpoint)
+	query := r.URL.Query()
+	if failure == nil && (query.Get("app_key") == "" || query.Get("sign") == "" || query.Get("userToken") == "") {
+		failure = &Failure{Code: "IncompleteSignature", Message: "The request signature does not conform to platform standards"}
+	}
+	if failure == nil {
+		return false
+	}
+	time.Sleep(failure.Delay)
+	status := failure.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	code := failure.Code
+	if code == "" {
+		code = "ServiceUnavailable"
+	}
+	writeJSON(w, status, map[string]string{
+		"code":       code,
+		"type":       "ISV",
+		"message":    failure.Message,
+		"request_id": "fake",
+	})
+	return true
+}
+
+func (s *Server) lazadaProductById(id string) (LazadaProduct, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, product := range s.fixtures.Lazada {
+		if strconv.FormatInt(product.Feed.ProductID, 10) == id {
+			return product, true
+		}
+	}
+	return LazadaProduct{}, false
+}
+
+var lazadaProductIdPattern = regexp.MustCompile(`-i(\d+)`)
+
+func (s *Server) lazadaProductByInput(inputType, inputValue string) (LazadaProduct, bool) {
+	if inputType == "productId" {
+		return s.lazadaProductById(inputValue)
+	}
+	s.mu.Lock()
+	for _, product := range s.fixtures.Lazada {
+		if product.Url == inputValue {
+			s.mu.Unlock()
+			return product, true
+		}
+	}
+	s.mu.Unlock()
+	match := lazadaProductIdPattern.FindStringSubmatch(inputValue)
+	if match == nil {
+		return LazadaProduct{}, false
+	}
+	return s.lazadaProductById(match[1])
+}
+
+var (
+	shopeeProductOfferPattern = regexp.MustCompile(`productOfferV2\(shopId:\s*(\d+),\s*itemId:\s*(\d+)\)`)
+	shopeeOriginUrlPattern    = regexp.MustCompile(`originUrl:"([^"]*)"`)
+	shopeeSubIdsPattern       = regexp.MustCompile(`subIds:\[([^\]]*)\]`)
+	shopeeConversionPattern   = regexp.MustCompile(`conversionReport\(purchaseTimeStart:\s*(\d+),\s*purchaseTimeEnd:\s*(\d+),\s*limit:\s*(\d+)(?:,\s*scrollId:\s*"(\d*)")?\)`)
+)
+
+func (s *Server) shopeeGraphql(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Query string `json:"query"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		writeShopeeError(w, http.StatusBadRequest, 10010, "Request parsing error")
+		return
+	}
+
+	switch {
+	case strings.Contains(body.Query, "productOfferV2"):
+		if s.shopeeFailed(w, r, EndpointShopeeProductOffer) {
+			return
+		}
+		s.shopeeProductOffer(w, body.Query)
+	case strings.Contains(body.Query, "generateShortLink"):
+		if s.shopeeFailed(w, r, EndpointShopeeShortLink) {
+			return
+		}
+		s.shopeeShortLink(w, body.Query)
+	case strings.Contains(body.Query, "conversionReport"):
+		if s.shopeeFailed(w, r, EndpointShopeeConversionReport) {
+			return
+		}
+		s.shopeeConversionReport(w, body.Query)
+	default:
+		writeShopeeError(w, http.StatusOK, 10010, "Unsupported query")
+	}
+}
+
+func (s *Server) shopeeProductOffer(w http.ResponseWriter, query string) {
+	nodes := []ShopeeProduct{}
+	match := shopeeProductOfferPattern.FindStringSubmatch(query)
+	if match != nil {
+		s.mu.Lock()
+		for _, product := range s.fixtures.Shopee {
+			if strconv.Itoa(product.ShopID) == match[1] && strconv.FormatInt(product.ItemID, 10) == match[2] {
+				nodes = append(nodes, product)
+			}
+		}
+		s.mu.Unlock()
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": map[string]any{
+			"productOfferV2": map[string]any{
+				"nodes": nodes,
+				"pageInfo": map[string]any{
+					"page":        1,
+					"limit":       len(nodes),
+					"hasNextPage": false,
+				},
+			},
+		},
+	})
+}
+
+func (s *Server) shopeeShortLink(w http.ResponseWriter, query string) {
+	match := shopeeOriginUrlPattern.FindStringSubmatch(query)
+	if match == nil || match[1] == "" {
+		writeShopeeError(w, http.StatusOK, 11001, "Params Error : originUrl is required")
+		return
+	}
+	subIds := ""
+	if subMatch := shopeeSubIdsPattern.FindStringSubmatch(query); subMatch != nil {
+		subIds = subMatch[1]
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(match[1] + "|" + subIds))
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": map[string]any{
+			"generateShortLink": map[string]string{
+				"shortLink": fmt.Sprintf("https://s.shopee.co.th/fake%08x", hash.Sum32()),
+			},
+		},
+	})
+}
+
+// shopeeConversionReport pages through the conversions purchased in [purchaseTimeStart,
+// purchaseTimeEnd). The scroll id is simply the offset of the next page.
+func (s *Server) shopeeConversionReport(w http.ResponseWriter, query string) {
+	match := shopeeConversionPattern.FindStringSubmatch(query)
+	if match == nil {
+		writeShopeeError(w, http.StatusOK, 11001, "Params Error : purchaseTimeStart, purchaseTimeEnd and limit are required")
+		return
+	}
+	start, _ := strconv.ParseInt(match[1], 10, 64)
+	end, _ := strconv.ParseInt(match[2], 10, 64)
+	limit, _ := strconv.Atoi(match[3])
+	offset, _ := strconv.Atoi(match[4])
+	nodes := []ShopeeConversion{}
+	s.mu.Lock()
+	for _, conversion := range s.fixtures.ShopeeConversions {
+		if conversion.PurchaseTime >= start && conversion.PurchaseTime < end {
+			nodes = append(nodes, conversion)
+		}
+	}
+	s.mu.Unlock()
+	hasNextPage := offset+limit < len(nodes)
+	scrollId := ""
+	if hasNextPage {
+		scrollId = strconv.Itoa(offset + limit)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": map[string]any{
+			"conversionReport": map[string]any{
+				"nodes": pageOf(nodes, offset, limit),
+				"pageInfo": map[string]any{
+					"limit":       limit,
+					"hasNextPage": hasNextPage,
+					"scrollId":    scrollId,
+				},
+			},
+		},
+	})
+}
+
+// pageOf returns up to limit items starting at offset.
+func pageOf[T any](items []T, offset, limit int) []T {
+	if offset < 0 || offset >= len(items) {
+		return []T{}
+	}
+	end := offset + limit
+	if limit <= 0 || end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+// shopeeFailed checks the Authorization header and applies any injected failure. It returns
+// true when the response has already been written.
+func (s *Server) shopeeFailed(w http.ResponseWriter, r *http.Request, endpoint string) bool {
+	failure := s.begin(endpoint)
+	if failure == nil && !strings.HasPrefix(r.Header.Get("Authorization"), "SHA256 Credential=") {
+		failure = &Failure{Code: "10020", Message: "Invalid Signature"}
+	}
+	if failure == nil {
+		return false
+	}
+	time.Sleep(failure.Delay)
+	status := failure.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	code, err := strconv.Atoi(failure.Code)
+	if err != nil {
+		code = 10000
+	}
+	writeShopeeError(w, status, code, failure.Message)
+	return true
+}
+
+func (s *Server) shareLink(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	target, ok := s.fixtures.ShareLinks[r.PathValue("code")]
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// addFailure lets tools outside the process inject failures:
+// POST /_fake/failures {"endpoint": "lazada.getlink", "failure": {"code": "ApiCallLimit", "times": 1}}
+func (s *Server) addFailure(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Endpoint string  `json:"endpoint"`
+		Failure  Failure `json:"failure"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil || body.Endpoint == "" {
+		http.Error(w, "endpoint and failure are required", http.StatusBadRequest)
+		return
+	}
+	s.Fail(body.Endpoint, body.Failure)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) resetFailures(w http.ResponseWriter, r *http.Request) {
+	s.Reset()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeLazada(w http.ResponseWriter, data any) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"result": map[string]any{
+			"data":    data,
+			"success": true,
+		},
+		"code":       "0",
+		"request_id": "fake",
+	})
+}
+
+func writeShopeeError(w http.ResponseWriter, status int, code int, message string) {
+	writeJSON(w, status, map[string]any{
+		"errors": []map[string]any{{
+			"message": message,
+			"extensions": map[string]any{
+				"code":    code,
+				"message": message,
+			},
+		}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -52,6 +52,13 @@ func (h *CampaignHandler) CreateCampaign(g *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param utm_campaign query string false "UTM campaign filter"
+// @Param state query string false "State filter" Enums(draft, scheduled, active, paused, ended, archived)
+// @Param deleted query bool false "List soft deleted campaigns instead"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "next_cursor of the previous page; replaces page"
+// @Param sort query string false "Sort field (default start_at)" Enums(start_at, end_at, name, clicks)
+// @Param order query string false "Sort order (default desc)" Enums(asc, desc)
 // @Success 200 {object} dto.CampaignsResponse
 // @Failure 400 {string} string "Bad Request"
 // @Failure 401 {string} string "Unauthorized"
@@ -72,9 +79,226 @@ func (h *CampaignHandler) GetCampaigns(g *gin.Context) {
 	g.JSON(http.StatusOK, res)
 }
 
+// UpdateCampaign godoc
+// @Summary Update campaign
+// @Description Update the name, UTM campaign or dates of a campaign. Only the fields sent are changed. Set regenerate_links to refresh the affiliate urls of existing links when utm_campaign changes.
+// @Tags campaign
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaign_id path string true "Campaign ID"
+// @Param body body dto.UpdateCampaignRequest true "Campaign fields to update"
+// @Success 200 {object} dto.CampaignUpdateResult
+// @Failure 400 {object} dto.EmptyResponse "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /campaign/{campaign_id} [patch]
+func (h *CampaignHandler) UpdateCampaign(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	campaignId := g.Param("campaign_id")
+	body := dto.UpdateCampaignRequest{}
+	if err := g.ShouldBindJSON(&body); err != nil || campaignId == "" {
+		g.AbortWithStatus(400)
+		return
+	}
+	res, err := h.campaignService.UpdateCampaign(ctx, userId, campaignId, body)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// ChangeCampaignState godoc
+// @Summary Change campaign state
+// @Description Move a campaign to draft, paused, ended or archived, or publish/resume it with scheduled. Published campaigns become active and ended as their dates pass.
+// @Tags campaign
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaign_id path string true "Campaign ID"
+// @Param body body dto.CampaignStateRequest true "Target state"
+// @Success 200 {object} dto.CampaignResponse
+// @Failure 400 {object} dto.EmptyResponse "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /campaign/{campaign_id}/state [put]
+func (h *CampaignHandler) ChangeCampaignState(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	campaignId := g.Param("campaign_id")
+	body := dto.CampaignStateRequest{}
+	if err := g.ShouldBindJSON(&body); err != nil || campaignId == "" {
+		g.AbortWithStatus(400)
+		return
+	}
+	res, err := h.campaignService.ChangeCampaignState(ctx, userId, campaignId, body)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// CloneCampaign godoc
+// @Summary Clone a campaign
+// @Description Copy a campaign under new dates and UTM campaign, creating a new affiliate link for each of its products. Products whose link could not be created are listed in failures.
+// @Tags campaign
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaign_id path string true "Campaign ID"
+// @Param body body dto.CloneCampaignRequest true "Clone request"
+// @Success 200 {object} dto.CampaignCloneResult
+// @Failure 400 {object} dto.EmptyResponse "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Failure 404 {object} dto.EmptyResponse "Campaign not found"
+// @Router /campaign/{campaign_id}/clone [post]
+func (h *CampaignHandler) CloneCampaign(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	campaignId := g.Param("campaign_id")
+	body := dto.CloneCampaignRequest{}
+	if err := g.ShouldBindJSON(&body); err != nil || campaignId == "" {
+		g.AbortWithStatus(400)
+		return
+	}
+	res, err := h.campaignService.CloneCampaign(ctx, userId, campaignId, body)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// SetCampaignGoal godoc
+// @Summary Set campaign goal
+// @Description Set the click, unique visitor and estimated commission targets of a campaign. Notify thresholds are attainment percentages that publish an event the first time they are reached.
+// @Tags campaign
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaign_id path string true "Campaign ID"
+// @Param body body dto.CampaignGoalRequest true "Campaign goal"
+// @Success 200 {object} dto.CampaignGoalResult
+// @Failure 400 {object} dto.EmptyResponse "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Failure 404 {object} dto.EmptyResponse "Campaign not found"
+// @Router /campaign/{campaign_id}/goal [put]
+func (h *CampaignHandler) SetCampaignGoal(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	campaignId := g.Param("campaign_id")
+	body := dto.CampaignGoalRequest{}
+	if err := g.ShouldBindJSON(&body); err != nil || campaignId == "" {
+		g.AbortWithStatus(400)
+		return
+	}
+	res, err := h.campaignService.SetCampaignGoal(ctx, userId, campaignId, body)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// GetCampaignProgress godoc
+// @Summary Get campaign goal progress
+// @Description Get attainment and pacing of each goal target from the campaign's clicks so far
+// @Tags campaign
+// @Produce json
+// @Security BearerAuth
+// @Param campaign_id path string true "Campaign ID"
+// @Success 200 {object} dto.CampaignProgressResult
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Failure 404 {object} dto.EmptyResponse "Campaign not found or has no goal"
+// @Router /campaign/{campaign_id}/progress [get]
+func (h *CampaignHandler) GetCampaignProgress(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	campaignId := g.Param("campaign_id")
+	res, err := h.campaignService.GetCampaignProgress(ctx, userId, campaignId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// GetCampaignReport godoc
+// @Summary Get campaign report
+// @Description Get a campaign's click totals, daily series and per link and per product breakdown for a range of days in the campaign timezone. Clicks on deleted links are included.
+// @Tags campaign
+// @Produce json
+// @Security BearerAuth
+// @Param campaign_id path string true "Campaign ID"
+// @Param start_at query string false "First day (YYYY-MM-DD), default the campaign start date"
+// @Param end_at query string false "Last day (YYYY-MM-DD), default today or the campaign end date"
+// @Param compare query string false "Also report a comparison period and the change per link and product" Enums(previous_period, previous_month, previous_year)
+// @Success 200 {object} dto.CampaignReportResult
+// @Failure 400 {object} dto.EmptyResponse "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Failure 404 {object} dto.EmptyResponse "Campaign not found"
+// @Router /campaign/{campaign_id}/report [get]
+func (h *CampaignHandler) GetCampaignReport(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	campaignId := g.Param("campaign_id")
+	query := dto.CampaignReportRequest{}
+	if err := g.ShouldBindQuery(&query); err != nil || campaignId == "" {
+		g.AbortWithStatus(400)
+		return
+	}
+	res, err := h.campaignService.GetCampaignReport(ctx, userId, campaignId, query)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// ExportCampaignReport godoc
+// @Summary Export campaign report
+// @Description Download a campaign report as CSV or XLSX, with the same filters as /campaign/{campaign_id}/report: the daily series, links and products and, with compare, the comparison period. XLSX puts each in its own sheet; CSV separates them with an empty line.
+// @Tags campaign
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,json
+// @Security BearerAuth
+// @Param campaign_id path string true "Campaign ID"
+// @Param format query string false "File format" Enums(csv, xlsx) default(csv)
+// @Param start_at query string false "First day (YYYY-MM-DD), default the campaign start date"
+// @Param end_at query string false "Last day (YYYY-MM-DD), default today or the campaign end date"
+// @Param compare query string false "Also export a comparison period and the change per link and product" Enums(previous_period, previous_month, previous_year)
+// @Success 200 {file} file "campaign-report.csv or .xlsx"
+// @Failure 400 {object} dto.EmptyResponse "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Failure 404 {object} dto.EmptyResponse "Campaign not found"
+// @Router /campaign/{campaign_id}/report/export [get]
+func (h *CampaignHandler) ExportCampaignReport(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	campaignId := g.Param("campaign_id")
+	query := dto.CampaignReportRequest{}
+	if err := g.ShouldBindQuery(&query); err != nil || campaignId == "" {
+		g.AbortWithStatus(400)
+		return
+	}
+	out, w, ok := startExport(g, "campaign-report")
+	if !ok {
+		return
+	}
+	res, err := h.campaignService.ExportCampaignReport(ctx, userId, campaignId, query, w)
+	finishExport(g, out, w, res, err)
+}
+
 // DeleteCampaign godoc
 // @Summary Delete campaign
-// @Description Delete a campaign and all associated links and clicks
+// @Description Soft delete a campaign and its links. It can be restored until the retention period has passed; clicks stay in analytics.
 // @Tags campaign
 // @Produce json
 // @Security BearerAuth
@@ -100,14 +324,48 @@ func (h *CampaignHandler) DeleteCampaign(g *gin.Context) {
 	g.JSON(http.StatusOK, res)
 }
 
+// RestoreCampaign godoc
+// @Summary Restore campaign
+// @Description Restore a soft deleted campaign and the links deleted with it
+// @Tags campaign
+// @Produce json
+// @Security BearerAuth
+// @Param campaign_id path string true "Campaign ID"
+// @Success 200 {object} dto.CampaignResponse
+// @Failure 400 {object} dto.EmptyResponse "Campaign is not deleted"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Failure 404 {object} dto.EmptyResponse "Campaign not found"
+// @Router /campaign/{campaign_id}/restore [post]
+func (h *CampaignHandler) RestoreCampaign(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	campaignId := g.Param("campaign_id")
+	if campaignId == "" {
+		g.AbortWithStatus(400)
+		return
+	}
+	res, err := h.campaignService.RestoreCampaign(ctx, userId, campaignId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
 // GetPublicCampaigns godoc
 // @Summary Get public campaigns
-// @Description Get all public campaigns available for everyone
+// @Description Get active campaigns of every user
 // @Tags campaign
 // @Produce json
 // @Param utm_campaign query string false "UTM campaign filter"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "next_cursor of the previous page; replaces page"
+// @Param sort query string false "Sort field (default start_at)" Enums(start_at, end_at, name)
+// @Param order query string false "Sort order (default desc)" Enums(asc, desc)
 // @Success 200 {object} dto.CampaignsResponse
-// @Failure 400 {string} string "Bad Request"
+// @Failure 400 {object} dto.EmptyResponse "Bad Request"
 // @Router /campaign/available [get]
 func (h *CampaignHandler) GetPublicCampaigns(g *gin.Context) {
 	ctx := g.Request.Context()
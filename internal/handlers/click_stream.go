@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+type ClickStreamHandler struct {
+	clickStreamService ports.ClickStreamService
+	heartbeat          time.Duration
+}
+
+// NewClickStreamHandler sends a comment on idle streams every heartbeat, so proxies and load
+// balancers do not close them.
+func NewClickStreamHandler(clickStreamService ports.ClickStreamService, heartbeat time.Duration) *ClickStreamHandler {
+	return &ClickStreamHandler{clickStreamService: clickStreamService, heartbeat: heartbeat}
+}
+
+// StreamClicks godoc
+// @Summary Stream clicks live
+// @Description Server-Sent Events stream of the clicks on the user's links as they are recorded, optionally only those of one campaign or link. Every click is a `click` event whose data is a domains.ClickRecorded. Clicks are not replayed: the stream starts with the clicks after it is opened, and a client that falls far behind misses clicks rather than slowing redirects down.
+// @Tags dashboard
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param campaign_id query string false "Campaign ID"
+// @Param link_id query string false "Link ID"
+// @Success 200 {object} domains.ClickRecorded
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse
+// @Failure 404 {object} dto.EmptyResponse
+// @Router /dashboard/clicks/stream [get]
+func (h *ClickStreamHandler) StreamClicks(g *gin.Context) {
+	ctx := g.Request.Context()
+	query := dto.ClickStreamRequest{}
+	if err := g.ShouldBindQuery(&query); err != nil {
+		g.AbortWithStatus(400)
+		return
+	}
+	userId := g.GetInt64("userId")
+	res, err := h.clickStreamService.Subscribe(ctx, userId, query)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+
+	g.Header("Content-Type", "text/event-stream")
+	g.Header("Cache-Control", "no-cache")
+	// Stops nginx from buffering the stream.
+	g.Header("X-Accel-Buffering", "no")
+	g.Status(200)
+	g.Writer.Flush()
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	g.Stream(func(w io.Writer) bool {
+		select {
+		case click, ok := <-res.Data:
+			if !ok {
+				return false
+			}
+			g.SSEvent("click", click)
+		case <-heartbeat.C:
+			io.WriteString(w, ": heartbeat\n\n")
+		}
+		return true
+	})
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+type CollectionHandler struct {
+	collectionService ports.CollectionService
+}
+
+func NewCollectionHandler(collectionService ports.CollectionService) *CollectionHandler {
+	return &CollectionHandler{collectionService: collectionService}
+}
+
+// CreateCollection godoc
+// @Summary Create a collection
+// @Description Create a named, ordered list of products
+// @Tags collection
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body dto.CollectionRequest true "Collection request"
+// @Success 200 {object} dto.CollectionResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /collection [post]
+func (h *CollectionHandler) CreateCollection(g *gin.Context) {
+	ctx := g.Request.Context()
+	body := dto.CollectionRequest{}
+	if err := g.ShouldBindJSON(&body); err != nil {
+		g.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	userId := g.GetInt64("userId")
+	res, err := h.collectionService.CreateCollection(ctx, userId, body)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// GetCollections godoc
+// @Summary Get user collections
+// @Description Get all collections of the authenticated user
+// @Tags collection
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.CollectionsResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Router /collection [get]
+func (h *CollectionHandler) GetCollections(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	res, err := h.collectionService.GetCollections(ctx, userId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// GetCollectionById godoc
+// @Summary Get collection
+// @Description Get a collection with its products in order
+// @Tags collection
+// @Produce json
+// @Security BearerAuth
+// @Param collection_id path string true "Collection ID"
+// @Success 200 {object} dto.CollectionResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /collection/{collection_id} [get]
+func (h *CollectionHandler) GetCollectionById(g *gin.Context) {
+	ctx := g.Request.Context()
+	collectionId := g.Param("collection_id")
+	userId := g.GetInt64("userId")
+	res, err := h.collectionService.GetCollectionById(ctx, userId, collectionId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// UpdateCollection godoc
+// @Summary Update collection
+// @Description Rename a collection and, when product_ids is given, replace its products in the given order
+// @Tags collection
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param collection_id path string true "Collection ID"
+// @Param body body dto.CollectionRequest true "Collection request"
+// @Success 200 {object} dto.CollectionResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /collection/{collection_id} [put]
+func (h *CollectionHandler) UpdateCollection(g *gin.Context) {
+	ctx := g.Request.Context()
+	collectionId := g.Param("collection_id")
+	body := dto.CollectionRequest{}
+	if err := g.ShouldBindJSON(&body); err != nil {
+		g.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	userId := g.GetInt64("userId")
+	res, err := h.collectionService.UpdateCollection(ctx, userId, collectionId, body)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// DeleteCollection godoc
+// @Summary Delete collection
+// @Description Delete a collection. The products themselves are kept.
+// @Tags collection
+// @Produce json
+// @Security BearerAuth
+// @Param collection_id path string true "Collection ID"
+// @Success 200 {object} dto.EmptyResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /collection/{collection_id} [delete]
+func (h *CollectionHandler) DeleteCollection(g *gin.Context) {
+	ctx := g.Request.Context()
+	collectionId := g.Param("collection_id")
+	userId := g.GetInt64("userId")
+	res, err := h.collectionService.DeleteCollection(ctx, userId, collectionId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// AddCollectionProduct godoc
+// @Summary Add product to collection
+// @Description Append a product to the end of a collection
+// @Tags collection
+// @Produce json
+// @Security BearerAuth
+// @Param collection_id path string true "Collection ID"
+// @Param productId path string true "Product ID"
+// @Success 200 {object} dto.EmptyResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /collection/{collection_id}/product/{productId} [post]
+func (h *CollectionHandler) AddCollectionProduct(g *gin.Context) {
+	ctx := g.Request.Context()
+	collectionId := g.Param("collection_id")
+	productId := g.Param("productId")
+	userId := g.GetInt64("userId")
+	res, err := h.collectionService.AddCollectionProduct(ctx, userId, collectionId, productId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// RemoveCollectionProduct godoc
+// @Summary Remove product from collection
+// @Description Remove a product from a collection
+// @Tags collection
+// @Produce json
+// @Security BearerAuth
+// @Param collection_id path string true "Collection ID"
+// @Param productId path string true "Product ID"
+// @Success 200 {object} dto.EmptyResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /collection/{collection_id}/product/{productId} [delete]
+func (h *CollectionHandler) RemoveCollectionProduct(g *gin.Context) {
+	ctx := g.Request.Context()
+	collectionId := g.Param("collection_id")
+	productId := g.Param("productId")
+	userId := g.GetInt64("userId")
+	res, err := h.collectionService.RemoveCollectionProduct(ctx, userId, collectionId, productId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// CreateCollectionLinks godoc
+// @Summary Create links for a collection
+// @Description Create an affiliate link for every product in the collection against a campaign. Products that fail are reported in failures.
+// @Tags collection
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param collection_id path string true "Collection ID"
+// @Param body body dto.CreateCollectionLinksRequest true "Campaign to link against"
+// @Success 200 {object} dto.BulkLinkResult
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /collection/{collection_id}/link [post]
+func (h *CollectionHandler) CreateCollectionLinks(g *gin.Context) {
+	ctx := g.Request.Context()
+	collectionId := g.Param("collection_id")
+	body := dto.CreateCollectionLinksRequest{}
+	if err := g.ShouldBindJSON(&body); err != nil {
+		g.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	userId := g.GetInt64("userId")
+	res, err := h.collectionService.CreateCollectionLinks(ctx, userId, collectionId, body)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
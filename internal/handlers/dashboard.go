@@ -1,9 +1,8 @@
 package handlers
 
 import (
-	"time"
-
 	"github.com/gin-gonic/gin"
+	"github.com/market-place-affiliate/api/internal/core/dto"
 	"github.com/market-place-affiliate/api/internal/core/ports"
 )
 
@@ -17,38 +16,159 @@ func NewDashboardHandler(dashboardService ports.DashboardService) *DashboardHand
 
 // GetDashboardData godoc
 // @Summary Get dashboard metrics
-// @Description Get dashboard analytics including clicks, products, and performance metrics
+// @Description Get dashboard analytics including clicks, products, and performance metrics. Clicks are bucketed by granularity in the tz timezone, and every bucket of the range is returned, with zero clicks when empty. Buckets also count imported conversions with their sales and revenue, and performance gives each series' conversion rate and earnings per click.
 // @Tags dashboard
 // @Produce json
 // @Security BearerAuth
-// @Param start_at query string false "Start date (YYYY-MM-DD)" default("7 days ago")
-// @Param end_at query string false "End date (YYYY-MM-DD)" default("tomorrow")
+// @Param start_at query string false "Start date (YYYY-MM-DD, midnight in tz) or RFC 3339 time" default("7 days ago")
+// @Param end_at query string false "Exclusive end date (YYYY-MM-DD, midnight in tz) or RFC 3339 time" default("tomorrow")
+// @Param granularity query string false "Bucket size" Enums(hour, day, week, month) default(day)
+// @Param tz query string false "IANA time zone, e.g. Asia/Bangkok" default(CAMPAIGN_TIMEZONE)
+// @Param compare query string false "Also count a comparison period and the change per series" Enums(previous_period, previous_month, previous_year)
 // @Success 200 {object} dto.DashboardResponse
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} dto.DashboardResponse
 // @Failure 401 {string} string "Unauthorized"
 // @Router /dashboard/metrics [get]
 func (h *DashboardHandler) GetDashboardData(g *gin.Context) {
 	ctx := g.Request.Context()
 	userId := g.GetInt64("userId")
-	start := g.Query("start_at")
-	end := g.Query("end_at")
-	if start == "" {
-		start = time.Now().AddDate(0, 0, -7).Format(time.DateOnly)
-	}
-	if end == "" {
-		end = time.Now().AddDate(0, 0, 1).Format(time.DateOnly)
+	var query dto.DashboardMetricsRequest
+	if err := g.ShouldBindQuery(&query); err != nil {
+		g.AbortWithStatus(400)
+		return
 	}
-	startTime, err := time.Parse(time.DateOnly, start)
+	res, err := h.dashboardService.GetDashboardMetrics(ctx, userId, query)
 	if err != nil {
-		g.JSON(400, gin.H{"error": "Invalid start date format"})
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(200, res)
+}
+
+// ExportDashboardMetrics godoc
+// @Summary Export dashboard metrics
+// @Description Download the dashboard metrics as CSV or XLSX, with the same filters as /dashboard/metrics: the buckets, each series' performance and, with compare, the buckets of the comparison period. XLSX puts each in its own sheet; CSV separates them with an empty line.
+// @Tags dashboard
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,json
+// @Security BearerAuth
+// @Param format query string false "File format" Enums(csv, xlsx) default(csv)
+// @Param start_at query string false "Start date (YYYY-MM-DD, midnight in tz) or RFC 3339 time" default("7 days ago")
+// @Param end_at query string false "Exclusive end date (YYYY-MM-DD, midnight in tz) or RFC 3339 time" default("tomorrow")
+// @Param granularity query string false "Bucket size" Enums(hour, day, week, month) default(day)
+// @Param tz query string false "IANA time zone, e.g. Asia/Bangkok" default(CAMPAIGN_TIMEZONE)
+// @Param compare query string false "Also export the comparison period" Enums(previous_period, previous_month, previous_year)
+// @Success 200 {file} file "dashboard-metrics.csv or .xlsx"
+// @Failure 400 {object} dto.EmptyResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Router /dashboard/metrics/export [get]
+func (h *DashboardHandler) ExportDashboardMetrics(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	var query dto.DashboardMetricsRequest
+	if err := g.ShouldBindQuery(&query); err != nil {
+		g.AbortWithStatus(400)
+		return
+	}
+	out, w, ok := startExport(g, "dashboard-metrics")
+	if !ok {
+		return
+	}
+	res, err := h.dashboardService.ExportDashboardMetrics(ctx, userId, query, w)
+	finishExport(g, out, w, res, err)
+}
+
+// ExportClickLog godoc
+// @Summary Export click log
+// @Description Download every click in a range as CSV or XLSX, streamed as it is read. The range works like in /dashboard/metrics.
+// @Tags dashboard
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,json
+// @Security BearerAuth
+// @Param format query string false "File format" Enums(csv, xlsx) default(csv)
+// @Param start_at query string false "Start date (YYYY-MM-DD, midnight in tz) or RFC 3339 time" default("7 days ago")
+// @Param end_at query string false "Exclusive end date (YYYY-MM-DD, midnight in tz) or RFC 3339 time" default("tomorrow")
+// @Param tz query string false "IANA time zone of the range and click times" default(CAMPAIGN_TIMEZONE)
+// @Param campaign_id query string false "Only clicks of this campaign"
+// @Param link_id query string false "Only clicks of this link"
+// @Success 200 {file} file "clicks.csv or .xlsx"
+// @Failure 400 {object} dto.EmptyResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Router /dashboard/clicks/export [get]
+func (h *DashboardHandler) ExportClickLog(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	var query dto.ClickLogRequest
+	if err := g.ShouldBindQuery(&query); err != nil {
+		g.AbortWithStatus(400)
 		return
 	}
-	endTime, err := time.Parse(time.DateOnly, end)
+	out, w, ok := startExport(g, "clicks")
+	if !ok {
+		return
+	}
+	res, err := h.dashboardService.ExportClickLog(ctx, userId, query, w)
+	finishExport(g, out, w, res, err)
+}
+
+// GetDashboardBreakdown godoc
+// @Summary Get a dashboard breakdown
+// @Description Group the clicks of a range by one dimension of their visitor and rank its values, with the change of the sort metric since a comparison period. Sources are the social network the click came from (by referrer, or in-app browser when there is none), direct or other; referrers are domains. Values the visitor did not send have an empty key.
+// @Tags dashboard
+// @Produce json
+// @Security BearerAuth
+// @Param dimension query string true "Dimension to group by" Enums(source, referrer, device, os, browser, language)
+// @Param start_at query string false "Start date (YYYY-MM-DD, midnight in tz) or RFC 3339 time" default("7 days ago")
+// @Param end_at query string false "Exclusive end date (YYYY-MM-DD, midnight in tz) or RFC 3339 time" default("tomorrow")
+// @Param tz query string false "IANA time zone, e.g. Asia/Bangkok" default(CAMPAIGN_TIMEZONE)
+// @Param campaign_id query string false "Only the clicks of this campaign"
+// @Param link_id query string false "Only the clicks of this link"
+// @Param limit query int false "Values to list" minimum(1) maximum(100) default(20)
+// @Param sort query string false "Metric to rank by" Enums(clicks, unique_visitors) default(clicks)
+// @Param compare query string false "Period the deltas compare with" Enums(previous_period, previous_month, previous_year) default(previous_period)
+// @Success 200 {object} dto.DashboardBreakdownResult
+// @Failure 400 {object} dto.DashboardBreakdownResult
+// @Failure 401 {string} string "Unauthorized"
+// @Router /dashboard/breakdown [get]
+func (h *DashboardHandler) GetDashboardBreakdown(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	var query dto.DashboardBreakdownRequest
+	if err := g.ShouldBindQuery(&query); err != nil {
+		g.AbortWithStatus(400)
+		return
+	}
+	res, err := h.dashboardService.GetDashboardBreakdown(ctx, userId, query)
 	if err != nil {
-		g.JSON(400, gin.H{"error": "Invalid end date format"})
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(200, res)
+}
+
+// GetDashboardLeaderboards godoc
+// @Summary Get dashboard leaderboards
+// @Description Rank the most clicked products, links, campaigns and marketplaces in a range, with the change of the sort metric since a comparison period. Boards are empty when nothing was clicked.
+// @Tags dashboard
+// @Produce json
+// @Security BearerAuth
+// @Param start_at query string false "Start date (YYYY-MM-DD, midnight in tz) or RFC 3339 time" default("7 days ago")
+// @Param end_at query string false "Exclusive end date (YYYY-MM-DD, midnight in tz) or RFC 3339 time" default("tomorrow")
+// @Param tz query string false "IANA time zone, e.g. Asia/Bangkok" default(CAMPAIGN_TIMEZONE)
+// @Param limit query int false "Entries per leaderboard" minimum(1) maximum(50) default(10)
+// @Param sort query string false "Metric to rank by" Enums(clicks, unique_visitors) default(clicks)
+// @Param compare query string false "Period the deltas compare with" Enums(previous_period, previous_month, previous_year) default(previous_period)
+// @Success 200 {object} dto.DashboardLeaderboardsResult
+// @Failure 400 {object} dto.DashboardLeaderboardsResult
+// @Failure 401 {string} string "Unauthorized"
+// @Router /dashboard/leaderboards [get]
+func (h *DashboardHandler) GetDashboardLeaderboards(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	var query dto.DashboardLeaderboardRequest
+	if err := g.ShouldBindQuery(&query); err != nil {
+		g.AbortWithStatus(400)
 		return
 	}
-	res, err := h.dashboardService.GetDashboardMetrics(ctx, userId, startTime, endTime)
+	res, err := h.dashboardService.GetDashboardLeaderboards(ctx, userId, query)
 	if err != nil {
 		g.JSON(res.HttpCode, res)
 		return
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/pkg/export"
+)
+
+// exportResponse streams an export to the client as a file download. The download headers are
+// only sent with the first bytes, so an export that fails before writing any can still be
+// answered with a JSON error.
+type exportResponse struct {
+	g        *gin.Context
+	format   string
+	filename string
+	started  bool
+}
+
+func (r *exportResponse) Write(p []byte) (int, error) {
+	if !r.started {
+		r.started = true
+		r.g.Header("Content-Type", export.ContentType(r.format))
+		r.g.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, r.filename, r.format))
+		r.g.Status(200)
+	}
+	return r.g.Writer.Write(p)
+}
+
+// startExport binds the format query parameter and returns a writer of that format named
+// filename, or aborts with 400.
+func startExport(g *gin.Context, filename string) (*exportResponse, export.Writer, bool) {
+	query := dto.ExportRequest{}
+	if err := g.ShouldBindQuery(&query); err != nil {
+		g.AbortWithStatus(400)
+		return nil, nil, false
+	}
+	if query.Format == "" {
+		query.Format = export.FormatCSV
+	}
+	out := &exportResponse{g: g, format: query.Format, filename: filename}
+	w, err := export.NewWriter(query.Format, out)
+	if err != nil {
+		g.AbortWithStatus(400)
+		return nil, nil, false
+	}
+	return out, w, true
+}
+
+// finishExport completes the download, or answers with res when the export failed before
+// anything was sent. A failure halfway can only leave the download incomplete.
+func finishExport(g *gin.Context, out *exportResponse, w export.Writer, res dto.Response[any], err error) {
+	if err == nil && res.Success {
+		err = w.Close()
+		if err != nil {
+			log.Printf("Failed to finish %s export: %v\n", out.filename, err)
+		}
+		return
+	}
+	if !out.started {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	log.Printf("Export %s failed after it started: %v\n", out.filename, err)
+	g.Abort()
+}
@@ -95,7 +95,12 @@ func (h *LinkHandler) GetLinkByShortCode(g *gin.Context) {
 func (h *LinkHandler) RedirectLink(g *gin.Context) {
 	ctx := g.Request.Context()
 	code := g.Param("short_code")
-	res, err := h.linkService.ClickByShortCode(ctx, code)
+	res, err := h.linkService.ClickByShortCode(ctx, code, dto.ClickRequest{
+		IpAddress:      g.ClientIP(),
+		UserAgent:      g.Request.UserAgent(),
+		Referrer:       g.Request.Referer(),
+		AcceptLanguage: g.GetHeader("Accept-Language"),
+	})
 	if err != nil {
 		g.JSON(res.HttpCode, res)
 		return
@@ -123,6 +128,32 @@ func (h *LinkHandler) GetLinksByCampaign(g *gin.Context) {
 	g.JSON(http.StatusOK, res)
 }
 
+// ExportCampaignLinks godoc
+// @Summary Export campaign links
+// @Description Download the links of one of your campaigns as CSV or XLSX
+// @Tags link
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,json
+// @Security BearerAuth
+// @Param campaignId path string true "Campaign ID"
+// @Param format query string false "File format" Enums(csv, xlsx) default(csv)
+// @Success 200 {file} file "campaign-links.csv or .xlsx"
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Failure 404 {object} dto.EmptyResponse "Not Found"
+// @Router /link/campaign/{campaignId}/export [get]
+func (h *LinkHandler) ExportCampaignLinks(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	campaignId := g.Param("campaignId")
+	out, w, ok := startExport(g, "campaign-links")
+	if !ok {
+		return
+	}
+	res, err := h.linkService.ExportCampaignLinks(ctx, userId, campaignId, w)
+	finishExport(g, out, w, res, err)
+}
+
 // DeleteLink godoc
 // @Summary Delete link
 // @Description Delete a link and all associated clicks
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+type PostbackHandler struct {
+	postbackService ports.PostbackService
+}
+
+func NewPostbackHandler(postbackService ports.PostbackService) *PostbackHandler {
+	return &PostbackHandler{postbackService: postbackService}
+}
+
+// postbackSecretHeader carries the postback secret of GET postbacks, which cannot send it in
+// the url without it being logged.
+const postbackSecretHeader = "X-Postback-Secret"
+
+// RecordPostback godoc
+// @Summary Record a conversion postback
+// @Description Server to server postback from a network or advertiser, served at /postback outside the API base path. Records an order against the click id the redirect passed to the destination, as a query string, form or JSON body. Authenticate with the link owner's postback secret, sent in the X-Postback-Secret header or as secret in a POST body but never in the query string, or with signature: the hex HMAC-SHA256, keyed by the secret, of the other non-empty parameters query encoded in key order (amount, click_id, currency, order_id, payout, status). An order posted back again updates the status, payout and amount recorded for it.
+// @Tags postback
+// @Accept json
+// @Produce json
+// @Param click_id query string true "Click ID"
+// @Param order_id query string true "Order ID, unique per user"
+// @Param payout query number false "Commission earned"
+// @Param amount query number false "Order value"
+// @Param currency query string false "ISO 4217 currency, defaults to the product's"
+// @Param status query string false "Conversion status (pending/approved/cancelled), defaults to approved"
+// @Param X-Postback-Secret header string false "Postback secret"
+// @Param signature query string false "HMAC-SHA256 signature"
+// @Success 200 {object} dto.ConversionResult
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {object} dto.EmptyResponse
+// @Failure 404 {object} dto.EmptyResponse
+// @Router /postback [get]
+// @Router /postback [post]
+func (h *PostbackHandler) RecordPostback(g *gin.Context) {
+	ctx := g.Request.Context()
+	if _, ok := g.GetQuery("secret"); ok {
+		g.AbortWithStatus(400)
+		return
+	}
+	postback := dto.PostbackRequest{}
+	if err := g.ShouldBind(&postback); err != nil {
+		g.AbortWithStatus(400)
+		return
+	}
+	if postback.Secret == "" {
+		postback.Secret = g.PostForm("secret")
+	}
+	if postback.Secret == "" {
+		postback.Secret = g.GetHeader(postbackSecretHeader)
+	}
+	res, err := h.postbackService.RecordPostback(ctx, postback)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
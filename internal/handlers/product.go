@@ -18,7 +18,7 @@ func NewProductHandler(productService ports.ProductService) *ProductHandler {
 
 // AddProduct godoc
 // @Summary Add a new product
-// @Description Create a new affiliate product from marketplace URL
+// @Description Create a new affiliate product from marketplace URL. Share links such as s.shopee.co.th/xxxx are followed to the product page first.
 // @Tags product
 // @Accept json
 // @Produce json
@@ -27,6 +27,7 @@ func NewProductHandler(productService ports.ProductService) *ProductHandler {
 // @Success 200 {object} dto.ProductsResponse
 // @Failure 400 {string} string "Bad Request"
 // @Failure 401 {string} string "Unauthorized"
+// @Failure 502 {object} dto.EmptyResponse "Share link could not be resolved or marketplace unavailable"
 // @Router /product [post]
 func (h *ProductHandler) AddProduct(g *gin.Context) {
 	ctx := g.Request.Context()
@@ -73,13 +74,66 @@ func (h *ProductHandler) GetOffers(g *gin.Context) {
 // @Tags product
 // @Produce json
 // @Security BearerAuth
+// @Param tag_id query string false "Only products with this tag"
+// @Param collection_id query string false "Only products in this collection, in collection order"
+// @Param deleted query bool false "List soft deleted products instead"
 // @Success 200 {object} dto.ProductsResponse
+// @Failure 400 {string} string "Bad Request"
 // @Failure 401 {string} string "Unauthorized"
 // @Router /product [get]
 func (h *ProductHandler) GetProducts(g *gin.Context) {
 	ctx := g.Request.Context()
 	userId := g.GetInt64("userId")
-	res, err := h.productService.GetProductsByUserId(ctx, userId)
+	query := dto.GetProductsQueryRequest{}
+	if err := g.ShouldBindQuery(&query); err != nil {
+		g.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	res, err := h.productService.GetProductsByUserId(ctx, userId, query)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// RefreshProduct godoc
+// @Summary Refresh product
+// @Description Re-fetch title, images and offers from the marketplace with your credentials and update the product in place, keeping its links and clicks. Returns what changed.
+// @Tags product
+// @Produce json
+// @Security BearerAuth
+// @Param productId path string true "Product ID"
+// @Success 200 {object} dto.ProductRefreshResult
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Failure 502 {object} dto.EmptyResponse "Marketplace unavailable"
+// @Router /product/{productId}/refresh [post]
+func (h *ProductHandler) RefreshProduct(g *gin.Context) {
+	ctx := g.Request.Context()
+	productId := g.Param("productId")
+	userId := g.GetInt64("userId")
+	res, err := h.productService.RefreshProduct(ctx, userId, productId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// GetUnavailableProducts godoc
+// @Summary Get unavailable products
+// @Description Get products whose offers are all out of stock or delisted, with the number of links still pointing at each
+// @Tags product
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.UnavailableProductsResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Router /product/unavailable [get]
+func (h *ProductHandler) GetUnavailableProducts(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	res, err := h.productService.GetUnavailableProducts(ctx, userId)
 	if err != nil {
 		g.JSON(res.HttpCode, res)
 		return
@@ -89,7 +143,7 @@ func (h *ProductHandler) GetProducts(g *gin.Context) {
 
 // DeleteProduct godoc
 // @Summary Delete product
-// @Description Delete a product and all associated links and clicks
+// @Description Soft delete a product and its links. It can be restored until the retention period has passed; clicks stay in analytics.
 // @Tags product
 // @Produce json
 // @Security BearerAuth
@@ -110,6 +164,30 @@ func (h *ProductHandler) DeleteProduct(g *gin.Context) {
 	g.JSON(http.StatusOK, res)
 }
 
+// RestoreProduct godoc
+// @Summary Restore product
+// @Description Restore a soft deleted product and the links deleted with it
+// @Tags product
+// @Produce json
+// @Security BearerAuth
+// @Param productId path string true "Product ID"
+// @Success 200 {object} dto.ProductResponse
+// @Failure 400 {object} dto.EmptyResponse "Product is not deleted"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /product/{productId}/restore [post]
+func (h *ProductHandler) RestoreProduct(g *gin.Context) {
+	ctx := g.Request.Context()
+	productId := g.Param("productId")
+	userId := g.GetInt64("userId")
+	res, err := h.productService.RestoreProduct(ctx, userId, productId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
 // GetProductById godoc
 // @Summary Get product by ID
 // @Description Get a specific product by its ID
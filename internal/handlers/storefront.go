@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+type StorefrontHandler struct {
+	storefrontService ports.StorefrontService
+	cacheMaxAge       time.Duration
+}
+
+func NewStorefrontHandler(storefrontService ports.StorefrontService, cacheMaxAge time.Duration) *StorefrontHandler {
+	return &StorefrontHandler{storefrontService: storefrontService, cacheMaxAge: cacheMaxAge}
+}
+
+// GetCampaignStorefront godoc
+// @Summary Get a campaign storefront
+// @Description Get an active campaign with its products, their best available offer and short link, for rendering landing pages. Responses carry Cache-Control and ETag headers and honour If-None-Match.
+// @Tags storefront
+// @Produce json
+// @Param campaign_id path string true "Campaign ID"
+// @Success 200 {object} dto.StorefrontCampaignResult
+// @Success 304 {string} string "Not Modified"
+// @Failure 404 {object} dto.StorefrontCampaignResult
+// @Router /storefront/campaign/{campaign_id} [get]
+func (h *StorefrontHandler) GetCampaignStorefront(g *gin.Context) {
+	ctx := g.Request.Context()
+	campaignId := g.Param("campaign_id")
+	if campaignId == "" {
+		g.AbortWithStatus(400)
+		return
+	}
+	res, err := h.storefrontService.GetCampaignStorefront(ctx, campaignId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	h.cachedJSON(g, res)
+}
+
+// GetUserStorefront godoc
+// @Summary Get a user storefront
+// @Description Get every active campaign of a user with its products, their best available offer and short link. Responses carry Cache-Control and ETag headers and honour If-None-Match.
+// @Tags storefront
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Success 200 {object} dto.UserStorefrontResult
+// @Success 304 {string} string "Not Modified"
+// @Failure 400 {string} string "Bad Request"
+// @Router /storefront/user/{user_id} [get]
+func (h *StorefrontHandler) GetUserStorefront(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId, err := strconv.ParseInt(g.Param("user_id"), 10, 64)
+	if err != nil {
+		g.AbortWithStatus(400)
+		return
+	}
+	res, err := h.storefrontService.GetUserStorefront(ctx, userId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	h.cachedJSON(g, res)
+}
+
+// cachedJSON writes body with a public Cache-Control and an ETag of its content, answering 304
+// when the client already has it.
+func (h *StorefrontHandler) cachedJSON(g *gin.Context, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		g.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	g.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.cacheMaxAge.Seconds())))
+	g.Header("ETag", etag)
+	if g.GetHeader("If-None-Match") == etag {
+		g.Status(http.StatusNotModified)
+		return
+	}
+	g.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+type TagHandler struct {
+	tagService ports.TagService
+}
+
+func NewTagHandler(tagService ports.TagService) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+// CreateTag godoc
+// @Summary Create a tag
+// @Description Create a new product tag for the authenticated user
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body dto.TagRequest true "Tag request"
+// @Success 200 {object} dto.TagResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Router /tag [post]
+func (h *TagHandler) CreateTag(g *gin.Context) {
+	ctx := g.Request.Context()
+	body := dto.TagRequest{}
+	if err := g.ShouldBindJSON(&body); err != nil {
+		g.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	userId := g.GetInt64("userId")
+	res, err := h.tagService.CreateTag(ctx, userId, body)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// GetTags godoc
+// @Summary Get user tags
+// @Description Get all product tags of the authenticated user
+// @Tags tag
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.TagsResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Router /tag [get]
+func (h *TagHandler) GetTags(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	res, err := h.tagService.GetTags(ctx, userId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// UpdateTag godoc
+// @Summary Rename a tag
+// @Description Rename a product tag
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tag_id path string true "Tag ID"
+// @Param body body dto.TagRequest true "Tag request"
+// @Success 200 {object} dto.TagResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /tag/{tag_id} [put]
+func (h *TagHandler) UpdateTag(g *gin.Context) {
+	ctx := g.Request.Context()
+	tagId := g.Param("tag_id")
+	body := dto.TagRequest{}
+	if err := g.ShouldBindJSON(&body); err != nil {
+		g.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+	userId := g.GetInt64("userId")
+	res, err := h.tagService.UpdateTag(ctx, userId, tagId, body)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// DeleteTag godoc
+// @Summary Delete a tag
+// @Description Delete a tag and remove it from all products
+// @Tags tag
+// @Produce json
+// @Security BearerAuth
+// @Param tag_id path string true "Tag ID"
+// @Success 200 {object} dto.EmptyResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /tag/{tag_id} [delete]
+func (h *TagHandler) DeleteTag(g *gin.Context) {
+	ctx := g.Request.Context()
+	tagId := g.Param("tag_id")
+	userId := g.GetInt64("userId")
+	res, err := h.tagService.DeleteTag(ctx, userId, tagId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// AddProductTag godoc
+// @Summary Tag a product
+// @Description Attach a tag to a product
+// @Tags tag
+// @Produce json
+// @Security BearerAuth
+// @Param productId path string true "Product ID"
+// @Param tag_id path string true "Tag ID"
+// @Success 200 {object} dto.EmptyResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /product/{productId}/tag/{tag_id} [post]
+func (h *TagHandler) AddProductTag(g *gin.Context) {
+	ctx := g.Request.Context()
+	productId := g.Param("productId")
+	tagId := g.Param("tag_id")
+	userId := g.GetInt64("userId")
+	res, err := h.tagService.AddProductTag(ctx, userId, productId, tagId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
+
+// RemoveProductTag godoc
+// @Summary Untag a product
+// @Description Detach a tag from a product
+// @Tags tag
+// @Produce json
+// @Security BearerAuth
+// @Param productId path string true "Product ID"
+// @Param tag_id path string true "Tag ID"
+// @Success 200 {object} dto.EmptyResponse
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 403 {object} dto.EmptyResponse "Forbidden"
+// @Router /product/{productId}/tag/{tag_id} [delete]
+func (h *TagHandler) RemoveProductTag(g *gin.Context) {
+	ctx := g.Request.Context()
+	productId := g.Param("productId")
+	tagId := g.Param("tag_id")
+	userId := g.GetInt64("userId")
+	res, err := h.tagService.RemoveProductTag(ctx, userId, productId, tagId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(http.StatusOK, res)
+}
@@ -142,6 +142,54 @@ func (h *UserHandler) SaveMarketplaceCredential(g *gin.Context) {
 	g.JSON(200, res)
 }
 
+// UpdateLinkPolicy godoc
+// @Summary Set unavailable product policy
+// @Description Choose what links do when their product is out of stock or delisted: keep redirecting, reroute to another available offer of the product, or send shoppers to a fallback url
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body dto.LinkPolicyRequest true "Link policy"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {string} string "Bad Request"
+// @Failure 401 {string} string "Unauthorized"
+// @Router /user/link-policy [put]
+func (h *UserHandler) UpdateLinkPolicy(g *gin.Context) {
+	ctx := g.Request.Context()
+	body := dto.LinkPolicyRequest{}
+	if err := g.ShouldBindJSON(&body); err != nil {
+		g.AbortWithStatus(400)
+		return
+	}
+	userId := g.GetInt64("userId")
+	res, err := h.userService.UpdateLinkPolicy(ctx, userId, body)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(200, res)
+}
+
+// RotatePostbackSecret godoc
+// @Summary Generate postback secret
+// @Description Generate a new secret for authenticating conversion postbacks, replacing the current one. Postbacks are refused until a secret has been generated.
+// @Tags user
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.PostbackSecretResult
+// @Failure 401 {string} string "Unauthorized"
+// @Router /user/postback-secret [post]
+func (h *UserHandler) RotatePostbackSecret(g *gin.Context) {
+	ctx := g.Request.Context()
+	userId := g.GetInt64("userId")
+	res, err := h.userService.RotatePostbackSecret(ctx, userId)
+	if err != nil {
+		g.JSON(res.HttpCode, res)
+		return
+	}
+	g.JSON(200, res)
+}
+
 // CheckMarketplaceCredential godoc
 // @Summary Check marketplace credentials
 // @Description Check if marketplace credentials exist for a platform
@@ -2,6 +2,10 @@ package db
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/market-place-affiliate/api/internal/core/dto"
@@ -25,49 +29,256 @@ func (r *campaignRepository) SaveCampaign(ctx context.Context, campaign domains.
 	return campaign, nil
 }
 func (r *campaignRepository) DeleteCampaign(ctx context.Context, campaignId string) error {
-	err := r.DB.Delete(&domains.Campaign{}, "id = ?", campaignId).Error
+	// Links share the campaign's deleted_at so a restore can tell them from links deleted earlier.
+	deletedAt := time.Now()
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domains.Link{}).Scopes(notDeleted).Where("campaign_id = ?", campaignId).Update("deleted_at", deletedAt).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&domains.Campaign{}).Scopes(notDeleted).Where("id = ?", campaignId).Update("deleted_at", deletedAt).Error
+	})
+}
+
+func (r *campaignRepository) RestoreCampaign(ctx context.Context, campaignId string) error {
+	var campaign domains.Campaign
+	err := r.DB.First(&campaign, "id = ?", campaignId).Error
 	if err != nil {
 		return err
 	}
-	return nil
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domains.Link{}).
+			Where("campaign_id = ? AND deleted_at = ?", campaignId, campaign.DeletedAt).
+			Where("product_id IN (?)", tx.Model(&domains.Product{}).Scopes(notDeleted).Select("id")).
+			Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&domains.Campaign{}).Where("id = ?", campaignId).Update("deleted_at", nil).Error
+	})
+}
+
+func (r *campaignRepository) PurgeDeletedCampaigns(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		campaignIds := tx.Model(&domains.Campaign{}).Select("id").Where("deleted_at < ?", before)
+		linkIds := tx.Model(&domains.Link{}).Select("id").Where("campaign_id IN (?)", campaignIds)
+		err := deleteLinkClicks(tx, linkIds)
+		if err != nil {
+			return err
+		}
+		err = tx.Where("campaign_id IN (?)", campaignIds).Delete(&domains.Link{}).Error
+		if err != nil {
+			return err
+		}
+		// Goals go with their campaign through ON DELETE CASCADE.
+		result := tx.Where("deleted_at < ?", before).Delete(&domains.Campaign{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
 }
 func (r *campaignRepository) GetCampaignById(ctx context.Context, campaignId string) (domains.Campaign, error) {
+	var campaign domains.Campaign
+	err := r.DB.Scopes(notDeleted).First(&campaign, "id = ?", campaignId).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domains.Campaign{}, ports.ErrCampaignNotFound
+	}
+	if err != nil {
+		return domains.Campaign{}, err
+	}
+	return campaign, nil
+}
+
+func (r *campaignRepository) GetCampaignByIdWithDeleted(ctx context.Context, campaignId string) (domains.Campaign, error) {
 	var campaign domains.Campaign
 	err := r.DB.First(&campaign, "id = ?", campaignId).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domains.Campaign{}, ports.ErrCampaignNotFound
+	}
 	if err != nil {
 		return domains.Campaign{}, err
 	}
 	return campaign, nil
 }
-func (r *campaignRepository) GetCampaignByQuery(ctx context.Context, userId int64, query dto.GetCampaignByQueryRequest) ([]domains.Campaign, error) {
-	var campaigns []domains.Campaign
+
+// campaignSorts maps a sort option to its SQL expression.
+var campaignSorts = map[string]string{
+	"start_at": "campaigns.start_at",
+	"end_at":   "campaigns.end_at",
+	"name":     "campaigns.name",
+	"clicks":   "coalesce(click_counts.clicks, 0)",
+}
+
+func (r *campaignRepository) GetCampaignByQuery(ctx context.Context, userId int64, query dto.GetCampaignByQueryRequest) ([]domains.Campaign, dto.PageMeta, error) {
 	dbQuery := r.DB.Model(&domains.Campaign{})
+	// Click counts are only for the owner; listings of every user's campaigns leave them out.
+	columns := "campaigns.*"
 	if userId != 0 {
-		dbQuery = dbQuery.Where("user_id = ?", userId)
+		dbQuery = dbQuery.
+			Joins(`left join (
+				select links.campaign_id, count(*) as clicks
+				from clicks join links on clicks.link_id = links.id
+				group by links.campaign_id
+			) click_counts on click_counts.campaign_id = campaigns.id`).
+			Where("campaigns.user_id = ?", userId)
+		columns = "campaigns.*, coalesce(click_counts.clicks, 0) as clicks"
 	}
 	if query.Name != "" {
-		dbQuery = dbQuery.Where("name LIKE ?", "%"+query.Name+"%")
+		dbQuery = dbQuery.Where("campaigns.name LIKE ?", "%"+query.Name+"%")
 	}
-	if !query.StartAt.IsZero(){
-		dbQuery = dbQuery.Where("start_at >= ?", query.StartAt)
+	if !query.StartAt.IsZero() {
+		dbQuery = dbQuery.Where("campaigns.start_at >= ?", query.StartAt)
 	}
 	if !query.EndAt.IsZero() {
-		dbQuery = dbQuery.Where("end_at <= ?", query.EndAt)
+		dbQuery = dbQuery.Where("campaigns.end_at <= ?", query.EndAt)
+	}
+	if query.State != "" {
+		dbQuery = dbQuery.Where("campaigns.state = ?", query.State)
 	}
-	err := dbQuery.Find(&campaigns).Error
+	if query.Deleted {
+		dbQuery = dbQuery.Scopes(onlyDeleted)
+	} else {
+		dbQuery = dbQuery.Scopes(notDeleted)
+	}
+
+	meta := dto.PageMeta{Limit: pageLimit(query.Limit)}
+	err := dbQuery.Session(&gorm.Session{}).Count(&meta.Total).Error
 	if err != nil {
-		return nil, err
+		return nil, dto.PageMeta{}, err
+	}
+	meta.TotalPages = totalPages(meta.Total, meta.Limit)
+
+	sort := query.Sort
+	if sort == "" {
+		sort = "start_at"
+	}
+	order := query.Order
+	if order == "" {
+		order = "desc"
+	}
+	sortExpr, ok := campaignSorts[sort]
+	if !ok || (sort == "clicks" && userId == 0) {
+		return nil, dto.PageMeta{}, fmt.Errorf("campaigns cannot be sorted by %s", sort)
+	}
+	if query.Cursor != "" {
+		value, id, err := decodeCursor(query.Cursor, sort+":"+order, func(value string) (any, error) {
+			return cursorValue(sort, value)
+		})
+		if err != nil {
+			return nil, dto.PageMeta{}, err
+		}
+		comparison := ">"
+		if order == "desc" {
+			comparison = "<"
+		}
+		dbQuery = dbQuery.Where("("+sortExpr+", campaigns.id) "+comparison+" (?, ?)", value, id)
+	} else {
+		meta.Page = max(query.Page, 1)
+		dbQuery = dbQuery.Offset((meta.Page - 1) * meta.Limit)
+	}
+
+	var campaigns []domains.Campaign
+	err = dbQuery.
+		Select(columns).
+		Order(sortExpr + " " + order).
+		Order("campaigns.id " + order).
+		Limit(meta.Limit + 1).
+		Find(&campaigns).Error
+	if err != nil {
+		return nil, dto.PageMeta{}, err
+	}
+	if len(campaigns) > meta.Limit {
+		campaigns = campaigns[:meta.Limit]
+		meta.HasMore = true
+		last := campaigns[len(campaigns)-1]
+		meta.NextCursor = encodeCursor(pageCursor{
+			Sort:  sort + ":" + order,
+			Value: campaignSortValue(sort, last),
+			Id:    last.Id.String(),
+		})
+	}
+	return campaigns, meta, nil
+}
+
+func campaignSortValue(sort string, campaign domains.Campaign) string {
+	switch sort {
+	case "end_at":
+		return campaign.EndAt.Format(time.RFC3339Nano)
+	case "name":
+		return campaign.Name
+	case "clicks":
+		if campaign.Clicks == nil {
+			return "0"
+		}
+		return strconv.FormatInt(*campaign.Clicks, 10)
+	default:
+		return campaign.StartAt.Format(time.RFC3339Nano)
 	}
-	return campaigns, nil
 }
 
-func (r *campaignRepository) GetAvailableCampaign(ctx context.Context) ([]domains.Campaign, error) {
+// cursorValue converts a cursor value back to the type of its sort column.
+func cursorValue(sort, value string) (any, error) {
+	switch sort {
+	case "start_at", "end_at":
+		return time.Parse(time.RFC3339Nano, value)
+	case "clicks":
+		return strconv.ParseInt(value, 10, 64)
+	default:
+		return value, nil
+	}
+}
+
+func (r *campaignRepository) GetCampaignsByStates(ctx context.Context, states []string) ([]domains.Campaign, error) {
 	var campaigns []domains.Campaign
-	dbQuery := r.DB.Model(&domains.Campaign{})
-	dbQuery.Where("date(start_at) <= ? AND date(end_at) >= ?",  gorm.Expr("date(NOW())"), gorm.Expr("date(NOW())"))
-	err := dbQuery.Find(&campaigns).Error
+	err := r.DB.Scopes(notDeleted).Where("state IN ?", states).Find(&campaigns).Error
 	if err != nil {
 		return nil, err
 	}
 	return campaigns, nil
-}
\ No newline at end of file
+}
+
+func (r *campaignRepository) UpdateCampaignState(ctx context.Context, campaignId string, from, to string) (bool, error) {
+	result := r.DB.Model(&domains.Campaign{}).Scopes(notDeleted).
+		Where("id = ? AND state = ?", campaignId, from).
+		Update("state", to)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *campaignRepository) SaveCampaignGoal(ctx context.Context, goal domains.CampaignGoal) (domains.CampaignGoal, error) {
+	err := r.DB.Save(&goal).Error
+	if err != nil {
+		return domains.CampaignGoal{}, err
+	}
+	return goal, nil
+}
+
+func (r *campaignRepository) GetCampaignGoal(ctx context.Context, campaignId string) (domains.CampaignGoal, error) {
+	var goal domains.CampaignGoal
+	err := r.DB.First(&goal, "campaign_id = ?", campaignId).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domains.CampaignGoal{}, ports.ErrCampaignGoalNotFound
+	}
+	if err != nil {
+		return domains.CampaignGoal{}, err
+	}
+	return goal, nil
+}
+
+func (r *campaignRepository) GetNotifiableCampaignGoals(ctx context.Context) ([]domains.CampaignGoal, error) {
+	var goals []domains.CampaignGoal
+	err := r.DB.
+		Joins("JOIN campaigns ON campaigns.id = campaign_goals.campaign_id").
+		Where("campaigns.state = ? AND campaigns.deleted_at IS NULL AND campaign_goals.notify_thresholds NOT IN ('', 'null', '[]')", domains.CampaignActive).
+		Find(&goals).Error
+	if err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
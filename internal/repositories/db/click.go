@@ -2,13 +2,15 @@ package db
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
-	"github.com/gofrs/uuid"
 	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/market-place-affiliate/api/internal/core/dto"
 	"github.com/market-place-affiliate/api/internal/core/ports"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type clickRepository struct {
@@ -26,59 +28,461 @@ func (r *clickRepository) SaveClick(ctx context.Context, click domains.Click) er
 	}
 	return nil
 }
-func (r *clickRepository) CountClicksByDateRange(ctx context.Context, userId int64, startDate, endDate time.Time) ([]dto.MetrictItem, error) {
+
+func (r *clickRepository) GetClickById(ctx context.Context, clickId string) (domains.Click, error) {
+	var click domains.Click
+	err := r.DB.First(&click, "id = ?", clickId).Error
+	if err != nil {
+		return domains.Click{}, err
+	}
+	return click, nil
+}
+
+func (r *clickRepository) StreamClicks(ctx context.Context, userId int64, startDate, endDate time.Time, campaignId, linkId string, fn func(dto.ClickLogEntry) error) error {
+	// The context cancels the query when the client of a long export goes away.
+	query := r.DB.WithContext(ctx).Table("clicks").Select(`
+	clicks.id as click_id,
+	clicks.created_at,
+	links.id as link_id,
+	links.short_code,
+	campaigns.id as campaign_id,
+	campaigns.name as campaign_name,
+	products.id as product_id,
+	products.title as product_title,
+	coalesce(offer.marketplace, '') as marketplace,
+	clicks.visitor_id,
+	clicks.referrer_domain,
+	clicks.device,
+	clicks.os,
+	clicks.browser,
+	clicks.language
+	`).
+		Joins("join links on links.id = clicks.link_id").
+		Joins("join campaigns on campaigns.id = links.campaign_id").
+		Joins("join products on products.id = links.product_id").
+		Joins(`left join lateral (
+		select offers.marketplace from offers
+		where offers.product_id = links.product_id
+		order by offers.id
+		limit 1
+	) offer on true`).
+		Where("campaigns.user_id = ? and clicks.created_at >= ? and clicks.created_at < ?", userId, startDate, endDate)
+	if campaignId != "" {
+		query = query.Where("links.campaign_id = ?", campaignId)
+	}
+	if linkId != "" {
+		query = query.Where("links.id = ?", linkId)
+	}
+	rows, err := query.Order("clicks.created_at, clicks.id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var entry dto.ClickLogEntry
+		err = r.DB.ScanRows(rows, &entry)
+		if err != nil {
+			return err
+		}
+		err = fn(entry)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// bucketFormats formats the start of a date_trunc bucket like dashboardBucketLayouts in the
+// dashboard service.
+var bucketFormats = map[string]string{
+	"hour":  `YYYY-MM-DD"T"HH24:00`,
+	"day":   "YYYY-MM-DD",
+	"week":  "YYYY-MM-DD",
+	"month": "YYYY-MM-DD",
+}
+
+func (r *clickRepository) CountClicksByDateRange(ctx context.Context, userId int64, startDate, endDate time.Time, granularity, timezone string) ([]dto.MetrictItem, error) {
 	var results []dto.MetrictItem
 	err := r.DB.Raw(`
-	select 
-	date(clicks.created_at) as date, 
-	count(*) as click_count,
-	links.campaign_id,
+	select
+	to_char(date_trunc(@granularity, click_rollups.bucket at time zone @timezone), @format) as date,
+	sum(click_rollups.clicks) as click_count,
+	click_rollups.campaign_id,
 	campaigns.name as campaign_name,
-	offers.marketplace
+	click_rollups.marketplace,
+	click_rollups.currency
+	from click_rollups
+	left join campaigns on click_rollups.campaign_id = campaigns.id
+	where click_rollups.user_id = @user and click_rollups.bucket >= @start and click_rollups.bucket < @end
+	group by 1, click_rollups.campaign_id, campaigns.name, click_rollups.marketplace, click_rollups.currency
+	order by 1 asc
+	`, map[string]any{
+		"granularity": granularity,
+		"timezone":    timezone,
+		"format":      bucketFormats[granularity],
+		"user":        userId,
+		"start":       startDate.Truncate(time.Hour),
+		"end":         endDate,
+	}).Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// leaderboardDimension is the rollup column a leaderboard groups by and how its entries are
+// labelled.
+type leaderboardDimension struct {
+	key   string
+	label string
+	join  string
+}
+
+var leaderboardDimensions = map[string]leaderboardDimension{
+	dto.LeaderboardProducts:     {"product_id::text", "products.title", "left join products on products.id::text = board.key"},
+	dto.LeaderboardLinks:        {"link_id::text", "links.short_code", "left join links on links.id::text = board.key"},
+	dto.LeaderboardCampaigns:    {"campaign_id::text", "campaigns.name", "left join campaigns on campaigns.id::text = board.key"},
+	dto.LeaderboardMarketplaces: {"marketplace", "board.key", ""},
+}
+
+// leaderboardSorts are the metrics a leaderboard can be ranked by.
+var leaderboardSorts = map[string]bool{"clicks": true, "unique_visitors": true}
+
+func (r *clickRepository) GetClickLeaderboard(ctx context.Context, userId int64, dimension string, previousStart, previousEnd, startDate, endDate time.Time, timezone, sort string, limit int) ([]dto.LeaderboardEntry, error) {
+	board, ok := leaderboardDimensions[dimension]
+	if !ok {
+		return nil, fmt.Errorf("unknown leaderboard %q", dimension)
+	}
+	if !leaderboardSorts[sort] {
+		return nil, fmt.Errorf("unknown leaderboard sort %q", sort)
+	}
+	var results []dto.LeaderboardEntry
+	err := r.DB.Raw(fmt.Sprintf(`
+	with board_clicks as (
+		select %[1]s as key,
+		coalesce(sum(clicks) filter (where bucket >= @start and bucket < @end), 0) as clicks,
+		coalesce(sum(clicks) filter (where bucket >= @previous_start and bucket < @previous_end), 0) as previous_clicks
+		from click_rollups
+		where user_id = @user
+		and (bucket >= @start and bucket < @end or bucket >= @previous_start and bucket < @previous_end)
+		group by 1
+	), board_visitors as (
+		select %[1]s as key,
+		count(distinct visitor_id) filter (where day >= @start_day and day < @end_day) as unique_visitors,
+		count(distinct visitor_id) filter (where day >= @previous_start_day and day < @previous_end_day) as previous_unique_visitors
+		from click_visitor_rollups
+		where user_id = @user
+		and (day >= @start_day and day < @end_day or day >= @previous_start_day and day < @previous_end_day)
+		group by 1
+	), board as (
+		select
+		key,
+		coalesce(board_clicks.clicks, 0) as clicks,
+		coalesce(board_visitors.unique_visitors, 0) as unique_visitors,
+		coalesce(board_clicks.previous_clicks, 0) as previous_clicks,
+		coalesce(board_visitors.previous_unique_visitors, 0) as previous_unique_visitors
+		from board_clicks
+		full join board_visitors using (key)
+	)
+	select board.*, coalesce(%[2]s, '') as label
+	from board
+	%[3]s
+	where board.%[4]s > 0
+	order by board.%[4]s desc, board.clicks desc, label
+	limit @limit
+	`, board.key, board.label, board.join, sort), map[string]any{
+		"user":               userId,
+		"previous_start":     previousStart.Truncate(time.Hour),
+		"previous_end":       previousEnd,
+		"start":              startDate.Truncate(time.Hour),
+		"end":                endDate,
+		"previous_start_day": rollupDay(previousStart, timezone),
+		"previous_end_day":   rollupDay(previousEnd, timezone),
+		"start_day":          rollupDay(startDate, timezone),
+		"end_day":            rollupDay(endDate, timezone),
+		"limit":              limit,
+	}).Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// breakdownDimensions are the click columns a breakdown groups by. Clicks whose visitor did not
+// tell are labelled Unknown, or Direct for the referrer.
+var breakdownDimensions = map[string]leaderboardDimension{
+	dto.BreakdownSource:   {trafficSourceKey(), trafficSourceLabel(), ""},
+	dto.BreakdownReferrer: {"clicks.referrer_domain", "coalesce(nullif(board.key, ''), 'Direct')", ""},
+	dto.BreakdownDevice:   {"clicks.device", "coalesce(nullif(board.key, ''), 'Unknown')", ""},
+	dto.BreakdownOs:       {"clicks.os", "coalesce(nullif(board.key, ''), 'Unknown')", ""},
+	dto.BreakdownBrowser:  {"clicks.browser", "coalesce(nullif(board.key, ''), 'Unknown')", ""},
+	dto.BreakdownLanguage: {"clicks.language", "coalesce(nullif(board.key, ''), 'Unknown')", ""},
+}
+
+// trafficSourceKey groups clicks into the domains.SocialNetworks by their referrer domain or,
+// without a referrer, their in-app browser. The values are constants, so they are inlined.
+func trafficSourceKey() string {
+	var cases strings.Builder
+	cases.WriteString("case")
+	for _, network := range domains.SocialNetworks {
+		var matches []string
+		for _, domain := range network.Domains {
+			matches = append(matches, fmt.Sprintf("clicks.referrer_domain = '%[1]s' or clicks.referrer_domain like '%%.%[1]s'", domain))
+		}
+		if network.Browser != "" {
+			matches = append(matches, fmt.Sprintf("clicks.referrer_domain = '' and clicks.browser = '%s'", network.Browser))
+		}
+		fmt.Fprintf(&cases, " when %s then '%s'", strings.Join(matches, " or "), network.Key)
+	}
+	fmt.Fprintf(&cases, " when clicks.referrer_domain = '' then '%s' else '%s' end", domains.TrafficSourceDirect, domains.TrafficSourceOther)
+	return cases.String()
+}
+
+func trafficSourceLabel() string {
+	var cases strings.Builder
+	cases.WriteString("case board.key")
+	for _, network := range domains.SocialNetworks {
+		fmt.Fprintf(&cases, " when '%s' then '%s'", network.Key, network.Name)
+	}
+	fmt.Fprintf(&cases, " when '%s' then 'Direct' else 'Other' end", domains.TrafficSourceDirect)
+	return cases.String()
+}
+
+func (r *clickRepository) GetClickBreakdown(ctx context.Context, userId int64, dimension string, previousStart, previousEnd, startDate, endDate time.Time, campaignId, linkId, sort string, limit int) ([]dto.LeaderboardEntry, error) {
+	breakdown, ok := breakdownDimensions[dimension]
+	if !ok {
+		return nil, fmt.Errorf("unknown breakdown %q", dimension)
+	}
+	if !leaderboardSorts[sort] {
+		return nil, fmt.Errorf("unknown breakdown sort %q", sort)
+	}
+	filters := ""
+	if campaignId != "" {
+		filters += " and links.campaign_id = @campaign"
+	}
+	if linkId != "" {
+		filters += " and links.id = @link"
+	}
+	var results []dto.LeaderboardEntry
+	err := r.DB.Raw(fmt.Sprintf(`
+	with board as (
+		select %[1]s as key,
+		count(*) filter (where clicks.created_at >= @start and clicks.created_at < @end) as clicks,
+		count(distinct nullif(clicks.visitor_id, '')) filter (where clicks.created_at >= @start and clicks.created_at < @end) as unique_visitors,
+		count(*) filter (where clicks.created_at >= @previous_start and clicks.created_at < @previous_end) as previous_clicks,
+		count(distinct nullif(clicks.visitor_id, '')) filter (where clicks.created_at >= @previous_start and clicks.created_at < @previous_end) as previous_unique_visitors
+		from clicks
+		join links on links.id = clicks.link_id
+		join campaigns on campaigns.id = links.campaign_id
+		where campaigns.user_id = @user
+		and (clicks.created_at >= @start and clicks.created_at < @end or clicks.created_at >= @previous_start and clicks.created_at < @previous_end)
+		%[3]s
+		group by 1
+	)
+	select board.*, %[2]s as label
+	from board
+	where board.%[4]s > 0
+	order by board.%[4]s desc, board.clicks desc, label
+	limit @limit
+	`, breakdown.key, breakdown.label, filters, sort), map[string]any{
+		"user":           userId,
+		"campaign":       campaignId,
+		"link":           linkId,
+		"previous_start": previousStart,
+		"previous_end":   previousEnd,
+		"start":          startDate,
+		"end":            endDate,
+		"limit":          limit,
+	}).Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *clickRepository) GetCampaignClickStats(ctx context.Context, campaignId string, startDate, endDate time.Time) (dto.CampaignClickStats, error) {
+	var stats dto.CampaignClickStats
+	err := r.DB.Raw(`
+	select
+	count(*) as click_count,
+	count(distinct nullif(clicks.visitor_id, '')) as unique_visitors,
+	coalesce(sum(offer.commission), 0) as commission
 	from clicks
-	left join links on clicks.link_id = links.id
-	left join campaigns on links.campaign_id = campaigns.id
-	left join offers on offers.product_id = links.product_id
-	where user_id = ? and clicks.created_at >= ? and clicks.created_at <= ?
-	group by date(clicks.created_at), links.campaign_id,campaigns.name, offers.marketplace
-	order by date(clicks.created_at) asc
-	`, userId, startDate, endDate,
+	join links on clicks.link_id = links.id
+	left join lateral (
+		select offers.commission from offers
+		where offers.product_id = links.product_id
+		order by offers.id
+		limit 1
+	) offer on true
+	where links.campaign_id = ? and clicks.created_at >= ? and clicks.created_at < ?
+	`, campaignId, startDate, endDate,
+	).Scan(&stats).Error
+	if err != nil {
+		return dto.CampaignClickStats{}, err
+	}
+	return stats, nil
+}
+
+// rollupParams binds a campaign and a range to the named parameters of the rollup queries.
+// Hourly buckets are matched from the hour startDate falls in, and visitor days from the day
+// startDate falls on in timezone.
+func rollupParams(campaignId string, startDate, endDate time.Time, timezone string) map[string]any {
+	return map[string]any{
+		"campaign": campaignId,
+		"start":    startDate.Truncate(time.Hour),
+		"end":      endDate,
+		"timezone": timezone,
+	}
+}
+
+// rollupDay is the visitor rollup day t falls on in timezone, as a Postgres date expression.
+func rollupDay(t time.Time, timezone string) clause.Expr {
+	return gorm.Expr("(cast(? as timestamptz) at time zone ?)::date", t, timezone)
+}
+
+// visitorDays matches the visitor rollup days of a rollupParams range.
+const visitorDays = `day >= (cast(@start as timestamptz) at time zone @timezone)::date and day < (cast(@end as timestamptz) at time zone @timezone)::date`
+
+func (r *clickRepository) GetCampaignClickTotals(ctx context.Context, campaignId string, startDate, endDate time.Time, timezone string) (dto.ClickTotals, error) {
+	var totals dto.ClickTotals
+	err := r.DB.Raw(`
+	select
+	coalesce((
+		select sum(clicks) from click_rollups
+		where campaign_id = @campaign and bucket >= @start and bucket < @end
+	), 0) as clicks,
+	(
+		select count(distinct visitor_id) from click_visitor_rollups
+		where campaign_id = @campaign and `+visitorDays+`
+	) as unique_visitors
+	`, rollupParams(campaignId, startDate, endDate, timezone),
+	).Scan(&totals).Error
+	if err != nil {
+		return dto.ClickTotals{}, err
+	}
+	return totals, nil
+}
+
+func (r *clickRepository) GetCampaignDailyClicks(ctx context.Context, campaignId string, startDate, endDate time.Time, timezone string) ([]dto.DailyClicks, error) {
+	var results []dto.DailyClicks
+	err := r.DB.Raw(`
+	with daily_clicks as (
+		select to_char(bucket at time zone @timezone, 'YYYY-MM-DD') as date, sum(clicks) as clicks
+		from click_rollups
+		where campaign_id = @campaign and bucket >= @start and bucket < @end
+		group by 1
+	), daily_visitors as (
+		select to_char(day, 'YYYY-MM-DD') as date, count(distinct visitor_id) as unique_visitors
+		from click_visitor_rollups
+		where campaign_id = @campaign and `+visitorDays+`
+		group by 1
+	)
+	select
+	date,
+	coalesce(daily_clicks.clicks, 0) as clicks,
+	coalesce(daily_visitors.unique_visitors, 0) as unique_visitors
+	from daily_clicks
+	full join daily_visitors using (date)
+	order by 1
+	`, rollupParams(campaignId, startDate, endDate, timezone),
 	).Scan(&results).Error
 	if err != nil {
 		return nil, err
 	}
 	return results, nil
 }
-func (r *clickRepository) CountTopProductClickByDateRange(ctx context.Context, userId int64, startDate, endDate time.Time) (uuid.UUID, int64, error) {
-	type results struct {
-		ProductId  uuid.UUID 
-		ClickCount int64
-	}
-	var result results
-	err := r.DB.Raw(
-		`
-	select 
-		products.id as product_id, 
-		count(*) as click_count
-	from clicks
-	left join links on clicks.link_id = links.id
-	left join products on links.product_id = products.id
-	where user_id = ? and clicks.created_at >= ? and clicks.created_at <= ?
-	group by products.id
-	order by count(*) desc
-	limit 1
-	`, userId, startDate, endDate,
-	).Scan(&result).Error
+
+func (r *clickRepository) GetCampaignLinkClicks(ctx context.Context, campaignId string, startDate, endDate time.Time, timezone string) ([]dto.CampaignLinkReport, error) {
+	var results []dto.CampaignLinkReport
+	err := r.DB.Raw(`
+	select
+	links.id as link_id,
+	links.short_code,
+	links.product_id,
+	products.title as product_title,
+	coalesce(offer.marketplace, '') as marketplace,
+	coalesce(link_clicks.clicks, 0) as clicks,
+	coalesce(link_visitors.unique_visitors, 0) as unique_visitors,
+	links.deleted_at is not null as deleted
+	from links
+	join products on products.id = links.product_id
+	left join lateral (
+		select offers.marketplace from offers
+		where offers.product_id = links.product_id
+		order by offers.id
+		limit 1
+	) offer on true
+	left join (
+		select link_id, sum(clicks) as clicks from click_rollups
+		where campaign_id = @campaign and bucket >= @start and bucket < @end
+		group by link_id
+	) link_clicks on link_clicks.link_id = links.id
+	left join (
+		select link_id, count(distinct visitor_id) as unique_visitors from click_visitor_rollups
+		where campaign_id = @campaign and `+visitorDays+`
+		group by link_id
+	) link_visitors on link_visitors.link_id = links.id
+	where links.campaign_id = @campaign and (links.deleted_at is null or link_clicks.clicks > 0)
+	order by clicks desc, links.short_code
+	`, rollupParams(campaignId, startDate, endDate, timezone),
+	).Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *clickRepository) GetCampaignProductClicks(ctx context.Context, campaignId string, startDate, endDate time.Time, timezone string) ([]dto.CampaignProductReport, error) {
+	var results []dto.CampaignProductReport
+	err := r.DB.Raw(`
+	select
+	products.id as product_id,
+	products.title as product_title,
+	coalesce(offer.marketplace, '') as marketplace,
+	coalesce(product_clicks.clicks, 0) as clicks,
+	coalesce(product_visitors.unique_visitors, 0) as unique_visitors
+	from (
+		select product_id, bool_or(deleted_at is null) as linked from links
+		where campaign_id = @campaign
+		group by product_id
+	) campaign_products
+	join products on products.id = campaign_products.product_id
+	left join lateral (
+		select offers.marketplace from offers
+		where offers.product_id = products.id
+		order by offers.id
+		limit 1
+	) offer on true
+	left join (
+		select product_id, sum(clicks) as clicks from click_rollups
+		where campaign_id = @campaign and bucket >= @start and bucket < @end
+		group by product_id
+	) product_clicks on product_clicks.product_id = products.id
+	left join (
+		select product_id, count(distinct visitor_id) as unique_visitors from click_visitor_rollups
+		where campaign_id = @campaign and `+visitorDays+`
+		group by product_id
+	) product_visitors on product_visitors.product_id = products.id
+	where campaign_products.linked or product_clicks.clicks > 0
+	order by clicks desc, products.title
+	`, rollupParams(campaignId, startDate, endDate, timezone),
+	).Scan(&results).Error
 	if err != nil {
-		return uuid.Nil, 0, err
+		return nil, err
 	}
-	return result.ProductId, result.ClickCount, nil
+	return results, nil
 }
 
-func (r *clickRepository) DeleteClicksByLinkId(ctx context.Context, linkId string) error {
-	err := r.DB.Delete(&domains.Click{}, "link_id = ?", linkId).Error
+// deleteLinkClicks removes the clicks on the links and their rollups.
+func deleteLinkClicks(tx *gorm.DB, linkIds *gorm.DB) error {
+	err := tx.Delete(&domains.ClickRollup{}, "link_id IN (?)", linkIds).Error
 	if err != nil {
 		return err
 	}
-	return nil
-}
\ No newline at end of file
+	err = tx.Delete(&domains.ClickVisitorRollup{}, "link_id IN (?)", linkIds).Error
+	if err != nil {
+		return err
+	}
+	return tx.Delete(&domains.Click{}, "link_id IN (?)", linkIds).Error
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type clickRollupRepository struct {
+	DB *gorm.DB
+}
+
+func NewClickRollupRepository(db *gorm.DB) ports.ClickRollupRepository {
+	return &clickRollupRepository{DB: db}
+}
+
+// RollupClicks replaces the hourly rollups in [from, to) so recounting an hour, for instance the
+// current one on every run, never counts a click twice. The upsert covers a backfill recounting
+// the same hour concurrently. Visitor rows are only ever added.
+func (r *clickRollupRepository) RollupClicks(ctx context.Context, from, to time.Time, timezone string) error {
+	params := map[string]any{"from": from, "to": to, "timezone": timezone}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("bucket >= ? AND bucket < ?", from, to).Delete(&domains.ClickRollup{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Exec(`
+		insert into click_rollups (bucket, link_id, campaign_id, product_id, user_id, marketplace, currency, clicks)
+		select
+		date_trunc('hour', clicks.created_at, 'UTC'),
+		links.id,
+		links.campaign_id,
+		links.product_id,
+		campaigns.user_id,
+		coalesce(offer.marketplace, ''),
+		coalesce(offer.currency, ''),
+		count(*)
+		from clicks
+		join links on clicks.link_id = links.id
+		join campaigns on links.campaign_id = campaigns.id
+		left join lateral (
+			select offers.marketplace, offers.currency from offers
+			where offers.product_id = links.product_id
+			order by offers.id
+			limit 1
+		) offer on true
+		where clicks.created_at >= @from and clicks.created_at < @to
+		group by 1, links.id, links.campaign_id, links.product_id, campaigns.user_id, offer.marketplace, offer.currency
+		on conflict (bucket, link_id) do update set clicks = excluded.clicks
+		`, params).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(`
+		insert into click_visitor_rollups (day, link_id, visitor_id, campaign_id, product_id, user_id, marketplace)
+		select distinct
+		(clicks.created_at at time zone @timezone)::date,
+		links.id,
+		clicks.visitor_id,
+		links.campaign_id,
+		links.product_id,
+		campaigns.user_id,
+		coalesce(offer.marketplace, '')
+		from clicks
+		join links on clicks.link_id = links.id
+		join campaigns on links.campaign_id = campaigns.id
+		left join lateral (
+			select offers.marketplace from offers
+			where offers.product_id = links.product_id
+			order by offers.id
+			limit 1
+		) offer on true
+		where clicks.visitor_id <> '' and clicks.created_at >= @from and clicks.created_at < @to
+		on conflict do nothing
+		`, params).Error
+	})
+}
+
+func (r *clickRollupRepository) GetLatestRollupBucket(ctx context.Context) (time.Time, error) {
+	var latest sql.NullTime
+	err := r.DB.Model(&domains.ClickRollup{}).Select("max(bucket)").Scan(&latest).Error
+	if err != nil {
+		return time.Time{}, err
+	}
+	return latest.Time, nil
+}
+
+func (r *clickRollupRepository) GetEarliestClickAt(ctx context.Context) (time.Time, error) {
+	var earliest sql.NullTime
+	err := r.DB.Model(&domains.Click{}).Select("min(created_at)").Scan(&earliest).Error
+	if err != nil {
+		return time.Time{}, err
+	}
+	return earliest.Time, nil
+}
+
+func (r *clickRollupRepository) ResetRollups(ctx context.Context) error {
+	return r.DB.Exec("TRUNCATE click_rollups, click_visitor_rollups").Error
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type collectionRepository struct {
+	DB *gorm.DB
+}
+
+func NewCollectionRepository(db *gorm.DB) ports.CollectionRepository {
+	return &collectionRepository{DB: db}
+}
+
+func preloadCollectionItems(db *gorm.DB) *gorm.DB {
+	return db.Order("position asc")
+}
+
+func (r *collectionRepository) SaveCollection(ctx context.Context, collection domains.Collection) (domains.Collection, error) {
+	err := r.DB.Omit("Items").Save(&collection).Error
+	if err != nil {
+		return domains.Collection{}, err
+	}
+	return collection, nil
+}
+
+func (r *collectionRepository) GetCollectionById(ctx context.Context, collectionId string) (domains.Collection, error) {
+	var collection domains.Collection
+	err := r.DB.Preload("Items", preloadCollectionItems).Preload("Items.Product", notDeleted).First(&collection, "id = ?", collectionId).Error
+	if err != nil {
+		return domains.Collection{}, err
+	}
+	return collection, nil
+}
+
+func (r *collectionRepository) GetCollectionsByUserId(ctx context.Context, userId int64) ([]domains.Collection, error) {
+	var collections []domains.Collection
+	err := r.DB.Preload("Items", preloadCollectionItems).Where("user_id = ?", userId).Order("name asc").Find(&collections).Error
+	if err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+func (r *collectionRepository) DeleteCollection(ctx context.Context, collectionId string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Delete(&domains.CollectionItem{}, "collection_id = ?", collectionId).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&domains.Collection{}, "id = ?", collectionId).Error
+	})
+}
+
+func (r *collectionRepository) ReplaceCollectionItems(ctx context.Context, collectionId string, productIds []uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Delete(&domains.CollectionItem{}, "collection_id = ?", collectionId).Error
+		if err != nil {
+			return err
+		}
+		if len(productIds) == 0 {
+			return nil
+		}
+		items := make([]domains.CollectionItem, 0, len(productIds))
+		for i, productId := range productIds {
+			items = append(items, domains.CollectionItem{
+				CollectionId: uuid.FromStringOrNil(collectionId),
+				ProductId:    productId,
+				Position:     i,
+			})
+		}
+		return tx.Create(&items).Error
+	})
+}
+
+func (r *collectionRepository) AddCollectionItem(ctx context.Context, collectionId, productId string) error {
+	err := r.DB.Exec(`
+	INSERT INTO collection_items (collection_id, product_id, position, created_at)
+	SELECT ?, ?, COALESCE(MAX(position) + 1, 0), NOW() FROM collection_items WHERE collection_id = ?
+	ON CONFLICT DO NOTHING
+	`, collectionId, productId, collectionId).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *collectionRepository) RemoveCollectionItem(ctx context.Context, collectionId, productId string) error {
+	err := r.DB.Delete(&domains.CollectionItem{}, "collection_id = ? AND product_id = ?", collectionId, productId).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type conversionRepository struct {
+	DB *gorm.DB
+}
+
+func NewConversionRepository(db *gorm.DB) ports.ConversionRepository {
+	return &conversionRepository{DB: db}
+}
+
+func (r *conversionRepository) SaveConversions(ctx context.Context, conversions []domains.Conversion) error {
+	if len(conversions) == 0 {
+		return nil
+	}
+	updates := clause.AssignmentColumns([]string{"status", "sub_ids", "amount", "commission", "currency", "ordered_at", "updated_at"})
+	for _, column := range []string{"link_id", "campaign_id", "product_id"} {
+		updates = append(updates, clause.Assignment{
+			Column: clause.Column{Name: column},
+			Value:  gorm.Expr("coalesce(excluded." + column + ", conversions." + column + ")"),
+		})
+	}
+	return r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "marketplace"}, {Name: "order_id"}, {Name: "item_id"}},
+		DoUpdates: updates,
+	}).CreateInBatches(&conversions, 500).Error
+}
+
+func (r *conversionRepository) SavePostbackConversion(ctx context.Context, conversion domains.Conversion) error {
+	return r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "marketplace"}, {Name: "order_id"}, {Name: "item_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "commission", "amount", "updated_at"}),
+	}).Create(&conversion).Error
+}
+
+func (r *conversionRepository) CountConversionsByDateRange(ctx context.Context, userId int64, startDate, endDate time.Time, granularity, timezone string) ([]dto.MetrictItem, error) {
+	var results []dto.MetrictItem
+	err := r.DB.Raw(`
+	select
+	to_char(date_trunc(@granularity, conversions.ordered_at at time zone @timezone), @format) as date,
+	count(distinct conversions.order_id) as conversions,
+	sum(conversions.amount) as sales,
+	sum(conversions.commission) as revenue,
+	coalesce(conversions.campaign_id, @nil) as campaign_id,
+	coalesce(campaigns.name, '') as campaign_name,
+	conversions.marketplace,
+	conversions.currency
+	from conversions
+	left join campaigns on conversions.campaign_id = campaigns.id
+	where conversions.user_id = @user and conversions.ordered_at >= @start and conversions.ordered_at < @end
+	and conversions.status <> @cancelled
+	group by 1, conversions.campaign_id, campaigns.name, conversions.marketplace, conversions.currency
+	order by 1 asc
+	`, map[string]any{
+		"granularity": granularity,
+		"timezone":    timezone,
+		"format":      bucketFormats[granularity],
+		"nil":         uuid.Nil,
+		"user":        userId,
+		"start":       startDate,
+		"end":         endDate,
+		"cancelled":   domains.ConversionCancelled,
+	}).Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/market-place-affiliate/api/internal/core/ports"
@@ -24,7 +25,7 @@ func (r *linkRepository) SaveLink(ctx context.Context, link domains.Link) (domai
 	return link, nil
 }
 func (r *linkRepository) DeleteLink(ctx context.Context, linkId string) error {
-	err := r.DB.Delete(&domains.Link{}, "id = ?", linkId).Error
+	err := r.DB.Model(&domains.Link{}).Scopes(notDeleted).Where("id = ?", linkId).Update("deleted_at", time.Now()).Error
 	if err != nil {
 		return err
 	}
@@ -32,7 +33,7 @@ func (r *linkRepository) DeleteLink(ctx context.Context, linkId string) error {
 }
 func (r *linkRepository) GetLinksByProductId(ctx context.Context, productId string) ([]domains.Link, error) {
 	var links []domains.Link
-	err := r.DB.Find(&links, "product_id = ?", productId).Error
+	err := r.DB.Scopes(notDeleted).Find(&links, "product_id = ?", productId).Error
 	if err != nil {
 		return nil, err
 	}
@@ -40,13 +41,22 @@ func (r *linkRepository) GetLinksByProductId(ctx context.Context, productId stri
 }
 func (r *linkRepository) GetLinkById(ctx context.Context, linkId string) (domains.Link, error) {
 	var link domains.Link
-	err := r.DB.First(&link, "id = ?", linkId).Error
+	err := r.DB.Scopes(notDeleted).First(&link, "id = ?", linkId).Error
 	if err != nil {
 		return domains.Link{}, err
 	}
 	return link, nil
 }
 func (r *linkRepository) GetLinkByShortCode(ctx context.Context, shortCode string) (domains.Link, error) {
+	var link domains.Link
+	err := r.DB.Scopes(notDeleted).First(&link, "short_code = ?", shortCode).Error
+	if err != nil {
+		return domains.Link{}, err
+	}
+	return link, nil
+}
+
+func (r *linkRepository) GetLinkByShortCodeWithDeleted(ctx context.Context, shortCode string) (domains.Link, error) {
 	var link domains.Link
 	err := r.DB.First(&link, "short_code = ?", shortCode).Error
 	if err != nil {
@@ -57,7 +67,7 @@ func (r *linkRepository) GetLinkByShortCode(ctx context.Context, shortCode strin
 
 func (r *linkRepository) GetLinksByCampaignId(ctx context.Context, campaignId string) ([]domains.Link, error) {
 	var links []domains.Link
-	err := r.DB.Find(&links, "campaign_id = ?", campaignId).Error
+	err := r.DB.Scopes(notDeleted).Find(&links, "campaign_id = ?", campaignId).Error
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +75,7 @@ func (r *linkRepository) GetLinksByCampaignId(ctx context.Context, campaignId st
 }
 
 func (r *linkRepository) DeleteLinkByProductId(ctx context.Context, productId string) error {
-	err := r.DB.Delete(&domains.Link{}, "product_id = ?", productId).Error
+	err := r.DB.Model(&domains.Link{}).Scopes(notDeleted).Where("product_id = ?", productId).Update("deleted_at", time.Now()).Error
 	if err != nil {
 		return err
 	}
@@ -73,9 +83,28 @@ func (r *linkRepository) DeleteLinkByProductId(ctx context.Context, productId st
 }
 
 func (r *linkRepository) DeleteLinkByCampaignId(ctx context.Context, campaignId string) error {
-	err := r.DB.Delete(&domains.Link{}, "campaign_id = ?", campaignId).Error
+	err := r.DB.Model(&domains.Link{}).Scopes(notDeleted).Where("campaign_id = ?", campaignId).Update("deleted_at", time.Now()).Error
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// PurgeDeletedLinks removes links deleted before the cutoff together with their clicks.
+func (r *linkRepository) PurgeDeletedLinks(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		linkIds := tx.Model(&domains.Link{}).Select("id").Where("deleted_at < ?", before)
+		err := deleteLinkClicks(tx, linkIds)
+		if err != nil {
+			return err
+		}
+		result := tx.Where("deleted_at < ?", before).Delete(&domains.Link{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
@@ -20,7 +20,7 @@ func NewMarketplaceCredentialRepository(db *gorm.DB) ports.MarketplaceRepository
 func (r *marketplaceCredentialRepository) Save(ctx context.Context, marketplace domains.MarketplaceCredential) (domains.MarketplaceCredential, error) {
 	err := r.DB.Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "user_id"}, {Name: "marketplace"}},
-		DoUpdates: clause.AssignmentColumns([]string{"app_id", "app_key", "app_secret", "user_token", "updated_at"}),
+		DoUpdates: clause.AssignmentColumns([]string{"app_id", "app_key", "app_secret", "user_token", "region", "updated_at"}),
 	}).Create(&marketplace).Error
 	if err != nil {
 		return domains.MarketplaceCredential{}, err
@@ -44,3 +44,12 @@ func (r *marketplaceCredentialRepository) DeleteByUserIdAndPlatform(ctx context.
 	}
 	return nil
 }
+
+func (r *marketplaceCredentialRepository) ListCredentials(ctx context.Context) ([]domains.MarketplaceCredential, error) {
+	var credentials []domains.MarketplaceCredential
+	err := r.DB.Order("id asc").Find(&credentials).Error
+	if err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
@@ -10,14 +10,26 @@ func Migrate(DB *gorm.DB) error {
 	if err != nil {
 		return err
 	}
+	err = DB.AutoMigrate(&domains.Tag{})
+	if err != nil {
+		return err
+	}
 	err = DB.AutoMigrate(&domains.Product{})
 	if err != nil {
 		return err
 	}
+	err = DB.AutoMigrate(&domains.ProductImage{})
+	if err != nil {
+		return err
+	}
 	err = DB.AutoMigrate(&domains.Campaign{})
 	if err != nil {
 		return err
 	}
+	err = DB.AutoMigrate(&domains.CampaignGoal{})
+	if err != nil {
+		return err
+	}
 	err = DB.AutoMigrate(&domains.Offer{})
 	if err != nil {
 		return err
@@ -34,5 +46,25 @@ func Migrate(DB *gorm.DB) error {
 	if err != nil {
 		return err
 	}
+	err = DB.AutoMigrate(&domains.ClickRollup{})
+	if err != nil {
+		return err
+	}
+	err = DB.AutoMigrate(&domains.ClickVisitorRollup{})
+	if err != nil {
+		return err
+	}
+	err = DB.AutoMigrate(&domains.Conversion{})
+	if err != nil {
+		return err
+	}
+	err = DB.AutoMigrate(&domains.Collection{})
+	if err != nil {
+		return err
+	}
+	err = DB.AutoMigrate(&domains.CollectionItem{})
+	if err != nil {
+		return err
+	}
 	return nil
 }
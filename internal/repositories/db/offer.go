@@ -32,12 +32,20 @@ func (r *offerRepository) DeleteOffer(ctx context.Context, offerId string) error
 }
 func (r *offerRepository) GetOffersByProductId(ctx context.Context, productId string) (domains.Offer, error) {
 	var offer domains.Offer
-	err := r.DB.First(&offer, "product_id = ?", productId).Error
+	err := r.DB.Order("created_at, id").First(&offer, "product_id = ?", productId).Error
 	if err != nil {
 		return domains.Offer{}, err
 	}
 	return offer, nil
 }
+func (r *offerRepository) ListOffersByProductId(ctx context.Context, productId string) ([]domains.Offer, error) {
+	var offers []domains.Offer
+	err := r.DB.Where("product_id = ?", productId).Order("created_at, id").Find(&offers).Error
+	if err != nil {
+		return nil, err
+	}
+	return offers, nil
+}
 func (r *offerRepository) GetOfferById(ctx context.Context, offerId string) (domains.Offer, error) {
 	var offer domains.Offer
 	err := r.DB.First(&offer, "id = ?", offerId).Error
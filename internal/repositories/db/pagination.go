@@ -0,0 +1,61 @@
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// pageCursor is the position after the last row of a page: its sort value and id, so rows with
+// equal sort values are neither skipped nor repeated.
+type pageCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	Id    string `json:"id"`
+}
+
+func encodeCursor(cursor pageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor reads a cursor created for the same sort. Its value is converted back to the
+// type of the sort column by parseValue.
+func decodeCursor(raw, sort string, parseValue func(string) (any, error)) (any, uuid.UUID, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, uuid.Nil, ports.ErrInvalidCursor
+	}
+	var cursor pageCursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil || cursor.Sort != sort {
+		return nil, uuid.Nil, ports.ErrInvalidCursor
+	}
+	id, err := uuid.FromString(cursor.Id)
+	if err != nil {
+		return nil, uuid.Nil, ports.ErrInvalidCursor
+	}
+	value, err := parseValue(cursor.Value)
+	if err != nil {
+		return nil, uuid.Nil, ports.ErrInvalidCursor
+	}
+	return value, id, nil
+}
+
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return defaultPageLimit
+	}
+	return min(limit, maxPageLimit)
+}
+
+func totalPages(total int64, limit int) int {
+	return int((total + int64(limit) - 1) / int64(limit))
+}
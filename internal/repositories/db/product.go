@@ -2,8 +2,10 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
 	"github.com/market-place-affiliate/api/internal/core/ports"
 	"gorm.io/gorm"
 )
@@ -16,6 +18,10 @@ func NewProductRepository(db *gorm.DB) ports.ProductRepository {
 	return &productRepository{DB: db}
 }
 
+func preloadProductImages(db *gorm.DB) *gorm.DB {
+	return db.Order("position asc")
+}
+
 func (r *productRepository) SaveProduct(ctx context.Context, product domains.Product) (domains.Product, error) {
 	err := r.DB.Save(&product).Error
 	if err != nil {
@@ -24,15 +30,19 @@ func (r *productRepository) SaveProduct(ctx context.Context, product domains.Pro
 	return product, nil
 }
 func (r *productRepository) DeleteProduct(ctx context.Context, productId string) error {
-	err := r.DB.Delete(&domains.Product{}, "id = ?", productId).Error
+	return r.DeleteProductById(ctx, productId)
+}
+func (r *productRepository) GetProductById(ctx context.Context, productId string) (domains.Product, error) {
+	var product domains.Product
+	err := r.DB.Scopes(notDeleted).Preload("Images", preloadProductImages).Preload("Tags").First(&product, "id = ?", productId).Error
 	if err != nil {
-		return err
+		return domains.Product{}, err
 	}
-	return nil
+	return product, nil
 }
-func (r *productRepository) GetProductById(ctx context.Context, productId string) (domains.Product, error) {
+func (r *productRepository) GetProductByIdWithDeleted(ctx context.Context, productId string) (domains.Product, error) {
 	var product domains.Product
-	err := r.DB.First(&product, "id = ?", productId).Error
+	err := r.DB.Preload("Images", preloadProductImages).Preload("Tags").First(&product, "id = ?", productId).Error
 	if err != nil {
 		return domains.Product{}, err
 	}
@@ -40,7 +50,7 @@ func (r *productRepository) GetProductById(ctx context.Context, productId string
 }
 func (r *productRepository) GetAllProducts(ctx context.Context, userId int64) ([]domains.Product, error) {
 	var products []domains.Product
-	err := r.DB.Where("user_id = ?", userId).Find(&products).Error
+	err := r.DB.Scopes(notDeleted).Preload("Images", preloadProductImages).Preload("Tags").Where("user_id = ?", userId).Find(&products).Error
 	if err != nil {
 		return nil, err
 	}
@@ -48,9 +58,131 @@ func (r *productRepository) GetAllProducts(ctx context.Context, userId int64) ([
 }
 
 func (r *productRepository) DeleteProductById(ctx context.Context, productId string) error {
-	err := r.DB.Delete(&domains.Product{}, "id = ?", productId).Error
+	// Links share the product's deleted_at so a restore can tell them from links deleted earlier.
+	deletedAt := time.Now()
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domains.Link{}).Scopes(notDeleted).Where("product_id = ?", productId).Update("deleted_at", deletedAt).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&domains.Product{}).Scopes(notDeleted).Where("id = ?", productId).Update("deleted_at", deletedAt).Error
+	})
+}
+
+func (r *productRepository) RestoreProduct(ctx context.Context, productId string) error {
+	var product domains.Product
+	err := r.DB.First(&product, "id = ?", productId).Error
+	if err != nil {
+		return err
+	}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domains.Link{}).
+			Where("product_id = ? AND deleted_at = ?", productId, product.DeletedAt).
+			Where("campaign_id IN (?)", tx.Model(&domains.Campaign{}).Scopes(notDeleted).Select("id")).
+			Update("deleted_at", nil).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&domains.Product{}).Where("id = ?", productId).Update("deleted_at", nil).Error
+	})
+}
+
+// PurgeDeletedProducts removes products deleted before the cutoff with everything that only
+// exists for them, including the clicks on their links.
+func (r *productRepository) PurgeDeletedProducts(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		productIds := tx.Model(&domains.Product{}).Select("id").Where("deleted_at < ?", before)
+		linkIds := tx.Model(&domains.Link{}).Select("id").Where("product_id IN (?)", productIds)
+		err := deleteLinkClicks(tx, linkIds)
+		if err != nil {
+			return err
+		}
+		err = tx.Where("product_id IN (?)", productIds).Delete(&domains.Link{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("product_id IN (?)", productIds).Delete(&domains.Offer{}).Error
+		if err != nil {
+			return err
+		}
+		err = deleteProductChildren(tx, productIds)
+		if err != nil {
+			return err
+		}
+		result := tx.Where("deleted_at < ?", before).Delete(&domains.Product{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+func (r *productRepository) GetProductsByQuery(ctx context.Context, userId int64, query dto.GetProductsQueryRequest) ([]domains.Product, error) {
+	var products []domains.Product
+	dbQuery := r.DB.Preload("Images", preloadProductImages).Preload("Tags").Where("products.user_id = ?", userId)
+	if query.Deleted {
+		dbQuery = dbQuery.Scopes(onlyDeleted)
+	} else {
+		dbQuery = dbQuery.Scopes(notDeleted)
+	}
+	if query.TagId != "" {
+		dbQuery = dbQuery.Where("products.id IN (?)", r.DB.Table("product_tags").Select("product_id").Where("tag_id = ?", query.TagId))
+	}
+	if query.CollectionId != "" {
+		dbQuery = dbQuery.
+			Joins("JOIN collection_items ON collection_items.product_id = products.id").
+			Where("collection_items.collection_id = ?", query.CollectionId).
+			Order("collection_items.position asc")
+	}
+	err := dbQuery.Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *productRepository) GetUnavailableProducts(ctx context.Context, userId int64) ([]domains.Product, error) {
+	var products []domains.Product
+	err := r.DB.Scopes(notDeleted).Preload("Images", preloadProductImages).Preload("Tags").
+		Where("user_id = ?", userId).
+		Where("EXISTS (SELECT 1 FROM offers WHERE offers.product_id = products.id)").
+		Where("NOT EXISTS (SELECT 1 FROM offers WHERE offers.product_id = products.id AND offers.availability = ?)", domains.OfferAvailable).
+		Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *productRepository) ReplaceProductImages(ctx context.Context, productId string, images []domains.ProductImage) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Delete(&domains.ProductImage{}, "product_id = ?", productId).Error
+		if err != nil {
+			return err
+		}
+		if len(images) == 0 {
+			return nil
+		}
+		return tx.Create(&images).Error
+	})
+}
+
+// deleteProductChildren removes the rows that only exist for the products: images, tag assignments and collection entries.
+func deleteProductChildren(tx *gorm.DB, productIds *gorm.DB) error {
+	err := tx.Delete(&domains.ProductImage{}, "product_id IN (?)", productIds).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Exec("DELETE FROM product_tags WHERE product_id IN (?)", productIds).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Delete(&domains.CollectionItem{}, "product_id IN (?)", productIds).Error
 	if err != nil {
 		return err
 	}
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,19 @@
+package db
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Campaigns, products and links are soft deleted: deleting one sets its deleted_at, and it is
+// only purged for good once the retention period has passed.
+
+// notDeleted scopes a query of campaigns, products or links to those that are not deleted.
+func notDeleted(db *gorm.DB) *gorm.DB {
+	return db.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "deleted_at"}, Value: nil})
+}
+
+// onlyDeleted scopes a query of campaigns, products or links to those that are deleted.
+func onlyDeleted(db *gorm.DB) *gorm.DB {
+	return db.Where(clause.Neq{Column: clause.Column{Table: clause.CurrentTable, Name: "deleted_at"}, Value: nil})
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+type tagRepository struct {
+	DB *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) ports.TagRepository {
+	return &tagRepository{DB: db}
+}
+
+func (r *tagRepository) SaveTag(ctx context.Context, tag domains.Tag) (domains.Tag, error) {
+	err := r.DB.Save(&tag).Error
+	if err != nil {
+		return domains.Tag{}, err
+	}
+	return tag, nil
+}
+
+func (r *tagRepository) GetTagById(ctx context.Context, tagId string) (domains.Tag, error) {
+	var tag domains.Tag
+	err := r.DB.First(&tag, "id = ?", tagId).Error
+	if err != nil {
+		return domains.Tag{}, err
+	}
+	return tag, nil
+}
+
+func (r *tagRepository) GetTagsByUserId(ctx context.Context, userId int64) ([]domains.Tag, error) {
+	var tags []domains.Tag
+	err := r.DB.Where("user_id = ?", userId).Order("name asc").Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) DeleteTag(ctx context.Context, tagId string) error {
+	err := r.DB.Exec("DELETE FROM product_tags WHERE tag_id = ?", tagId).Error
+	if err != nil {
+		return err
+	}
+	err = r.DB.Delete(&domains.Tag{}, "id = ?", tagId).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *tagRepository) AddProductTag(ctx context.Context, productId, tagId string) error {
+	err := r.DB.Exec("INSERT INTO product_tags (product_id, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING", productId, tagId).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *tagRepository) RemoveProductTag(ctx context.Context, productId, tagId string) error {
+	err := r.DB.Exec("DELETE FROM product_tags WHERE product_id = ? AND tag_id = ?", productId, tagId).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
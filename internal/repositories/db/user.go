@@ -33,6 +33,14 @@ func (r *userRepository) GetUserByID(ctx context.Context, userId int64) (domains
 	return user, nil
 }
 
+func (r *userRepository) UpdateUser(ctx context.Context, user domains.User) (domains.User, error) {
+	err := r.DB.Save(&user).Error
+	if err != nil {
+		return domains.User{}, err
+	}
+	return user, nil
+}
+
 func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (domains.User, error) {
 	var user domains.User
 	err := r.DB.First(&user, "email = ?", email).Error
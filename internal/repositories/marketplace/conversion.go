@@ -0,0 +1,36 @@
+package marketplace
+
+import (
+	"strings"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+)
+
+const (
+	// conversionPageSize is how many report rows are asked for per request.
+	conversionPageSize = 100
+	// maxConversionPages stops a report that keeps claiming more pages.
+	maxConversionPages = 200
+)
+
+var conversionStatuses = map[string]string{
+	"completed": domains.ConversionApproved,
+	"delivered": domains.ConversionApproved,
+	"fulfilled": domains.ConversionApproved,
+	"valid":     domains.ConversionApproved,
+	"cancelled": domains.ConversionCancelled,
+	"canceled":  domains.ConversionCancelled,
+	"invalid":   domains.ConversionCancelled,
+	"returned":  domains.ConversionCancelled,
+	"refunded":  domains.ConversionCancelled,
+}
+
+// conversionStatus normalises the order status of either report. Orders that are not yet paid,
+// shipped or settled are pending.
+func conversionStatus(status string) string {
+	normalised, ok := conversionStatuses[strings.ToLower(status)]
+	if !ok {
+		return domains.ConversionPending
+	}
+	return normalised
+}
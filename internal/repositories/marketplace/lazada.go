@@ -0,0 +1,209 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/market-place-affiliate/commonlib/lazada"
+)
+
+const Lazada = "lazada"
+
+type lazadaProvider struct {
+	clients LazadaClients
+}
+
+func NewLazadaProvider(clients LazadaClients) ports.MarketplaceProvider {
+	return &lazadaProvider{clients: clients}
+}
+
+func (p *lazadaProvider) Name() string {
+	return Lazada
+}
+
+func (p *lazadaProvider) ValidateCredential(cred domains.MarketplaceCredential) error {
+	_, err := clientFor(p.clients, cred)
+	if err != nil {
+		return err
+	}
+	return missingFields(map[string]string{
+		"app_key":    cred.AppKey,
+		"app_secret": cred.AppSecret,
+		"user_token": cred.UserToken,
+	})
+}
+
+func (p *lazadaProvider) FetchProduct(ctx context.Context, cred domains.MarketplaceCredential, sourceUrl string) ([]dto.MarketplaceProduct, error) {
+	lazadaRepo, err := clientFor(p.clients, cred)
+	if err != nil {
+		return nil, err
+	}
+	lazadaCred := lazadaCredentials(cred)
+	lazadaResp, err := lazadaRepo.GetBatchPromoteLink(lazadaCred, "url", sourceUrl, [6]string{})
+	if err == nil {
+		err = lazadaError(lazadaResp.Code)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(lazadaResp.Result.Data.URLBatchGetLinkInfoList) == 0 {
+		return nil, fmt.Errorf("%w: lazada returned no product for %s", ports.ErrInvalidProductUrl, sourceUrl)
+	}
+
+	products := []dto.MarketplaceProduct{}
+	for _, promote := range lazadaResp.Result.Data.URLBatchGetLinkInfoList {
+		lazadaProductFeed, err := lazadaRepo.GetProductFeed(lazadaCred, promote.ProductID, 1, 1)
+		if err == nil {
+			err = lazadaError(lazadaProductFeed.Code)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(lazadaProductFeed.Result.Data) == 0 {
+			return nil, ports.ErrProductNotAvailable
+		}
+		for _, feed := range lazadaProductFeed.Result.Data {
+			images := productImages(feed.Pictures)
+			prod := domains.Product{
+				Title:    feed.ProductName,
+				Images:   images,
+				Category: categoryPath([]int{feed.CategoryL1}),
+			}
+			if len(images) > 0 {
+				prod.ImageUrl = images[0].Url
+			}
+			availability := domains.OfferAvailable
+			if feed.OutOfStock {
+				availability = domains.OfferOutOfStock
+			}
+			currency := feed.Currency
+			if currency == "" {
+				currency = regionCurrency(credentialRegion(cred))
+			}
+			storeName := feed.BrandName
+			if storeName == "" {
+				storeName = "Lazada Official Store"
+			}
+			products = append(products, dto.MarketplaceProduct{
+				Product: prod,
+				Offers: []domains.Offer{{
+					Marketplace:    Lazada,
+					StoreName:      storeName,
+					Price:          feed.DiscountPrice,
+					Currency:       currency,
+					CommissionRate: feed.TotalCommissionRate,
+					Commission:     feed.TotalCommissionAmount,
+					Sales:          feed.Sales7D,
+					Availability:   availability,
+				}},
+			})
+		}
+	}
+	return products, nil
+}
+
+func (p *lazadaProvider) ListOffers(ctx context.Context, cred domains.MarketplaceCredential, sourceUrl string) ([]domains.Offer, error) {
+	products, err := p.FetchProduct(ctx, cred, sourceUrl)
+	if err != nil {
+		return nil, err
+	}
+	offers := []domains.Offer{}
+	for _, product := range products {
+		offers = append(offers, product.Offers...)
+	}
+	return offers, nil
+}
+
+func (p *lazadaProvider) GenerateAffiliateLink(ctx context.Context, cred domains.MarketplaceCredential, sourceUrl string, subIds []string) (string, error) {
+	lazadaRepo, err := clientFor(p.clients, cred)
+	if err != nil {
+		return "", err
+	}
+	sub := [6]string{}
+	copy(sub[:], subIds)
+	lazadaResp, err := lazadaRepo.GetBatchPromoteLink(lazadaCredentials(cred), "url", sourceUrl, sub)
+	if err == nil {
+		err = lazadaError(lazadaResp.Code)
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(lazadaResp.Result.Data.URLBatchGetLinkInfoList) == 0 {
+		return "", fmt.Errorf("%w: lazada returned no promotion link for %s", ports.ErrInvalidProductUrl, sourceUrl)
+	}
+	return lazadaResp.Result.Data.URLBatchGetLinkInfoList[0].RegularPromotionLink, nil
+}
+
+func (p *lazadaProvider) FetchConversions(ctx context.Context, cred domains.MarketplaceCredential, start, end time.Time) ([]domains.Conversion, error) {
+	lazadaRepo, err := clientFor(p.clients, cred)
+	if err != nil {
+		return nil, err
+	}
+	lazadaCred := lazadaCredentials(cred)
+	currency := regionCurrency(credentialRegion(cred))
+	conversions := []domains.Conversion{}
+	// The report filters by whole days in the marketplace's timezone, so a day either side is
+	// asked for and rows outside [start, end) are dropped below.
+	dateStart := start.AddDate(0, 0, -1).Format(time.DateOnly)
+	dateEnd := end.AddDate(0, 0, 1).Format(time.DateOnly)
+	for page := 1; page <= maxConversionPages; page++ {
+		report, err := lazadaRepo.GetConversionReport(lazadaCred, dateStart, dateEnd, page, conversionPageSize)
+		if err == nil {
+			err = lazadaError(report.Code)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range report.Result.Data {
+			orderedAt := time.UnixMilli(row.ConversionTime)
+			if orderedAt.Before(start) || !orderedAt.Before(end) {
+				continue
+			}
+			itemId := row.SubOrderId
+			if itemId == "" {
+				itemId = row.ProductId
+			}
+			rowCurrency := row.Currency
+			if rowCurrency == "" {
+				rowCurrency = currency
+			}
+			conversions = append(conversions, domains.Conversion{
+				Marketplace: Lazada,
+				OrderId:     row.OrderId,
+				ItemId:      itemId,
+				Status:      conversionStatus(row.OrderStatus),
+				SubIds:      []string{row.Sub1, row.Sub2, row.Sub3, row.Sub4, row.Sub5, row.Sub6},
+				Amount:      row.OrderAmount,
+				Commission:  row.EstPayout,
+				Currency:    rowCurrency,
+				OrderedAt:   orderedAt,
+			})
+		}
+		if len(report.Result.Data) < conversionPageSize {
+			break
+		}
+	}
+	return conversions, nil
+}
+
+func lazadaCredentials(cred domains.MarketplaceCredential) lazada.LazadaCredentials {
+	return lazada.LazadaCredentials{
+		AppKey:     cred.AppKey,
+		AppSecret:  cred.AppSecret,
+		SignMethod: "sha256",
+		UserToken:  cred.UserToken,
+	}
+}
+
+// lazadaError turns a non-zero Lazada response code into an error. Lazada reports most failures,
+// such as bad signatures or rate limits, with HTTP 200 and a code in the body.
+func lazadaError(code string) error {
+	if code == "" || code == "0" {
+		return nil
+	}
+	return fmt.Errorf("lazada responded with code %s", code)
+}
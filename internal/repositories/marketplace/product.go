@@ -0,0 +1,37 @@
+package marketplace
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+)
+
+// productImages turns marketplace picture urls into an ordered gallery, skipping blanks and duplicates.
+func productImages(urls []string) []domains.ProductImage {
+	images := []domains.ProductImage{}
+	seen := map[string]bool{}
+	for _, url := range urls {
+		if url == "" || seen[url] {
+			continue
+		}
+		seen[url] = true
+		images = append(images, domains.ProductImage{
+			Url:      url,
+			Position: len(images),
+		})
+	}
+	return images
+}
+
+// categoryPath joins marketplace category ids from the top level down, e.g. "100001/100017".
+func categoryPath(ids []int) string {
+	parts := []string{}
+	for _, id := range ids {
+		if id == 0 {
+			continue
+		}
+		parts = append(parts, strconv.Itoa(id))
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,103 @@
+package marketplace
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/market-place-affiliate/commonlib/lazada"
+	"github.com/market-place-affiliate/commonlib/shopee"
+)
+
+var lazadaGateways = map[string]lazada.LazadaApiGateway{
+	"th": lazada.ApiGatewayTH,
+	"my": lazada.ApiGatewayMY,
+	"vn": lazada.ApiGatewayVN,
+	"ph": lazada.ApiGatewayPH,
+	"sg": lazada.ApiGatewaySG,
+	"id": lazada.ApiGatewayID,
+}
+
+var shopeeEndpoints = map[string]string{
+	"th": "https://open-api.affiliate.shopee.co.th/graphql",
+	"my": "https://open-api.affiliate.shopee.com.my/graphql",
+	"vn": "https://open-api.affiliate.shopee.vn/graphql",
+	"ph": "https://open-api.affiliate.shopee.ph/graphql",
+	"sg": "https://open-api.affiliate.shopee.sg/graphql",
+	"id": "https://open-api.affiliate.shopee.co.id/graphql",
+}
+
+var regionCurrencies = map[string]string{
+	"th": "THB",
+	"my": "MYR",
+	"vn": "VND",
+	"ph": "PHP",
+	"sg": "SGD",
+	"id": "IDR",
+}
+
+// LazadaClients holds one Lazada client per region.
+type LazadaClients map[string]LazadaClient
+
+// ShopeeClients holds one Shopee client per region.
+type ShopeeClients map[string]ShopeeClient
+
+// NewLazadaClients builds a client for every region. A non-empty gateway, such as the one
+// served by cmd/fakemarket, replaces the real gateway of every region.
+func NewLazadaClients(gateway string, debug bool) LazadaClients {
+	clients := LazadaClients{}
+	for region, regionGateway := range lazadaGateways {
+		if gateway != "" {
+			regionGateway = lazada.LazadaApiGateway(gateway)
+		}
+		clients[region] = NewLazadaClient(regionGateway, debug)
+	}
+	return clients
+}
+
+// NewShopeeClients builds a client for every region. A non-empty endpoint, such as the one
+// served by cmd/fakemarket, replaces the real endpoint of every region.
+func NewShopeeClients(endpoint string, debug bool) ShopeeClients {
+	clients := ShopeeClients{}
+	for region, regionEndpoint := range shopeeEndpoints {
+		switch {
+		case endpoint != "":
+			clients[region] = NewShopeeClient(endpoint, debug)
+		case region == domains.DefaultMarketplaceRegion:
+			clients[region] = &commonlibShopeeClient{
+				ShopeeRepository: shopee.NewShopeeRepository(debug),
+				reports:          NewShopeeClient(regionEndpoint, debug),
+			}
+		default:
+			clients[region] = NewShopeeClient(regionEndpoint, debug)
+		}
+	}
+	return clients
+}
+
+func credentialRegion(cred domains.MarketplaceCredential) string {
+	if cred.Region == "" {
+		return domains.DefaultMarketplaceRegion
+	}
+	return strings.ToLower(cred.Region)
+}
+
+func regionCurrency(region string) string {
+	return regionCurrencies[region]
+}
+
+// clientFor returns the client registered for the credential's region.
+func clientFor[T any](clients map[string]T, cred domains.MarketplaceCredential) (T, error) {
+	client, ok := clients[credentialRegion(cred)]
+	if !ok {
+		regions := []string{}
+		for region := range clients {
+			regions = append(regions, region)
+		}
+		sort.Strings(regions)
+		return client, fmt.Errorf("%w %q, expected one of %s", ports.ErrUnsupportedRegion, cred.Region, strings.Join(regions, ", "))
+	}
+	return client, nil
+}
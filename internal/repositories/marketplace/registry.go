@@ -0,0 +1,53 @@
+package marketplace
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+type registry struct {
+	providers map[string]ports.MarketplaceProvider
+}
+
+func NewRegistry(providers ...ports.MarketplaceProvider) ports.MarketplaceRegistry {
+	r := &registry{providers: map[string]ports.MarketplaceProvider{}}
+	for _, provider := range providers {
+		r.providers[provider.Name()] = provider
+	}
+	return r
+}
+
+func (r *registry) Get(name string) (ports.MarketplaceProvider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ports.ErrUnsupportedMarketplace, name)
+	}
+	return provider, nil
+}
+
+func (r *registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// missingFields reports which required credential fields are empty, as "field_a, field_b".
+func missingFields(fields map[string]string) error {
+	missing := []string{}
+	for name, value := range fields {
+		if value == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return fmt.Errorf("missing credential fields: %s", strings.Join(missing, ", "))
+}
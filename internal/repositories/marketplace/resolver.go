@@ -0,0 +1,120 @@
+package marketplace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/core/ports"
+)
+
+type UrlResolverConfig struct {
+	// AllowedHosts are host suffixes every url in a redirect chain must match, e.g. "shopee.co.th"
+	// matches shopee.co.th and s.shopee.co.th.
+	AllowedHosts []string
+	// ShortLinkHosts are hosts whose urls are followed until they land on another host.
+	ShortLinkHosts []string
+	MaxHops        int
+	Timeout        time.Duration
+}
+
+type urlResolver struct {
+	cfg        UrlResolverConfig
+	httpClient *http.Client
+}
+
+func NewUrlResolver(cfg UrlResolverConfig) ports.UrlResolver {
+	if cfg.MaxHops <= 0 {
+		cfg.MaxHops = 5
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	return &urlResolver{
+		cfg: cfg,
+		httpClient: &http.Client{
+			// Redirects are followed by hand so every hop can be checked against the allowlist.
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (r *urlResolver) Resolve(ctx context.Context, rawUrl string) (string, error) {
+	current, err := url.Parse(strings.TrimSpace(rawUrl))
+	if err != nil || (current.Scheme != "http" && current.Scheme != "https") || current.Host == "" {
+		return "", fmt.Errorf("%w: %s", ports.ErrInvalidProductUrl, rawUrl)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+	for hops := 0; ; hops++ {
+		if !hostMatches(current.Hostname(), r.cfg.AllowedHosts) {
+			return "", fmt.Errorf("%w: %s", ports.ErrUrlHostNotAllowed, current.Hostname())
+		}
+		if !hostIn(current.Hostname(), r.cfg.ShortLinkHosts) {
+			return current.String(), nil
+		}
+		if hops >= r.cfg.MaxHops {
+			return "", fmt.Errorf("%w: gave up after %d hops at %s", ports.ErrTooManyRedirects, hops, current)
+		}
+		next, err := r.follow(ctx, current)
+		if err != nil {
+			return "", err
+		}
+		current = next
+	}
+}
+
+// follow requests u and returns the url it redirects to.
+func (r *urlResolver) follow(ctx context.Context, u *url.URL) (*url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ports.ErrUrlResolveFailed, err.Error())
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; market-place-affiliate)")
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: timed out after %s", ports.ErrUrlResolveFailed, r.cfg.Timeout)
+		}
+		return nil, fmt.Errorf("%w: %s", ports.ErrUrlResolveFailed, err.Error())
+	}
+	resp.Body.Close()
+
+	location := resp.Header.Get("Location")
+	if resp.StatusCode < 300 || resp.StatusCode >= 400 || location == "" {
+		return nil, fmt.Errorf("%w: %s answered %d without a redirect", ports.ErrUrlResolveFailed, u.Hostname(), resp.StatusCode)
+	}
+	next, err := u.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad redirect location %q", ports.ErrUrlResolveFailed, location)
+	}
+	return next, nil
+}
+
+// hostMatches reports whether host equals one of suffixes or is a subdomain of one.
+func hostMatches(host string, suffixes []string) bool {
+	host = strings.ToLower(host)
+	for _, suffix := range suffixes {
+		suffix = strings.ToLower(suffix)
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func hostIn(host string, hosts []string) bool {
+	for _, h := range hosts {
+		if strings.EqualFold(host, h) {
+			return true
+		}
+	}
+	return false
+}
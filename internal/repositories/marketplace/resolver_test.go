@@ -0,0 +1,133 @@
+package marketplace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+)
+
+// newShortLinkServer serves short links on 127.0.0.1. The resolver follows urls on that host and
+// accepts landing on localhost, the same server under another name, or on example.com.
+func newShortLinkServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, UrlResolverConfig) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server, UrlResolverConfig{
+		AllowedHosts:   []string{"127.0.0.1", "localhost", "example.com"},
+		ShortLinkHosts: []string{"127.0.0.1"},
+		MaxHops:        3,
+		Timeout:        time.Second,
+	}
+}
+
+// landing is the url of path on server under the name localhost, which is not followed.
+func landing(server *httptest.Server, path string) string {
+	u, _ := url.Parse(server.URL)
+	return "http://localhost:" + u.Port() + path
+}
+
+func TestUrlResolver_FollowsShortLinks(t *testing.T) {
+	var server *httptest.Server
+	server, cfg := newShortLinkServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/s/abc":
+			// Relative locations resolve against the url that answered.
+			w.Header().Set("Location", "/s/next?ref=share")
+		case "/s/next":
+			w.Header().Set("Location", landing(server, "/product/123?ref="+r.URL.Query().Get("ref")))
+		}
+		w.WriteHeader(http.StatusFound)
+	})
+
+	resolved, err := NewUrlResolver(cfg).Resolve(context.Background(), "  "+server.URL+"/s/abc  ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, landing(server, "/product/123?ref=share"), resolved)
+}
+
+func TestUrlResolver_LeavesProductUrlsAlone(t *testing.T) {
+	var requests atomic.Int32
+	server, cfg := newShortLinkServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	})
+
+	resolved, err := NewUrlResolver(cfg).Resolve(context.Background(), landing(server, "/product/123"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, landing(server, "/product/123"), resolved)
+	assert.Zero(t, requests.Load())
+}
+
+func TestUrlResolver_Failures(t *testing.T) {
+	var loops atomic.Int32
+	server, cfg := newShortLinkServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/loop":
+			loops.Add(1)
+			w.Header().Set("Location", "/loop")
+			w.WriteHeader(http.StatusMovedPermanently)
+		case "/elsewhere":
+			w.Header().Set("Location", "https://evil.example/phish")
+			w.WriteHeader(http.StatusFound)
+		case "/not-found":
+			w.WriteHeader(http.StatusNotFound)
+		case "/no-location":
+			w.WriteHeader(http.StatusFound)
+		case "/bad-location":
+			w.Header().Set("Location", "http://[::1")
+			w.WriteHeader(http.StatusFound)
+		case "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}
+	})
+	cfg.Timeout = 50 * time.Millisecond
+
+	tests := []struct {
+		name string
+		url  string
+		// err is what the product service maps to its error code: ErrInvalidProductUrl and
+		// ErrUrlHostNotAllowed to 2008, ErrTooManyRedirects to 2009 and ErrUrlResolveFailed
+		// to 2010.
+		err error
+	}{
+		{name: "not a url", url: "shopee", err: ports.ErrInvalidProductUrl},
+		{name: "not http", url: "ftp://127.0.0.1/file", err: ports.ErrInvalidProductUrl},
+		{name: "host not allowed", url: "https://evil.example/phish", err: ports.ErrUrlHostNotAllowed},
+		{name: "redirect to host not allowed", url: server.URL + "/elsewhere", err: ports.ErrUrlHostNotAllowed},
+		{name: "redirect loop", url: server.URL + "/loop", err: ports.ErrTooManyRedirects},
+		{name: "not found", url: server.URL + "/not-found", err: ports.ErrUrlResolveFailed},
+		{name: "redirect without location", url: server.URL + "/no-location", err: ports.ErrUrlResolveFailed},
+		{name: "bad location", url: server.URL + "/bad-location", err: ports.ErrUrlResolveFailed},
+		{name: "timeout", url: server.URL + "/slow", err: ports.ErrUrlResolveFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := NewUrlResolver(cfg).Resolve(context.Background(), tt.url)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Empty(t, resolved)
+		})
+	}
+	assert.Equal(t, int32(cfg.MaxHops), loops.Load())
+}
+
+func TestUrlResolver_TimeoutMessage(t *testing.T) {
+	server, cfg := newShortLinkServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	cfg.Timeout = 50 * time.Millisecond
+
+	_, err := NewUrlResolver(cfg).Resolve(context.Background(), server.URL+"/slow")
+
+	assert.ErrorIs(t, err, ports.ErrUrlResolveFailed)
+	assert.ErrorContains(t, err, "timed out after 50ms")
+}
@@ -0,0 +1,223 @@
+package marketplace
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/internal/core/ports"
+	"github.com/market-place-affiliate/commonlib/shopee"
+)
+
+const Shopee = "shopee"
+
+// shopeeSubIdCount is how many sub ids a Shopee affiliate link carries.
+const shopeeSubIdCount = 5
+
+type shopeeProvider struct {
+	clients ShopeeClients
+}
+
+func NewShopeeProvider(clients ShopeeClients) ports.MarketplaceProvider {
+	return &shopeeProvider{clients: clients}
+}
+
+func (p *shopeeProvider) Name() string {
+	return Shopee
+}
+
+func (p *shopeeProvider) ValidateCredential(cred domains.MarketplaceCredential) error {
+	_, err := clientFor(p.clients, cred)
+	if err != nil {
+		return err
+	}
+	return missingFields(map[string]string{
+		"app_id":     cred.AppId,
+		"app_secret": cred.AppSecret,
+	})
+}
+
+func (p *shopeeProvider) FetchProduct(ctx context.Context, cred domains.MarketplaceCredential, sourceUrl string) ([]dto.MarketplaceProduct, error) {
+	offerList, err := p.getProductOfferList(cred, sourceUrl)
+	if err != nil {
+		return nil, err
+	}
+	nodes := offerList.Data.ProductOfferV2.Nodes
+
+	imageUrls := []string{}
+	for _, node := range nodes {
+		imageUrls = append(imageUrls, node.ImageURL)
+	}
+	prod := domains.Product{
+		Title:    nodes[0].ProductName,
+		ImageUrl: nodes[0].ImageURL,
+		Images:   productImages(imageUrls),
+		Category: categoryPath(nodes[0].ProductCatIds),
+	}
+	return []dto.MarketplaceProduct{{
+		Product: prod,
+		Offers:  shopeeOffers(offerList, regionCurrency(credentialRegion(cred))),
+	}}, nil
+}
+
+func (p *shopeeProvider) ListOffers(ctx context.Context, cred domains.MarketplaceCredential, sourceUrl string) ([]domains.Offer, error) {
+	offerList, err := p.getProductOfferList(cred, sourceUrl)
+	if err != nil {
+		return nil, err
+	}
+	return shopeeOffers(offerList, regionCurrency(credentialRegion(cred))), nil
+}
+
+func (p *shopeeProvider) GenerateAffiliateLink(ctx context.Context, cred domains.MarketplaceCredential, sourceUrl string, subIds []string) (string, error) {
+	shopeeRepo, err := clientFor(p.clients, cred)
+	if err != nil {
+		return "", err
+	}
+	sub := [shopeeSubIdCount]string{}
+	copy(sub[:], subIds)
+	shopeeResp, err := shopeeRepo.GetShortLink(shopeeCredentials(cred), sourceUrl, sub)
+	if err != nil {
+		return "", err
+	}
+	if shopeeResp.Data.GenerateShortLink.ShortLink == "" {
+		if len(shopeeResp.Errors) > 0 {
+			return "", fmt.Errorf("shopee short link: %s", shopeeResp.Errors[0].Message)
+		}
+		return "", fmt.Errorf("shopee returned no short link for %s", sourceUrl)
+	}
+	return shopeeResp.Data.GenerateShortLink.ShortLink, nil
+}
+
+func (p *shopeeProvider) FetchConversions(ctx context.Context, cred domains.MarketplaceCredential, start, end time.Time) ([]domains.Conversion, error) {
+	shopeeRepo, err := clientFor(p.clients, cred)
+	if err != nil {
+		return nil, err
+	}
+	shopeeCred := shopeeCredentials(cred)
+	currency := regionCurrency(credentialRegion(cred))
+	conversions := []domains.Conversion{}
+	scrollId := ""
+	for page := 1; page <= maxConversionPages; page++ {
+		report, err := shopeeRepo.GetConversionReport(shopeeCred, start, end, conversionPageSize, scrollId)
+		if err != nil {
+			return nil, err
+		}
+		if len(report.Errors) > 0 {
+			return nil, fmt.Errorf("shopee conversion report: %s", report.Errors[0].Message)
+		}
+		for _, node := range report.Data.ConversionReport.Nodes {
+			subIds := shopeeSubIds(node.UtmContent)
+			for _, order := range node.Orders {
+				for _, item := range order.Items {
+					amount, _ := strconv.ParseFloat(item.ActualAmount, 64)
+					commission, _ := strconv.ParseFloat(item.ItemTotalCommission, 64)
+					conversions = append(conversions, domains.Conversion{
+						Marketplace: Shopee,
+						OrderId:     order.OrderId,
+						ItemId:      fmt.Sprintf("%d-%d", item.ItemId, item.ModelId),
+						Status:      conversionStatus(order.OrderStatus),
+						SubIds:      subIds,
+						Amount:      amount,
+						Commission:  commission,
+						Currency:    currency,
+						OrderedAt:   time.Unix(node.PurchaseTime, 0),
+					})
+				}
+			}
+		}
+		pageInfo := report.Data.ConversionReport.PageInfo
+		if !pageInfo.HasNextPage || pageInfo.ScrollId == "" {
+			break
+		}
+		scrollId = pageInfo.ScrollId
+	}
+	return conversions, nil
+}
+
+func (p *shopeeProvider) getProductOfferList(cred domains.MarketplaceCredential, sourceUrl string) (shopee.ShopeeGetProductOfferList, error) {
+	shopeeRepo, err := clientFor(p.clients, cred)
+	if err != nil {
+		return shopee.ShopeeGetProductOfferList{}, err
+	}
+	shopId, itemId, err := shopeeShopAndItemId(sourceUrl)
+	if err != nil {
+		return shopee.ShopeeGetProductOfferList{}, fmt.Errorf("%w: %s", ports.ErrInvalidProductUrl, err.Error())
+	}
+	offerList, err := shopeeRepo.GetProductOfferListV2(shopeeCredentials(cred), shopId, itemId)
+	if err != nil {
+		return shopee.ShopeeGetProductOfferList{}, err
+	}
+	if len(offerList.Data.ProductOfferV2.Nodes) == 0 {
+		return shopee.ShopeeGetProductOfferList{}, ports.ErrProductNotAvailable
+	}
+	return offerList, nil
+}
+
+var shopeeProductPathPattern = regexp.MustCompile(`^/product/(\d+)/(\d+)`)
+
+// shopeeShopAndItemId reads the shop and item ids from either product url form,
+// https://shopee.co.th/Name-i.{shop}.{item} or https://shopee.co.th/product/{shop}/{item},
+// the latter being where app share links usually resolve to.
+func shopeeShopAndItemId(link string) (string, string, error) {
+	u, err := url.Parse(link)
+	if err == nil {
+		match := shopeeProductPathPattern.FindStringSubmatch(u.Path)
+		if match != nil {
+			return match[1], match[2], nil
+		}
+	}
+	return shopee.ExtractShopIdAndItemIdFromLink(link)
+}
+
+func shopeeOffers(offerList shopee.ShopeeGetProductOfferList, currency string) []domains.Offer {
+	offers := []domains.Offer{}
+	for _, node := range offerList.Data.ProductOfferV2.Nodes {
+		price, _ := strconv.ParseFloat(node.Price, 64)
+		commissionRate, _ := strconv.ParseFloat(node.CommissionRate, 64)
+		commission, _ := strconv.ParseFloat(node.Commission, 64)
+		ratingStar, _ := strconv.ParseFloat(node.RatingStar, 64)
+		offerUrl := node.OfferLink
+		if offerUrl == "" {
+			offerUrl = node.ProductLink
+		}
+		offers = append(offers, domains.Offer{
+			Marketplace:    Shopee,
+			StoreName:      node.ShopName,
+			Price:          price,
+			Currency:       currency,
+			CommissionRate: commissionRate,
+			Commission:     commission,
+			RatingStar:     ratingStar,
+			Sales:          node.Sales,
+			// productOfferV2 only lists offers that can currently be promoted.
+			Availability: domains.OfferAvailable,
+			Url:          offerUrl,
+		})
+	}
+	return offers
+}
+
+func shopeeCredentials(cred domains.MarketplaceCredential) shopee.ShopeeCredentials {
+	return shopee.ShopeeCredentials{
+		AppId:     cred.AppId,
+		AppSecret: cred.AppSecret,
+	}
+}
+
+// shopeeSubIds splits the utmContent of a conversion back into sub ids. Shopee joins them with
+// "-", keeping the place of empty ones, but does not escape a "-" inside one. Only the first
+// sub id, a campaign's UtmCampaign on the links made here, may contain one, so extra parts are
+// joined back into it and the other sub ids keep their place.
+func shopeeSubIds(utmContent string) []string {
+	parts := strings.Split(utmContent, "-")
+	if extra := len(parts) - shopeeSubIdCount; extra > 0 {
+		parts = append([]string{strings.Join(parts[:extra+1], "-")}, parts[extra+1:]...)
+	}
+	return parts
+}
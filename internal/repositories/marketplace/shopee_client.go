@@ -0,0 +1,184 @@
+package marketplace
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/market-place-affiliate/commonlib/shopee"
+)
+
+// ShopeeClient is the commonlib Shopee client plus the conversion report, which commonlib does
+// not cover.
+type ShopeeClient interface {
+	shopee.ShopeeRepository
+	// GetConversionReport lists the conversions purchased in [start, end), a page at a time.
+	// scrollId is empty for the first page and taken from the previous page after that.
+	GetConversionReport(cred shopee.ShopeeCredentials, start, end time.Time, limit int, scrollId string) (ShopeeConversionReport, error)
+}
+
+type ShopeeConversionReport struct {
+	Data struct {
+		ConversionReport struct {
+			Nodes    []ShopeeConversion `json:"nodes"`
+			PageInfo struct {
+				Limit       int    `json:"limit"`
+				HasNextPage bool   `json:"hasNextPage"`
+				ScrollId    string `json:"scrollId"`
+			} `json:"pageInfo"`
+		} `json:"conversionReport"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// ShopeeConversion is a click that led to orders. UtmContent holds the sub ids of the affiliate
+// link joined by "-".
+type ShopeeConversion struct {
+	ConversionId int64                   `json:"conversionId"`
+	PurchaseTime int64                   `json:"purchaseTime"`
+	UtmContent   string                  `json:"utmContent"`
+	Orders       []ShopeeConversionOrder `json:"orders"`
+}
+
+type ShopeeConversionOrder struct {
+	OrderId     string                 `json:"orderId"`
+	OrderStatus string                 `json:"orderStatus"`
+	Items       []ShopeeConversionItem `json:"items"`
+}
+
+// ShopeeConversionItem reports money as decimal strings, like productOfferV2.
+type ShopeeConversionItem struct {
+	ItemId              int64  `json:"itemId"`
+	ModelId             int64  `json:"modelId"`
+	ItemName            string `json:"itemName"`
+	Qty                 int    `json:"qty"`
+	ActualAmount        string `json:"actualAmount"`
+	ItemTotalCommission string `json:"itemTotalCommission"`
+}
+
+// shopeeClient speaks the same protocol as shopee.NewShopeeRepository but against a configurable
+// GraphQL endpoint, which the commonlib client hardcodes.
+type shopeeClient struct {
+	debug      bool
+	endpoint   string
+	httpClient *http.Client
+}
+
+func NewShopeeClient(endpoint string, debug bool) ShopeeClient {
+	return &shopeeClient{
+		debug:      debug,
+		endpoint:   endpoint,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *shopeeClient) GetProductOfferListV2(cred shopee.ShopeeCredentials, shopId, itemId string) (shopee.ShopeeGetProductOfferList, error) {
+	gql := fmt.Sprintf(`{ productOfferV2(shopId: %s, itemId: %s) { nodes { productName itemId commissionRate commission price sales imageUrl shopName productLink offerLink periodStartTime periodEndTime priceMin priceMax productCatIds ratingStar priceDiscountRate shopId shopType sellerCommissionRate shopeeCommissionRate } pageInfo { page limit hasNextPage scrollId } } }`, shopId, itemId)
+
+	var response shopee.ShopeeGetProductOfferList
+	err := c.post(cred, gql, &response)
+	if err != nil {
+		return shopee.ShopeeGetProductOfferList{}, err
+	}
+	return response, nil
+}
+
+func (c *shopeeClient) GetShortLink(cred shopee.ShopeeCredentials, originalUrl string, sub [5]string) (shopee.ShopeeGetShortLink, error) {
+	subIds := []string{}
+	for _, v := range sub {
+		if v != "" {
+			subIds = append(subIds, `"`+v+`"`)
+		}
+	}
+	gql := fmt.Sprintf(
+		`mutation { generateShortLink(input:{ originUrl:"%s", subIds:[%s] }){ shortLink }}`,
+		originalUrl,
+		strings.Join(subIds, ","),
+	)
+
+	var response shopee.ShopeeGetShortLink
+	err := c.post(cred, gql, &response)
+	if err != nil {
+		return shopee.ShopeeGetShortLink{}, err
+	}
+	return response, nil
+}
+
+func (c *shopeeClient) GetConversionReport(cred shopee.ShopeeCredentials, start, end time.Time, limit int, scrollId string) (ShopeeConversionReport, error) {
+	scroll := ""
+	if scrollId != "" {
+		scroll = fmt.Sprintf(`, scrollId: "%s"`, scrollId)
+	}
+	gql := fmt.Sprintf(
+		`{ conversionReport(purchaseTimeStart: %d, purchaseTimeEnd: %d, limit: %d%s) { nodes { conversionId purchaseTime utmContent orders { orderId orderStatus items { itemId modelId itemName qty actualAmount itemTotalCommission } } } pageInfo { limit hasNextPage scrollId } } }`,
+		start.Unix(),
+		end.Unix(),
+		limit,
+		scroll,
+	)
+
+	var response ShopeeConversionReport
+	err := c.post(cred, gql, &response)
+	if err != nil {
+		return ShopeeConversionReport{}, err
+	}
+	return response, nil
+}
+
+// commonlibShopeeClient serves products and short links from the commonlib client and the
+// conversion report from a shopeeClient for the same endpoint.
+type commonlibShopeeClient struct {
+	shopee.ShopeeRepository
+	reports ShopeeClient
+}
+
+func (c *commonlibShopeeClient) GetConversionReport(cred shopee.ShopeeCredentials, start, end time.Time, limit int, scrollId string) (ShopeeConversionReport, error) {
+	return c.reports.GetConversionReport(cred, start, end, limit, scrollId)
+}
+
+// post signs and sends a GraphQL query. The signature is sha256(appId + timestamp + payload + secret).
+func (c *shopeeClient) post(cred shopee.ShopeeCredentials, gql string, result any) error {
+	payload, err := json.Marshal(map[string]string{"query": gql})
+	if err != nil {
+		return err
+	}
+	timestamp := fmt.Sprintf("%d", time.Now().Unix())
+	hash := sha256.Sum256([]byte(cred.AppId + timestamp + string(payload) + cred.AppSecret))
+
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"SHA256 Credential=%s, Timestamp=%s, Signature=%s",
+		cred.AppId,
+		timestamp,
+		hex.EncodeToString(hash[:]),
+	))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if c.debug {
+		fmt.Printf("Shopee Response: %s\n", string(body))
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("shopee responded with status %d", resp.StatusCode)
+	}
+	return json.Unmarshal(body, result)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/market-place-affiliate/api/internal/core/dto"
@@ -22,17 +23,52 @@ func (m *MockCampaignRepository) DeleteCampaign(ctx context.Context, campaignId
 	return args.Error(0)
 }
 
+func (m *MockCampaignRepository) GetCampaignByIdWithDeleted(ctx context.Context, campaignId string) (domains.Campaign, error) {
+	args := m.Called(ctx, campaignId)
+	return args.Get(0).(domains.Campaign), args.Error(1)
+}
+
+func (m *MockCampaignRepository) RestoreCampaign(ctx context.Context, campaignId string) error {
+	args := m.Called(ctx, campaignId)
+	return args.Error(0)
+}
+
+func (m *MockCampaignRepository) PurgeDeletedCampaigns(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockCampaignRepository) GetCampaignById(ctx context.Context, campaignId string) (domains.Campaign, error) {
 	args := m.Called(ctx, campaignId)
 	return args.Get(0).(domains.Campaign), args.Error(1)
 }
 
-func (m *MockCampaignRepository) GetCampaignByQuery(ctx context.Context, userId int64, query dto.GetCampaignByQueryRequest) ([]domains.Campaign, error) {
+func (m *MockCampaignRepository) GetCampaignByQuery(ctx context.Context, userId int64, query dto.GetCampaignByQueryRequest) ([]domains.Campaign, dto.PageMeta, error) {
 	args := m.Called(ctx, userId, query)
+	return args.Get(0).([]domains.Campaign), args.Get(1).(dto.PageMeta), args.Error(2)
+}
+
+func (m *MockCampaignRepository) GetCampaignsByStates(ctx context.Context, states []string) ([]domains.Campaign, error) {
+	args := m.Called(ctx, states)
 	return args.Get(0).([]domains.Campaign), args.Error(1)
 }
 
-func (m *MockCampaignRepository) GetAvailableCampaign(ctx context.Context) ([]domains.Campaign, error) {
+func (m *MockCampaignRepository) UpdateCampaignState(ctx context.Context, campaignId string, from, to string) (bool, error) {
+	args := m.Called(ctx, campaignId, from, to)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCampaignRepository) SaveCampaignGoal(ctx context.Context, goal domains.CampaignGoal) (domains.CampaignGoal, error) {
+	args := m.Called(ctx, goal)
+	return args.Get(0).(domains.CampaignGoal), args.Error(1)
+}
+
+func (m *MockCampaignRepository) GetCampaignGoal(ctx context.Context, campaignId string) (domains.CampaignGoal, error) {
+	args := m.Called(ctx, campaignId)
+	return args.Get(0).(domains.CampaignGoal), args.Error(1)
+}
+
+func (m *MockCampaignRepository) GetNotifiableCampaignGoals(ctx context.Context) ([]domains.CampaignGoal, error) {
 	args := m.Called(ctx)
-	return args.Get(0).([]domains.Campaign), args.Error(1)
+	return args.Get(0).([]domains.CampaignGoal), args.Error(1)
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/gofrs/uuid"
 	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/market-place-affiliate/api/internal/core/dto"
 	"github.com/stretchr/testify/mock"
@@ -19,17 +18,59 @@ func (m *MockClickRepository) SaveClick(ctx context.Context, click domains.Click
 	return args.Error(0)
 }
 
-func (m *MockClickRepository) CountClicksByDateRange(ctx context.Context, userId int64, startDate, endDate time.Time) ([]dto.MetrictItem, error) {
-	args := m.Called(ctx, userId, startDate, endDate)
+func (m *MockClickRepository) GetClickById(ctx context.Context, clickId string) (domains.Click, error) {
+	args := m.Called(ctx, clickId)
+	return args.Get(0).(domains.Click), args.Error(1)
+}
+
+func (m *MockClickRepository) StreamClicks(ctx context.Context, userId int64, startDate, endDate time.Time, campaignId, linkId string, fn func(dto.ClickLogEntry) error) error {
+	args := m.Called(ctx, userId, startDate, endDate, campaignId, linkId, fn)
+	if entries, ok := args.Get(0).([]dto.ClickLogEntry); ok {
+		for _, entry := range entries {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockClickRepository) CountClicksByDateRange(ctx context.Context, userId int64, startDate, endDate time.Time, granularity, timezone string) ([]dto.MetrictItem, error) {
+	args := m.Called(ctx, userId, startDate, endDate, granularity, timezone)
 	return args.Get(0).([]dto.MetrictItem), args.Error(1)
 }
 
-func (m *MockClickRepository) CountTopProductClickByDateRange(ctx context.Context, userId int64, startDate, endDate time.Time) (uuid.UUID, int64, error) {
-	args := m.Called(ctx, userId, startDate, endDate)
-	return args.Get(0).(uuid.UUID), args.Get(1).(int64), args.Error(2)
+func (m *MockClickRepository) GetClickLeaderboard(ctx context.Context, userId int64, dimension string, previousStart, previousEnd, startDate, endDate time.Time, timezone, sort string, limit int) ([]dto.LeaderboardEntry, error) {
+	args := m.Called(ctx, userId, dimension, previousStart, previousEnd, startDate, endDate, timezone, sort, limit)
+	return args.Get(0).([]dto.LeaderboardEntry), args.Error(1)
 }
 
-func (m *MockClickRepository) DeleteClicksByLinkId(ctx context.Context, linkId string) error {
-	args := m.Called(ctx, linkId)
-	return args.Error(0)
+func (m *MockClickRepository) GetClickBreakdown(ctx context.Context, userId int64, dimension string, previousStart, previousEnd, startDate, endDate time.Time, campaignId, linkId, sort string, limit int) ([]dto.LeaderboardEntry, error) {
+	args := m.Called(ctx, userId, dimension, previousStart, previousEnd, startDate, endDate, campaignId, linkId, sort, limit)
+	return args.Get(0).([]dto.LeaderboardEntry), args.Error(1)
+}
+
+func (m *MockClickRepository) GetCampaignClickStats(ctx context.Context, campaignId string, startDate, endDate time.Time) (dto.CampaignClickStats, error) {
+	args := m.Called(ctx, campaignId, startDate, endDate)
+	return args.Get(0).(dto.CampaignClickStats), args.Error(1)
+}
+
+func (m *MockClickRepository) GetCampaignClickTotals(ctx context.Context, campaignId string, startDate, endDate time.Time, timezone string) (dto.ClickTotals, error) {
+	args := m.Called(ctx, campaignId, startDate, endDate, timezone)
+	return args.Get(0).(dto.ClickTotals), args.Error(1)
+}
+
+func (m *MockClickRepository) GetCampaignDailyClicks(ctx context.Context, campaignId string, startDate, endDate time.Time, timezone string) ([]dto.DailyClicks, error) {
+	args := m.Called(ctx, campaignId, startDate, endDate, timezone)
+	return args.Get(0).([]dto.DailyClicks), args.Error(1)
+}
+
+func (m *MockClickRepository) GetCampaignLinkClicks(ctx context.Context, campaignId string, startDate, endDate time.Time, timezone string) ([]dto.CampaignLinkReport, error) {
+	args := m.Called(ctx, campaignId, startDate, endDate, timezone)
+	return args.Get(0).([]dto.CampaignLinkReport), args.Error(1)
+}
+
+func (m *MockClickRepository) GetCampaignProductClicks(ctx context.Context, campaignId string, startDate, endDate time.Time, timezone string) ([]dto.CampaignProductReport, error) {
+	args := m.Called(ctx, campaignId, startDate, endDate, timezone)
+	return args.Get(0).([]dto.CampaignProductReport), args.Error(1)
 }
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockClickRollupRepository struct {
+	mock.Mock
+}
+
+func (m *MockClickRollupRepository) RollupClicks(ctx context.Context, from, to time.Time, timezone string) error {
+	args := m.Called(ctx, from, to, timezone)
+	return args.Error(0)
+}
+
+func (m *MockClickRollupRepository) GetLatestRollupBucket(ctx context.Context) (time.Time, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockClickRollupRepository) GetEarliestClickAt(ctx context.Context) (time.Time, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockClickRollupRepository) ResetRollups(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCollectionRepository struct {
+	mock.Mock
+}
+
+func (m *MockCollectionRepository) SaveCollection(ctx context.Context, collection domains.Collection) (domains.Collection, error) {
+	args := m.Called(ctx, collection)
+	return args.Get(0).(domains.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) GetCollectionById(ctx context.Context, collectionId string) (domains.Collection, error) {
+	args := m.Called(ctx, collectionId)
+	return args.Get(0).(domains.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) GetCollectionsByUserId(ctx context.Context, userId int64) ([]domains.Collection, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]domains.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) DeleteCollection(ctx context.Context, collectionId string) error {
+	args := m.Called(ctx, collectionId)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) ReplaceCollectionItems(ctx context.Context, collectionId string, productIds []uuid.UUID) error {
+	args := m.Called(ctx, collectionId, productIds)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) AddCollectionItem(ctx context.Context, collectionId, productId string) error {
+	args := m.Called(ctx, collectionId, productId)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) RemoveCollectionItem(ctx context.Context, collectionId, productId string) error {
+	args := m.Called(ctx, collectionId, productId)
+	return args.Error(0)
+}
@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockConversionRepository struct {
+	mock.Mock
+}
+
+func (m *MockConversionRepository) SaveConversions(ctx context.Context, conversions []domains.Conversion) error {
+	args := m.Called(ctx, conversions)
+	return args.Error(0)
+}
+
+func (m *MockConversionRepository) SavePostbackConversion(ctx context.Context, conversion domains.Conversion) error {
+	args := m.Called(ctx, conversion)
+	return args.Error(0)
+}
+
+func (m *MockConversionRepository) CountConversionsByDateRange(ctx context.Context, userId int64, startDate, endDate time.Time, granularity, timezone string) ([]dto.MetrictItem, error) {
+	args := m.Called(ctx, userId, startDate, endDate, granularity, timezone)
+	return args.Get(0).([]dto.MetrictItem), args.Error(1)
+}
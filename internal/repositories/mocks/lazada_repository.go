@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"github.com/market-place-affiliate/api/internal/repositories/marketplace"
 	"github.com/market-place-affiliate/commonlib/lazada"
 	"github.com/stretchr/testify/mock"
 )
@@ -18,3 +19,8 @@ func (m *MockLazadaRepository) GetBatchPromoteLink(cred lazada.LazadaCredentials
 	args := m.Called(cred, inputType, inputValue, sub)
 	return args.Get(0).(lazada.LazadaResponse[lazada.BatchPromoteLinkResponse]), args.Error(1)
 }
+
+func (m *MockLazadaRepository) GetConversionReport(cred lazada.LazadaCredentials, dateStart, dateEnd string, page, limit int) (marketplace.LazadaConversionReport, error) {
+	args := m.Called(cred, dateStart, dateEnd, page, limit)
+	return args.Get(0).(marketplace.LazadaConversionReport), args.Error(1)
+}
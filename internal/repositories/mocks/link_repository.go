@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/market-place-affiliate/api/internal/core/domains"
 	"github.com/stretchr/testify/mock"
@@ -21,6 +22,11 @@ func (m *MockLinkRepository) DeleteLink(ctx context.Context, linkId string) erro
 	return args.Error(0)
 }
 
+func (m *MockLinkRepository) PurgeDeletedLinks(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockLinkRepository) GetLinksByProductId(ctx context.Context, productId string) ([]domains.Link, error) {
 	args := m.Called(ctx, productId)
 	return args.Get(0).([]domains.Link), args.Error(1)
@@ -36,6 +42,11 @@ func (m *MockLinkRepository) GetLinkByShortCode(ctx context.Context, shortCode s
 	return args.Get(0).(domains.Link), args.Error(1)
 }
 
+func (m *MockLinkRepository) GetLinkByShortCodeWithDeleted(ctx context.Context, shortCode string) (domains.Link, error) {
+	args := m.Called(ctx, shortCode)
+	return args.Get(0).(domains.Link), args.Error(1)
+}
+
 func (m *MockLinkRepository) GetLinksByCampaignId(ctx context.Context, campaignId string) ([]domains.Link, error) {
 	args := m.Called(ctx, campaignId)
 	return args.Get(0).([]domains.Link), args.Error(1)
@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
+	"github.com/market-place-affiliate/api/pkg/export"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLinkService struct {
+	mock.Mock
+}
+
+func (m *MockLinkService) CreateLink(ctx context.Context, userId int64, link dto.CreateLinkRequest) (dto.Response[domains.Link], error) {
+	args := m.Called(ctx, userId, link)
+	return args.Get(0).(dto.Response[domains.Link]), args.Error(1)
+}
+
+func (m *MockLinkService) GetLinkByCampaign(ctx context.Context, campaignId string) (dto.Response[[]domains.Link], error) {
+	args := m.Called(ctx, campaignId)
+	return args.Get(0).(dto.Response[[]domains.Link]), args.Error(1)
+}
+
+func (m *MockLinkService) ClickByShortCode(ctx context.Context, shortCode string, click dto.ClickRequest) (dto.Response[domains.Link], error) {
+	args := m.Called(ctx, shortCode, click)
+	return args.Get(0).(dto.Response[domains.Link]), args.Error(1)
+}
+
+func (m *MockLinkService) DeleteLinkById(ctx context.Context, userId int64, linkId string) (dto.Response[any], error) {
+	args := m.Called(ctx, userId, linkId)
+	return args.Get(0).(dto.Response[any]), args.Error(1)
+}
+
+func (m *MockLinkService) GetLinkById(ctx context.Context, linkId string) (dto.Response[domains.Link], error) {
+	args := m.Called(ctx, linkId)
+	return args.Get(0).(dto.Response[domains.Link]), args.Error(1)
+}
+
+func (m *MockLinkService) GetLinkByShortCode(ctx context.Context, shortCode string) (dto.Response[domains.Link], error) {
+	args := m.Called(ctx, shortCode)
+	return args.Get(0).(dto.Response[domains.Link]), args.Error(1)
+}
+
+func (m *MockLinkService) RegenerateLink(ctx context.Context, userId int64, linkId string) (dto.Response[domains.Link], error) {
+	args := m.Called(ctx, userId, linkId)
+	return args.Get(0).(dto.Response[domains.Link]), args.Error(1)
+}
+
+func (m *MockLinkService) ExportCampaignLinks(ctx context.Context, userId int64, campaignId string, w export.Writer) (dto.Response[any], error) {
+	args := m.Called(ctx, userId, campaignId, w)
+	return args.Get(0).(dto.Response[any]), args.Error(1)
+}
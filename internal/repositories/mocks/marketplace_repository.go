@@ -25,3 +25,8 @@ func (m *MockMarketplaceRepository) DeleteByUserIdAndPlatform(ctx context.Contex
 	args := m.Called(ctx, userId, platform)
 	return args.Error(0)
 }
+
+func (m *MockMarketplaceRepository) ListCredentials(ctx context.Context) ([]domains.MarketplaceCredential, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domains.MarketplaceCredential), args.Error(1)
+}
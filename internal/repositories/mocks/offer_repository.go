@@ -26,6 +26,11 @@ func (m *MockOfferRepository) GetOffersByProductId(ctx context.Context, productI
 	return args.Get(0).(domains.Offer), args.Error(1)
 }
 
+func (m *MockOfferRepository) ListOffersByProductId(ctx context.Context, productId string) ([]domains.Offer, error) {
+	args := m.Called(ctx, productId)
+	return args.Get(0).([]domains.Offer), args.Error(1)
+}
+
 func (m *MockOfferRepository) GetOfferById(ctx context.Context, offerId string) (domains.Offer, error) {
 	args := m.Called(ctx, offerId)
 	return args.Get(0).(domains.Offer), args.Error(1)
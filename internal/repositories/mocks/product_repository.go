@@ -2,8 +2,10 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/market-place-affiliate/api/internal/core/dto"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -35,3 +37,33 @@ func (m *MockProductRepository) DeleteProductById(ctx context.Context, productId
 	args := m.Called(ctx, productId)
 	return args.Error(0)
 }
+
+func (m *MockProductRepository) GetProductByIdWithDeleted(ctx context.Context, productId string) (domains.Product, error) {
+	args := m.Called(ctx, productId)
+	return args.Get(0).(domains.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) RestoreProduct(ctx context.Context, productId string) error {
+	args := m.Called(ctx, productId)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) PurgeDeletedProducts(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductRepository) GetUnavailableProducts(ctx context.Context, userId int64) ([]domains.Product, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]domains.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) ReplaceProductImages(ctx context.Context, productId string, images []domains.ProductImage) error {
+	args := m.Called(ctx, productId, images)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) GetProductsByQuery(ctx context.Context, userId int64, query dto.GetProductsQueryRequest) ([]domains.Product, error) {
+	args := m.Called(ctx, userId, query)
+	return args.Get(0).([]domains.Product), args.Error(1)
+}
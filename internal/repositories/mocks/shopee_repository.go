@@ -1,6 +1,9 @@
 package mocks
 
 import (
+	"time"
+
+	"github.com/market-place-affiliate/api/internal/repositories/marketplace"
 	"github.com/market-place-affiliate/commonlib/shopee"
 	"github.com/stretchr/testify/mock"
 )
@@ -18,3 +21,8 @@ func (m *MockShopeeRepository) GetShortLink(cred shopee.ShopeeCredentials, origi
 	args := m.Called(cred, originalUrl, sub)
 	return args.Get(0).(shopee.ShopeeGetShortLink), args.Error(1)
 }
+
+func (m *MockShopeeRepository) GetConversionReport(cred shopee.ShopeeCredentials, start, end time.Time, limit int, scrollId string) (marketplace.ShopeeConversionReport, error) {
+	args := m.Called(cred, start, end, limit, scrollId)
+	return args.Get(0).(marketplace.ShopeeConversionReport), args.Error(1)
+}
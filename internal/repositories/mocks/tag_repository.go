@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/market-place-affiliate/api/internal/core/domains"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) SaveTag(ctx context.Context, tag domains.Tag) (domains.Tag, error) {
+	args := m.Called(ctx, tag)
+	return args.Get(0).(domains.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) GetTagById(ctx context.Context, tagId string) (domains.Tag, error) {
+	args := m.Called(ctx, tagId)
+	return args.Get(0).(domains.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) GetTagsByUserId(ctx context.Context, userId int64) ([]domains.Tag, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]domains.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) DeleteTag(ctx context.Context, tagId string) error {
+	args := m.Called(ctx, tagId)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) AddProductTag(ctx context.Context, productId, tagId string) error {
+	args := m.Called(ctx, productId, tagId)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) RemoveProductTag(ctx context.Context, productId, tagId string) error {
+	args := m.Called(ctx, productId, tagId)
+	return args.Error(0)
+}
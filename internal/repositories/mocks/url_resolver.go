@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUrlResolver struct {
+	mock.Mock
+}
+
+func (m *MockUrlResolver) Resolve(ctx context.Context, rawUrl string) (string, error) {
+	args := m.Called(ctx, rawUrl)
+	return args.String(0), args.Error(1)
+}
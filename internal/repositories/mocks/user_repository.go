@@ -21,6 +21,11 @@ func (m *MockUserRepository) GetUserByID(ctx context.Context, userId int64) (dom
 	return args.Get(0).(domains.User), args.Error(1)
 }
 
+func (m *MockUserRepository) UpdateUser(ctx context.Context, user domains.User) (domains.User, error) {
+	args := m.Called(ctx, user)
+	return args.Get(0).(domains.User), args.Error(1)
+}
+
 func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (domains.User, error) {
 	args := m.Called(ctx, email)
 	return args.Get(0).(domains.User), args.Error(1)
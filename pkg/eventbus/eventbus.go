@@ -0,0 +1,72 @@
+package eventbus
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// Handler receives the payload published on a topic.
+type Handler func(ctx context.Context, payload any)
+
+// Bus is an in-process publish/subscribe bus. Handlers run synchronously, in subscription
+// order, on the goroutine that publishes.
+type Bus struct {
+	mu       sync.RWMutex
+	nextId   int
+	handlers map[string]map[int]Handler
+	order    map[string][]int
+}
+
+func New() *Bus {
+	return &Bus{
+		handlers: map[string]map[int]Handler{},
+		order:    map[string][]int{},
+	}
+}
+
+// Subscribe registers handler for topic and returns a function that removes it.
+func (b *Bus) Subscribe(topic string, handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextId
+	b.nextId++
+	if b.handlers[topic] == nil {
+		b.handlers[topic] = map[int]Handler{}
+	}
+	b.handlers[topic][id] = handler
+	b.order[topic] = append(b.order[topic], id)
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers[topic], id)
+		for i, v := range b.order[topic] {
+			if v == id {
+				b.order[topic] = append(b.order[topic][:i], b.order[topic][i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Publish calls every handler subscribed to topic. A panicking handler is logged and does not
+// stop the others.
+func (b *Bus) Publish(ctx context.Context, topic string, payload any) {
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.order[topic]))
+	for _, id := range b.order[topic] {
+		handlers = append(handlers, b.handlers[topic][id])
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("eventbus: handler for %s panicked: %v\n", topic, r)
+				}
+			}()
+			handler(ctx, payload)
+		}()
+	}
+}
@@ -0,0 +1,99 @@
+package eventbus
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// relayBuffer is how many events may wait to be sent to Redis before further ones are dropped.
+// Publishers never wait for Redis.
+const relayBuffer = 1024
+
+// Decoder turns a payload relayed from another instance back into the type published there.
+type Decoder func(data []byte) (any, error)
+
+// DecodeAs decodes relayed payloads as T.
+func DecodeAs[T any]() Decoder {
+	return func(data []byte) (any, error) {
+		var payload T
+		err := json.Unmarshal(data, &payload)
+		return payload, err
+	}
+}
+
+type relayedKey struct{}
+
+type envelope struct {
+	Origin  string          `json:"origin"`
+	Topic   string          `json:"topic"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Relay joins bus to the buses of other instances through a Redis pub/sub channel: events
+// published on bus on one of topics are sent to the channel as JSON, and events sent by other
+// instances are published on bus, decoded by their topic's Decoder. It returns when ctx is
+// done or the channel cannot be subscribed to.
+func Relay(ctx context.Context, bus *Bus, client *redis.Client, channel string, topics map[string]Decoder) error {
+	pubsub := client.Subscribe(ctx, channel)
+	defer pubsub.Close()
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("subscribe to %s: %w", channel, err)
+	}
+	origin := rand.Text()
+
+	outgoing := make(chan envelope, relayBuffer)
+	for topic := range topics {
+		unsubscribe := bus.Subscribe(topic, func(ctx context.Context, payload any) {
+			if ctx.Value(relayedKey{}) != nil {
+				return
+			}
+			data, err := json.Marshal(payload)
+			if err != nil {
+				log.Printf("eventbus: cannot relay %s: %v\n", topic, err)
+				return
+			}
+			select {
+			case outgoing <- envelope{Origin: origin, Topic: topic, Payload: data}:
+			default:
+				log.Printf("eventbus: relay of %s is behind, event dropped\n", topic)
+			}
+		})
+		defer unsubscribe()
+	}
+
+	incoming := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-outgoing:
+			message, _ := json.Marshal(event)
+			if err := client.Publish(ctx, channel, message).Err(); err != nil {
+				log.Printf("eventbus: cannot relay %s: %v\n", event.Topic, err)
+			}
+		case message, ok := <-incoming:
+			if !ok {
+				return nil
+			}
+			var event envelope
+			if err := json.Unmarshal([]byte(message.Payload), &event); err != nil || event.Origin == origin {
+				continue
+			}
+			decode, ok := topics[event.Topic]
+			if !ok {
+				continue
+			}
+			payload, err := decode(event.Payload)
+			if err != nil {
+				log.Printf("eventbus: cannot decode relayed %s: %v\n", event.Topic, err)
+				continue
+			}
+			bus.Publish(context.WithValue(ctx, relayedKey{}, true), event.Topic, payload)
+		}
+	}
+}
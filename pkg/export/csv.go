@@ -0,0 +1,47 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// csvWriter writes every table to the same CSV file, separated by an empty line.
+type csvWriter struct {
+	w      *csv.Writer
+	tables int
+	record []string
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Sheet(name string) error {
+	c.tables++
+	if c.tables == 1 {
+		return nil
+	}
+	return c.w.Write(nil)
+}
+
+func (c *csvWriter) WriteRow(values ...any) error {
+	if c.tables == 0 {
+		c.tables = 1
+	}
+	c.record = c.record[:0]
+	for _, value := range values {
+		text, number := cell(value)
+		if !number && text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+			// Keep spreadsheets from evaluating text such as product titles as formulas.
+			text = "'" + text
+		}
+		c.record = append(c.record, text)
+	}
+	return c.w.Write(c.record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,73 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Export formats.
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Writer writes tables a row at a time to an io.Writer, so large exports are streamed rather
+// than built in memory. Output is buffered a few kilobytes at a time.
+type Writer interface {
+	// Sheet starts a new table. Rows written before the first Sheet go to a table called Sheet1.
+	Sheet(name string) error
+	// WriteRow writes a row of strings, integers, floats, *float64, bools, times or
+	// fmt.Stringers. Nil values are left blank.
+	WriteRow(values ...any) error
+	// Close writes whatever is buffered. An XLSX workbook is only complete once closed.
+	Close() error
+}
+
+// NewWriter returns a Writer of format, FormatCSV or FormatXLSX.
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w), nil
+	}
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+// ContentType is the media type of format.
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// cell formats value and reports whether it is a number.
+func cell(value any) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, false
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case *float64:
+		if v == nil {
+			return "", false
+		}
+		return cell(*v)
+	case bool:
+		return strconv.FormatBool(v), false
+	case time.Time:
+		return v.Format(time.RFC3339), false
+	case fmt.Stringer:
+		return v.String(), false
+	}
+	return fmt.Sprint(value), false
+}
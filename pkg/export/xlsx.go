@@ -0,0 +1,138 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// xlsxWriter writes a minimal Office Open XML workbook. Each sheet is a zip entry that is
+// deflated as its rows are written; the workbook parts listing the sheets are written on Close.
+// Text is stored inline rather than in a shared strings table, which would have to be buffered.
+type xlsxWriter struct {
+	zip    *zip.Writer
+	sheets []string
+	sheet  io.Writer
+	rows   int
+	buf    strings.Builder
+}
+
+func newXLSXWriter(w io.Writer) *xlsxWriter {
+	return &xlsxWriter{zip: zip.NewWriter(w)}
+}
+
+func (x *xlsxWriter) Sheet(name string) error {
+	err := x.endSheet()
+	if err != nil {
+		return err
+	}
+	x.sheets = append(x.sheets, name)
+	x.sheet, err = x.zip.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", len(x.sheets)))
+	if err != nil {
+		return err
+	}
+	x.rows = 0
+	_, err = io.WriteString(x.sheet, xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return err
+}
+
+func (x *xlsxWriter) WriteRow(values ...any) error {
+	if x.sheet == nil {
+		err := x.Sheet("Sheet1")
+		if err != nil {
+			return err
+		}
+	}
+	x.rows++
+	x.buf.Reset()
+	fmt.Fprintf(&x.buf, `<row r="%d">`, x.rows)
+	for _, value := range values {
+		text, number := cell(value)
+		switch {
+		case text == "":
+			x.buf.WriteString(`<c/>`)
+		case number:
+			x.buf.WriteString(`<c><v>` + text + `</v></c>`)
+		default:
+			x.buf.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+			xml.EscapeText(&x.buf, []byte(text))
+			x.buf.WriteString(`</t></is></c>`)
+		}
+	}
+	x.buf.WriteString(`</row>`)
+	_, err := io.WriteString(x.sheet, x.buf.String())
+	return err
+}
+
+func (x *xlsxWriter) endSheet() error {
+	if x.sheet == nil {
+		return nil
+	}
+	_, err := io.WriteString(x.sheet, `</sheetData></worksheet>`)
+	x.sheet = nil
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	if len(x.sheets) == 0 {
+		// A workbook needs at least one sheet.
+		err := x.Sheet("Sheet1")
+		if err != nil {
+			return err
+		}
+	}
+	err := x.endSheet()
+	if err != nil {
+		return err
+	}
+	var types, sheets, rels strings.Builder
+	for n, name := range x.sheets {
+		fmt.Fprintf(&types, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n+1)
+		fmt.Fprintf(&sheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, sheetName(name), n+1, n+1)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n+1, n+1)
+	}
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			types.String() + `</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + sheets.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			rels.String() + `</Relationships>`},
+	}
+	for _, part := range parts {
+		w, err := x.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, xml.Header+part.content)
+		if err != nil {
+			return err
+		}
+	}
+	return x.zip.Close()
+}
+
+// sheetName escapes name and removes what Excel does not allow in sheet names, keeping the
+// first 31 characters.
+func sheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(name))
+	return escaped.String()
+}
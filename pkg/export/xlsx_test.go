@@ -0,0 +1,129 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type xlsxCell struct {
+	Type   string `xml:"t,attr"`
+	Value  string `xml:"v"`
+	Inline string `xml:"is>t"`
+}
+
+type xlsxRow struct {
+	R     int        `xml:"r,attr"`
+	Cells []xlsxCell `xml:"c"`
+}
+
+// readXLSX opens a workbook written by xlsxWriter and returns its parts by name.
+func readXLSX(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	parts := map[string][]byte{}
+	for _, file := range archive.File {
+		r, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+		parts[file.Name] = content
+	}
+	return parts
+}
+
+func readRows(t *testing.T, sheet []byte) []xlsxRow {
+	t.Helper()
+	var worksheet struct {
+		Rows []xlsxRow `xml:"sheetData>row"`
+	}
+	require.NoError(t, xml.Unmarshal(sheet, &worksheet))
+	return worksheet.Rows
+}
+
+func TestXLSXWriter_Parts(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(FormatXLSX, &out)
+	require.NoError(t, err)
+	require.NoError(t, w.Sheet("Summary"))
+	require.NoError(t, w.WriteRow("clicks", 12))
+	require.NoError(t, w.Sheet("Clicks: <by day> & more [2026] of a very long campaign"))
+	require.NoError(t, w.WriteRow("2026-03-01", 5))
+	require.NoError(t, w.Close())
+
+	parts := readXLSX(t, out.Bytes())
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		assert.Contains(t, parts, name)
+	}
+	assert.Len(t, parts, 6)
+	for name, content := range parts {
+		assert.NoError(t, xml.Unmarshal(content, new(struct{})), name)
+	}
+	assert.Contains(t, string(parts["[Content_Types].xml"]), `PartName="/xl/worksheets/sheet2.xml"`)
+	assert.Contains(t, string(parts["xl/_rels/workbook.xml.rels"]), `Target="worksheets/sheet2.xml"`)
+
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	require.NoError(t, xml.Unmarshal(parts["xl/workbook.xml"], &workbook))
+	if assert.Len(t, workbook.Sheets, 2) {
+		assert.Equal(t, "Summary", workbook.Sheets[0].Name)
+		assert.Equal(t, "Clicks <by day> & more 2026 of ", workbook.Sheets[1].Name)
+	}
+}
+
+func TestXLSXWriter_EmptyWorkbook(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(FormatXLSX, &out)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	parts := readXLSX(t, out.Bytes())
+
+	assert.Contains(t, parts, "xl/worksheets/sheet1.xml")
+	assert.Contains(t, string(parts["xl/workbook.xml"]), `<sheet name="Sheet1"`)
+	assert.Empty(t, readRows(t, parts["xl/worksheets/sheet1.xml"]))
+}
+
+func TestXLSXWriter_Cells(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(FormatXLSX, &out)
+	require.NoError(t, err)
+	rate := 0.075
+	var missing *float64
+	require.NoError(t, w.WriteRow("title", "sales", "rate"))
+	require.NoError(t, w.WriteRow(`Earbuds <Pro> & "Max" 'X'`, 1200, rate, missing, nil, "", true,
+		int64(-3), 12.5, time.Date(2026, 3, 1, 7, 30, 0, 0, time.UTC), "  padded  ", "=1+1"))
+	require.NoError(t, w.Close())
+
+	rows := readRows(t, readXLSX(t, out.Bytes())["xl/worksheets/sheet1.xml"])
+
+	require.Len(t, rows, 2)
+	assert.Equal(t, 1, rows[0].R)
+	assert.Equal(t, 2, rows[1].R)
+	assert.Equal(t, []xlsxCell{
+		{Type: "inlineStr", Inline: `Earbuds <Pro> & "Max" 'X'`},
+		{Value: "1200"},
+		{Value: "0.075"},
+		{},
+		{},
+		{},
+		{Type: "inlineStr", Inline: "true"},
+		{Value: "-3"},
+		{Value: "12.5"},
+		{Type: "inlineStr", Inline: "2026-03-01T07:30:00Z"},
+		{Type: "inlineStr", Inline: "  padded  "},
+		{Type: "inlineStr", Inline: "=1+1"},
+	}, rows[1].Cells)
+}
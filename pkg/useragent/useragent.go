@@ -0,0 +1,120 @@
+// Package useragent classifies the device, operating system and browser of a User-Agent header
+// into the few values analytics group clicks by. It recognises the in-app browsers of the social
+// apps shoppers mostly arrive from, which often send no referrer.
+package useragent
+
+import "strings"
+
+// Device types.
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceBot     = "bot"
+)
+
+// Operating systems.
+const (
+	OsAndroid  = "Android"
+	OsIOS      = "iOS"
+	OsWindows  = "Windows"
+	OsMacOS    = "macOS"
+	OsChromeOS = "ChromeOS"
+	OsLinux    = "Linux"
+	OsOther    = "Other"
+)
+
+// Browsers, including the in-app browsers of social apps.
+const (
+	BrowserFacebook  = "Facebook"
+	BrowserInstagram = "Instagram"
+	BrowserTikTok    = "TikTok"
+	BrowserLine      = "LINE"
+	BrowserSamsung   = "Samsung Internet"
+	BrowserEdge      = "Edge"
+	BrowserOpera     = "Opera"
+	BrowserFirefox   = "Firefox"
+	BrowserChrome    = "Chrome"
+	BrowserSafari    = "Safari"
+	BrowserOther     = "Other"
+)
+
+// Agent is what a User-Agent header tells about the visitor. Every field is empty for an empty
+// header.
+type Agent struct {
+	Device  string
+	Os      string
+	Browser string
+}
+
+type token struct {
+	substrings []string
+	value      string
+}
+
+// The first matching token wins, so in-app browsers come before the browsers whose tokens they
+// also carry, and every browser before Chrome and Safari.
+var browsers = []token{
+	{[]string{"FBAN/", "FBAV/", "FB_IAB/"}, BrowserFacebook},
+	{[]string{"Instagram"}, BrowserInstagram},
+	{[]string{"musical_ly", "BytedanceWebview", "TikTok"}, BrowserTikTok},
+	{[]string{" Line/"}, BrowserLine},
+	{[]string{"SamsungBrowser/"}, BrowserSamsung},
+	{[]string{"Edg/", "EdgA/", "EdgiOS/"}, BrowserEdge},
+	{[]string{"OPR/", "Opera"}, BrowserOpera},
+	{[]string{"Firefox/", "FxiOS/"}, BrowserFirefox},
+	{[]string{"Chrome/", "CriOS/"}, BrowserChrome},
+	{[]string{"Safari/"}, BrowserSafari},
+}
+
+// iPads and iPhones also claim to be "like Mac OS X", and Android to be Linux.
+var systems = []token{
+	{[]string{"Windows"}, OsWindows},
+	{[]string{"iPhone", "iPad", "iPod"}, OsIOS},
+	{[]string{"Android"}, OsAndroid},
+	{[]string{"CrOS"}, OsChromeOS},
+	{[]string{"Macintosh", "Mac OS X"}, OsMacOS},
+	{[]string{"Linux"}, OsLinux},
+}
+
+var bots = []string{"bot", "crawler", "spider", "facebookexternalhit", "curl/", "wget/", "python-requests", "go-http-client", "headless"}
+
+// Parse classifies header.
+func Parse(header string) Agent {
+	if header == "" {
+		return Agent{}
+	}
+	return Agent{
+		Device:  device(header),
+		Os:      match(header, systems, OsOther),
+		Browser: match(header, browsers, BrowserOther),
+	}
+}
+
+func device(header string) string {
+	lower := strings.ToLower(header)
+	for _, bot := range bots {
+		if strings.Contains(lower, bot) {
+			return DeviceBot
+		}
+	}
+	switch {
+	case strings.Contains(header, "iPad"), strings.Contains(header, "Tablet"),
+		strings.Contains(header, "Android") && !strings.Contains(header, "Mobile"):
+		return DeviceTablet
+	case strings.Contains(header, "Mobi"), strings.Contains(header, "iPhone"), strings.Contains(header, "iPod"):
+		return DeviceMobile
+	}
+	return DeviceDesktop
+}
+
+func match(header string, tokens []token, other string) string {
+	for _, t := range tokens {
+		for _, substring := range t.substrings {
+			if strings.Contains(header, substring) {
+				return t.value
+			}
+		}
+	}
+	return other
+}
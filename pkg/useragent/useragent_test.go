@@ -0,0 +1,146 @@
+package useragent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   Agent
+	}{
+		{
+			name:   "empty",
+			header: "",
+			want:   Agent{},
+		},
+		{
+			name:   "unknown",
+			header: "SomeClient",
+			want:   Agent{Device: DeviceDesktop, Os: OsOther, Browser: BrowserOther},
+		},
+		{
+			name:   "facebook app on iphone",
+			header: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 [FBAN/FBIOS;FBAV/456.0.0.41.109;FBBV/590131112]",
+			want:   Agent{Device: DeviceMobile, Os: OsIOS, Browser: BrowserFacebook},
+		},
+		{
+			name:   "facebook app on android",
+			header: "Mozilla/5.0 (Linux; Android 14; SM-S918B Build/UP1A.231005.007; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/124.0.6367.82 Mobile Safari/537.36 [FB_IAB/FB4A;FBAV/462.0.0.42.108;]",
+			want:   Agent{Device: DeviceMobile, Os: OsAndroid, Browser: BrowserFacebook},
+		},
+		{
+			name:   "instagram app on iphone",
+			header: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 Instagram 327.0.2.30.93 (iPhone15,3; iOS 17_4_1; th_TH; th; scale=3.00; 1290x2796; 584305455)",
+			want:   Agent{Device: DeviceMobile, Os: OsIOS, Browser: BrowserInstagram},
+		},
+		{
+			name:   "tiktok app on android",
+			header: "Mozilla/5.0 (Linux; Android 13; 22101316G Build/TP1A.220624.014; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/123.0.6312.118 Mobile Safari/537.36 trill_340103 JsSdk/1.0 NetType/WIFI Channel/googleplay AppName/trill app_version/34.1.3 ByteLocale/th ByteFullLocale/th Region/TH BytedanceWebview/d8a21c6",
+			want:   Agent{Device: DeviceMobile, Os: OsAndroid, Browser: BrowserTikTok},
+		},
+		{
+			name:   "line app on iphone",
+			header: "Mozilla/5.0 (iPhone; CPU iPhone OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 Safari Line/14.5.0",
+			want:   Agent{Device: DeviceMobile, Os: OsIOS, Browser: BrowserLine},
+		},
+		{
+			name:   "samsung internet",
+			header: "Mozilla/5.0 (Linux; Android 14; SAMSUNG SM-A546E) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/24.0 Chrome/117.0.0.0 Mobile Safari/537.36",
+			want:   Agent{Device: DeviceMobile, Os: OsAndroid, Browser: BrowserSamsung},
+		},
+		{
+			name:   "edge on windows",
+			header: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.2478.51",
+			want:   Agent{Device: DeviceDesktop, Os: OsWindows, Browser: BrowserEdge},
+		},
+		{
+			name:   "opera on windows",
+			header: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 OPR/110.0.0.0",
+			want:   Agent{Device: DeviceDesktop, Os: OsWindows, Browser: BrowserOpera},
+		},
+		{
+			name:   "firefox on linux",
+			header: "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0",
+			want:   Agent{Device: DeviceDesktop, Os: OsLinux, Browser: BrowserFirefox},
+		},
+		{
+			name:   "firefox on iphone",
+			header: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) FxiOS/125.0 Mobile/15E148 Safari/605.1.15",
+			want:   Agent{Device: DeviceMobile, Os: OsIOS, Browser: BrowserFirefox},
+		},
+		{
+			name:   "chrome on mac",
+			header: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			want:   Agent{Device: DeviceDesktop, Os: OsMacOS, Browser: BrowserChrome},
+		},
+		{
+			name:   "chrome on ipad",
+			header: "Mozilla/5.0 (iPad; CPU OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/124.0.6367.88 Mobile/15E148 Safari/604.1",
+			want:   Agent{Device: DeviceTablet, Os: OsIOS, Browser: BrowserChrome},
+		},
+		{
+			name:   "chrome on android tablet",
+			header: "Mozilla/5.0 (Linux; Android 14; SM-X710) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			want:   Agent{Device: DeviceTablet, Os: OsAndroid, Browser: BrowserChrome},
+		},
+		{
+			name:   "chrome on chromebook",
+			header: "Mozilla/5.0 (X11; CrOS x86_64 14541.0.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			want:   Agent{Device: DeviceDesktop, Os: OsChromeOS, Browser: BrowserChrome},
+		},
+		{
+			name:   "safari on iphone",
+			header: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+			want:   Agent{Device: DeviceMobile, Os: OsIOS, Browser: BrowserSafari},
+		},
+		{
+			name:   "safari on ipod",
+			header: "Mozilla/5.0 (iPod touch; CPU iPhone OS 12_5_7 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/12.1.2 Safari/604.1",
+			want:   Agent{Device: DeviceMobile, Os: OsIOS, Browser: BrowserSafari},
+		},
+		{
+			name:   "safari on mac",
+			header: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15",
+			want:   Agent{Device: DeviceDesktop, Os: OsMacOS, Browser: BrowserSafari},
+		},
+		{
+			name:   "windows tablet",
+			header: "Mozilla/5.0 (Windows NT 10.0; Win64; x64; Tablet PC 2.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			want:   Agent{Device: DeviceTablet, Os: OsWindows, Browser: BrowserChrome},
+		},
+		{
+			name:   "googlebot",
+			header: "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			want:   Agent{Device: DeviceBot, Os: OsOther, Browser: BrowserOther},
+		},
+		{
+			name:   "facebook link preview",
+			header: "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)",
+			want:   Agent{Device: DeviceBot, Os: OsOther, Browser: BrowserOther},
+		},
+		{
+			name:   "headless chrome",
+			header: "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/124.0.0.0 Safari/537.36",
+			want:   Agent{Device: DeviceBot, Os: OsLinux, Browser: BrowserChrome},
+		},
+		{
+			name:   "curl",
+			header: "curl/8.5.0",
+			want:   Agent{Device: DeviceBot, Os: OsOther, Browser: BrowserOther},
+		},
+		{
+			name:   "go http client",
+			header: "Go-http-client/1.1",
+			want:   Agent{Device: DeviceBot, Os: OsOther, Browser: BrowserOther},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.header))
+		})
+	}
+}